@@ -1,23 +1,90 @@
 package aastocks
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 )
 
+// HongKong time zone, which times of AAStocks are wall clock of. It has no daylight saving time.
+var HongKong = time.FixedZone("HKT", 8*60*60)
+
+// HongKongTime converts wall clock parsed from AAStocks, which is labelled as UTC, to the same wall clock in HongKong.
+// Zero time is kept as zero.
+func HongKongTime(wall time.Time) time.Time {
+	if wall.IsZero() {
+		return wall
+	}
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), HongKong)
+}
+
+// WallClock converts time to wall clock of HongKong labelled as UTC, as times parsed from AAStocks.
+// It is the inverse of HongKongTime, zero time is kept as zero.
+func WallClock(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	t = t.In(HongKong)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// ErrSymbolNotFound is returned when symbol cannot be found in AAStocks.
+var ErrSymbolNotFound = errors.New("Symbol cannot be found")
+
+// ParseError is returned when field of data from AAStocks cannot be parsed, which is likely caused by change of its pages.
+type ParseError struct {
+	// Field named as JSON field name (i.e. "pe_ratio")
+	Field string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// StatusError is returned when AAStocks responds with status other than 200 OK, which is likely temporary.
+type StatusError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *StatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// checkStatus returns StatusError if response is not 200 OK, with description of data fetched.
+func checkStatus(resp *http.Response, data string) error {
+	if resp.StatusCode != http.StatusOK {
+		return &StatusError{StatusCode: resp.StatusCode, Err: fmt.Errorf("Failed to fetch %v: %v", data, resp.Status)}
+	}
+	return nil
+}
+
 // Quote of AAStocks data
 type Quote struct {
-	Symbol       string
-	Name         string
-	Price        float64
-	Price52WLow  float64
-	Price52WHigh float64
-	Yield        float64
-	PeRatio      float64
-	PbRatio      float64
-	Lots         int
-	Eps          float64
-	UpdateTime   time.Time
+	Symbol       string    `json:"symbol"`
+	Name         string    `json:"name"`
+	Industry     string    `json:"industry"`
+	Price        float64   `json:"price"`
+	PrevClose    float64   `json:"prev_close"`
+	Price52WLow  float64   `json:"price_52w_low"`
+	Price52WHigh float64   `json:"price_52w_high"`
+	Yield        float64   `json:"yield"`
+	PeRatio      float64   `json:"pe_ratio"`
+	PbRatio      float64   `json:"pb_ratio"`
+	Lots         int       `json:"lots"`
+	Eps          float64   `json:"eps"`
+	UpdateTime   time.Time `json:"update_time"`
 
 	client *http.Client
 }
@@ -31,7 +98,7 @@ func Get(symbol string, opts ...Option) (*Quote, error) {
 	for _, opt := range opts {
 		opt(q)
 	}
-	return q, q.details()
+	return q, q.details(context.Background())
 }
 
 func defaultClient() *http.Client {
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -112,3 +113,23 @@ func checkErrorFunc(t *testing.T, expectedError error, f errorFunc) {
 		}
 	}
 }
+
+func TestHongKongTime(t *testing.T) {
+	wall := time.Date(2020, 8, 25, 21, 18, 38, 0, time.UTC)
+	hk := HongKongTime(wall)
+	expected := time.Date(2020, 8, 25, 13, 18, 38, 0, time.UTC)
+	if !hk.Equal(expected) {
+		t.Fatalf("expected %v to be %v", hk, expected)
+	}
+	diff := cmp.Diff(wall, WallClock(hk))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff(wall, WallClock(expected))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	if !HongKongTime(time.Time{}).IsZero() || !WallClock(time.Time{}).IsZero() {
+		t.Fatalf("expected zero time to be kept")
+	}
+}
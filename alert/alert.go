@@ -0,0 +1,221 @@
+// Package alert evaluates alert rules against quotes from AAStocks.
+//
+// Rules are evaluated against fields of quotes (i.e. price, 52 week range, yield and PE ratio),
+// which are usually refreshed by price stream. Rules can be loaded from JSON or YAML file.
+// Custom rules can be written as expressions of package expr.
+//
+//	rules, err := alert.LoadFile("rules.json")
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	engine, err := alert.NewEngine(rules)
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	stream := quote.StreamPrices(ctx, 5*time.Second)
+//	for a := range engine.Watch(ctx, stream.C()) {
+//		if a.Err != nil {
+//			logger.Printf("error: %v\n", a.Err)
+//			continue
+//		}
+//		logger.Printf("alert: %v\n", a.Rule.Name)
+//	}
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/expr"
+)
+
+// AlertEvent is fired when rule is triggered.
+type AlertEvent struct {
+	Rule Rule
+	// Value compared by the rule (i.e. price, or change from previous close), it is 1 for expression rules.
+	Value float64
+	Quote *aastocks.Quote
+	Time  time.Time
+	// Err of expression rule failed to be evaluated against the quote, the rule is not triggered if it is set.
+	Err error
+}
+
+type ruleState struct {
+	rule      Rule
+	condition func(q *aastocks.Quote) (condition, bool, error)
+	evaluated bool
+	armed     bool
+	fired     time.Time
+}
+
+// Engine evaluates rules against quotes, with states of rules for hysteresis and cooldown.
+// It is safe for concurrent use.
+type Engine struct {
+	mu      sync.Mutex
+	rules   []*ruleState
+	history map[string][]aastocks.HistoricalPrice
+	now     func() time.Time
+}
+
+// NewEngine creates engine with rules.
+func NewEngine(rules []Rule) (*Engine, error) {
+	e := &Engine{
+		rules:   make([]*ruleState, 0, len(rules)),
+		history: make(map[string][]aastocks.HistoricalPrice),
+		now:     time.Now,
+	}
+	for _, r := range rules {
+		err := e.Add(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Add rule to the engine.
+func (e *Engine) Add(r Rule) error {
+	err := r.Validate()
+	if err != nil {
+		return err
+	}
+	s := &ruleState{rule: r}
+	if r.Kind == Expression {
+		p, err := expr.CompileBool(r.Expr)
+		if err != nil {
+			return err
+		}
+		s.condition = e.expression(p)
+	} else {
+		c := conditions[r.Kind]
+		s.condition = func(q *aastocks.Quote) (condition, bool, error) {
+			c, ok := c(r, q)
+			return c, ok, nil
+		}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules = append(e.rules, s)
+	return nil
+}
+
+// SetHistory sets historical prices of the symbol, for expression rules using indicators.
+// Expression rules are not evaluated until historical prices are sufficient for them.
+func (e *Engine) SetHistory(symbol string, prices []aastocks.HistoricalPrice) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.history[symbol] = prices
+}
+
+// expression returns condition of the program, which is met with metric of 1 when it is true.
+// The condition is not evaluated without error if historical prices are insufficient.
+// The condition must be evaluated with lock held.
+func (e *Engine) expression(p *expr.Program) func(q *aastocks.Quote) (condition, bool, error) {
+	return func(q *aastocks.Quote) (condition, bool, error) {
+		ok, err := p.EvalBool(expr.Env{Quote: q, Prices: e.history[q.Symbol]})
+		if errors.Is(err, expr.ErrInsufficient) {
+			return condition{}, false, nil
+		}
+		if err != nil {
+			return condition{}, false, err
+		}
+		c := condition{threshold: 1, above: true, inclusive: true}
+		if ok {
+			c.metric = 1
+		}
+		return c, true, nil
+	}
+}
+
+// Evaluate rules of the quote symbol, and returns alerts fired.
+// Expression rules failed to be evaluated are returned as alerts with Err, which are not fired.
+func (e *Engine) Evaluate(q *aastocks.Quote) []AlertEvent {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := e.now()
+	alerts := make([]AlertEvent, 0)
+	for _, s := range e.rules {
+		if s.rule.Symbol != q.Symbol {
+			continue
+		}
+		c, ok, err := s.condition(q)
+		if err != nil {
+			alerts = append(alerts, AlertEvent{
+				Rule:  s.rule,
+				Quote: q,
+				Time:  now,
+				Err:   fmt.Errorf("Rule %q failed to be evaluated: %w", s.rule.Name, err),
+			})
+			continue
+		}
+		if !ok {
+			continue
+		}
+		first := !s.evaluated
+		s.evaluated = true
+		if !c.met() {
+			if first || c.rearmed(s.rule.Hysteresis) {
+				s.armed = true
+			}
+			continue
+		}
+		if first && c.primed {
+			// Crossing cannot be determined without previous value
+			continue
+		}
+		if first {
+			s.armed = true
+		}
+		if !s.armed {
+			continue
+		}
+		if !s.fired.IsZero() && now.Sub(s.fired) < time.Duration(s.rule.Cooldown) {
+			continue
+		}
+		s.armed = false
+		s.fired = now
+		alerts = append(alerts, AlertEvent{
+			Rule:  s.rule,
+			Value: c.metric,
+			Quote: q,
+			Time:  now,
+		})
+	}
+	return alerts
+}
+
+// Watch price events, and evaluates rules for quotes of them.
+// Channel of alerts is closed when price events are closed or context is done.
+func (e *Engine) Watch(ctx context.Context, events <-chan aastocks.PriceEvent) <-chan AlertEvent {
+	alerts := make(chan AlertEvent)
+	go func() {
+		defer close(alerts)
+		for {
+			var event aastocks.PriceEvent
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok = <-events:
+				if !ok {
+					return
+				}
+			}
+			if event.Err != nil || event.Quote == nil {
+				continue
+			}
+			for _, a := range e.Evaluate(event.Quote) {
+				select {
+				case <-ctx.Done():
+					return
+				case alerts <- a:
+				}
+			}
+		}
+	}()
+	return alerts
+}
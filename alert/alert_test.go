@@ -0,0 +1,223 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+func TestEngineEvaluate(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		rule   Rule
+		quotes []aastocks.Quote
+		// fired is whether alert is fired for each quote
+		fired []bool
+	}{
+		{
+			desc: "PriceCrossAbove",
+			rule: Rule{Symbol: "00006", Kind: PriceCrossAbove, Value: 45},
+			quotes: []aastocks.Quote{
+				{Price: 44.65}, {Price: 45.1}, {Price: 45.3}, {Price: 44.9}, {Price: 45.2},
+			},
+			fired: []bool{false, true, false, false, true},
+		},
+		{
+			desc: "PriceCrossAboveWhenAlreadyAbove",
+			rule: Rule{Symbol: "00006", Kind: PriceCrossAbove, Value: 45},
+			quotes: []aastocks.Quote{
+				{Price: 45.5}, {Price: 45.6},
+			},
+			fired: []bool{false, false},
+		},
+		{
+			desc: "PriceCrossBelowWithHysteresis",
+			rule: Rule{Symbol: "00006", Kind: PriceCrossBelow, Value: 44, Hysteresis: 0.5},
+			quotes: []aastocks.Quote{
+				{Price: 44.2}, {Price: 43.9}, {Price: 44.1}, {Price: 43.9}, {Price: 44.6}, {Price: 43.8},
+			},
+			fired: []bool{false, true, false, false, false, true},
+		},
+		{
+			desc: "ChangeAbove",
+			rule: Rule{Symbol: "00006", Kind: ChangeAbove, Value: 0.05},
+			quotes: []aastocks.Quote{
+				{Price: 44.2, PrevClose: 44.2}, {Price: 41.8, PrevClose: 44.2}, {Price: 41.0, PrevClose: 44.2},
+			},
+			fired: []bool{false, true, false},
+		},
+		{
+			desc: "ChangeAboveOnFirstQuote",
+			rule: Rule{Symbol: "00006", Kind: ChangeAbove, Value: 0.05},
+			quotes: []aastocks.Quote{
+				{Price: 47, PrevClose: 44.2},
+			},
+			fired: []bool{true},
+		},
+		{
+			desc: "New52WHigh",
+			rule: Rule{Symbol: "00006", Kind: New52WHigh},
+			quotes: []aastocks.Quote{
+				{Price: 58, Price52WHigh: 58.5}, {Price: 58.5, Price52WHigh: 58.5}, {Price: 59, Price52WHigh: 59},
+			},
+			fired: []bool{false, true, false},
+		},
+		{
+			desc: "New52WLow",
+			rule: Rule{Symbol: "00006", Kind: New52WLow},
+			quotes: []aastocks.Quote{
+				{Price: 41.6, Price52WLow: 41.6},
+			},
+			fired: []bool{true},
+		},
+		{
+			desc: "YieldAbove",
+			rule: Rule{Symbol: "00006", Kind: YieldAbove, Value: 0.06},
+			quotes: []aastocks.Quote{
+				{Yield: 0.06271}, {Yield: 0.05}, {Yield: 0.063},
+			},
+			fired: []bool{true, false, true},
+		},
+		{
+			desc: "PeBelow",
+			rule: Rule{Symbol: "00006", Kind: PeBelow, Value: 10},
+			quotes: []aastocks.Quote{
+				{PeRatio: 0}, {PeRatio: 9.5},
+			},
+			fired: []bool{false, true},
+		},
+		{
+			desc: "Expression",
+			rule: Rule{Symbol: "00006", Kind: Expression, Expr: "yield > 0.06 && pe < 15"},
+			quotes: []aastocks.Quote{
+				{Yield: 0.065, PeRatio: 16}, {Yield: 0.065, PeRatio: 14}, {Yield: 0.07, PeRatio: 14}, {Yield: 0.05, PeRatio: 14}, {Yield: 0.065, PeRatio: 14},
+			},
+			fired: []bool{false, true, false, false, true},
+		},
+		{
+			desc: "OtherSymbol",
+			rule: Rule{Symbol: "00005", Kind: YieldAbove, Value: 0.06},
+			quotes: []aastocks.Quote{
+				{Yield: 0.07},
+			},
+			fired: []bool{false},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			e, err := NewEngine([]Rule{tC.rule})
+			if err != nil {
+				t.Fatal(err)
+			}
+			fired := make([]bool, len(tC.quotes))
+			for i := range tC.quotes {
+				q := tC.quotes[i]
+				q.Symbol = "00006"
+				fired[i] = len(e.Evaluate(&q)) > 0
+			}
+			diff := cmp.Diff(tC.fired, fired)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestEngineCooldown(t *testing.T) {
+	now := time.Date(2020, time.August, 25, 10, 0, 0, 0, time.UTC)
+	e, err := NewEngine([]Rule{
+		{Symbol: "00006", Kind: YieldAbove, Value: 0.06, Cooldown: Duration(time.Hour)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.now = func() time.Time {
+		return now
+	}
+	steps := []struct {
+		after time.Duration
+		yield float64
+		fired bool
+	}{
+		{yield: 0.065, fired: true},
+		{after: time.Minute, yield: 0.05, fired: false},
+		// Re-armed, but still in cooldown
+		{after: time.Minute, yield: 0.065, fired: false},
+		{after: time.Hour, yield: 0.065, fired: true},
+	}
+	for i, s := range steps {
+		now = now.Add(s.after)
+		fired := len(e.Evaluate(&aastocks.Quote{Symbol: "00006", Yield: s.yield})) > 0
+		if fired != s.fired {
+			t.Fatalf("step %v: expected fired to be %v", i, s.fired)
+		}
+	}
+}
+
+func TestEngineHistory(t *testing.T) {
+	e, err := NewEngine([]Rule{
+		{Symbol: "00006", Kind: Expression, Expr: "price > sma(2)"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := &aastocks.Quote{Symbol: "00006", Price: 45}
+	if len(e.Evaluate(q)) > 0 {
+		t.Fatalf("expected no alert without historical prices")
+	}
+	e.SetHistory("00006", []aastocks.HistoricalPrice{{Close: 44}, {Close: 44.5}})
+	if len(e.Evaluate(q)) == 0 {
+		t.Fatalf("expected alert with historical prices")
+	}
+}
+
+func TestEngineEvaluateError(t *testing.T) {
+	e, err := NewEngine([]Rule{
+		{Name: "Trend", Symbol: "00006", Kind: Expression, Expr: "price > sma(2)"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.rules[0].condition = func(q *aastocks.Quote) (condition, bool, error) {
+		return condition{}, false, fmt.Errorf("Operator cannot be evaluated")
+	}
+	alerts := e.Evaluate(&aastocks.Quote{Symbol: "00006", Price: 45})
+	if len(alerts) != 1 {
+		t.Fatalf("expected alert with error, but got %v alerts", len(alerts))
+	}
+	diff := cmp.Diff(`Rule "Trend" failed to be evaluated: Operator cannot be evaluated`, fmt.Sprint(alerts[0].Err))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestEngineWatch(t *testing.T) {
+	e, err := NewEngine([]Rule{
+		{Name: "Breakout", Symbol: "00006", Kind: PriceCrossAbove, Value: 45},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := make(chan aastocks.PriceEvent, 3)
+	events <- aastocks.PriceEvent{Quote: &aastocks.Quote{Symbol: "00006", Price: 44.65}}
+	events <- aastocks.PriceEvent{Err: context.DeadlineExceeded}
+	events <- aastocks.PriceEvent{Quote: &aastocks.Quote{Symbol: "00006", Price: 45.1}}
+	close(events)
+
+	alerts := make([]string, 0)
+	for a := range e.Watch(context.Background(), events) {
+		alerts = append(alerts, a.Rule.Name)
+		diff := cmp.Diff(45.1, a.Value)
+		if diff != "" {
+			t.Fatalf(diff)
+		}
+	}
+	diff := cmp.Diff([]string{"Breakout"}, alerts)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,217 @@
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/expr"
+	"gopkg.in/yaml.v3"
+)
+
+// Kind of rule.
+type Kind string
+
+const (
+	// PriceCrossAbove fires when price crosses above the value
+	PriceCrossAbove Kind = "price_cross_above"
+	// PriceCrossBelow fires when price crosses below the value
+	PriceCrossBelow Kind = "price_cross_below"
+	// ChangeAbove fires when price moves more than the value as fraction from previous close, in either direction
+	ChangeAbove Kind = "change_above"
+	// New52WHigh fires when price reaches 52 week high
+	New52WHigh Kind = "new_52w_high"
+	// New52WLow fires when price reaches 52 week low
+	New52WLow Kind = "new_52w_low"
+	// YieldAbove fires when yield is above the value as fraction
+	YieldAbove Kind = "yield_above"
+	// PeBelow fires when PE ratio is positive and below the value
+	PeBelow Kind = "pe_below"
+	// Expression fires when expression of the rule becomes true
+	Expression Kind = "expr"
+)
+
+// Rule of alert for a symbol.
+//
+// Rule fires when its condition becomes true, and it is re-armed only after the condition is false
+// by hysteresis beyond the threshold, so that alerts do not flap around the threshold.
+// Rule does not fire again within cooldown after it fires.
+type Rule struct {
+	Name   string  `json:"name"`
+	Symbol string  `json:"symbol"`
+	Kind   Kind    `json:"kind"`
+	Value  float64 `json:"value"`
+	// Expr is expression of bool for rule of Expression kind, i.e. "price > 1.05 * sma(50)".
+	Expr string `json:"expr,omitempty"`
+	// Hysteresis in the same unit as the value being compared (i.e. price for crosses, fraction for yield).
+	Hysteresis float64  `json:"hysteresis"`
+	Cooldown   Duration `json:"cooldown"`
+}
+
+// Duration is time.Duration encoded as string in JSON (i.e. "5m").
+type Duration time.Duration
+
+// MarshalJSON encodes duration as string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON decodes duration from string.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// condition of the rule, as metric of quote compared with threshold.
+type condition struct {
+	metric    float64
+	threshold float64
+	above     bool
+	inclusive bool
+	// primed conditions only fire on crossing, not when it is true on the first evaluation
+	primed bool
+}
+
+func (c condition) met() bool {
+	if c.above {
+		return c.metric > c.threshold || (c.inclusive && c.metric == c.threshold)
+	}
+	return c.metric < c.threshold || (c.inclusive && c.metric == c.threshold)
+}
+
+// rearmed when metric is beyond threshold by hysteresis, in the opposite direction.
+func (c condition) rearmed(hysteresis float64) bool {
+	if c.above {
+		return c.metric < c.threshold-hysteresis
+	}
+	return c.metric > c.threshold+hysteresis
+}
+
+type conditionFunc func(r Rule, q *aastocks.Quote) (condition, bool)
+
+var conditions = map[Kind]conditionFunc{
+	PriceCrossAbove: func(r Rule, q *aastocks.Quote) (condition, bool) {
+		return condition{metric: q.Price, threshold: r.Value, above: true, primed: true}, true
+	},
+	PriceCrossBelow: func(r Rule, q *aastocks.Quote) (condition, bool) {
+		return condition{metric: q.Price, threshold: r.Value, above: false, primed: true}, true
+	},
+	ChangeAbove: func(r Rule, q *aastocks.Quote) (condition, bool) {
+		if q.PrevClose == 0 {
+			return condition{}, false
+		}
+		change := q.Price/q.PrevClose - 1
+		if change < 0 {
+			change = -change
+		}
+		return condition{metric: change, threshold: r.Value, above: true}, true
+	},
+	New52WHigh: func(r Rule, q *aastocks.Quote) (condition, bool) {
+		if q.Price52WHigh == 0 {
+			return condition{}, false
+		}
+		return condition{metric: q.Price, threshold: q.Price52WHigh, above: true, inclusive: true}, true
+	},
+	New52WLow: func(r Rule, q *aastocks.Quote) (condition, bool) {
+		if q.Price52WLow == 0 {
+			return condition{}, false
+		}
+		return condition{metric: q.Price, threshold: q.Price52WLow, above: false, inclusive: true}, true
+	},
+	YieldAbove: func(r Rule, q *aastocks.Quote) (condition, bool) {
+		return condition{metric: q.Yield, threshold: r.Value, above: true}, true
+	},
+	PeBelow: func(r Rule, q *aastocks.Quote) (condition, bool) {
+		if q.PeRatio <= 0 {
+			return condition{}, false
+		}
+		return condition{metric: q.PeRatio, threshold: r.Value, above: false}, true
+	},
+}
+
+// Validate the rule.
+func (r Rule) Validate() error {
+	if r.Symbol == "" {
+		return fmt.Errorf("Symbol of rule %q cannot be empty", r.Name)
+	}
+	if r.Kind == Expression {
+		_, err := expr.CompileBool(r.Expr)
+		if err != nil {
+			return fmt.Errorf("Expression of rule %q failed to be compiled: %v", r.Name, err)
+		}
+		if r.Hysteresis != 0 {
+			return fmt.Errorf("Hysteresis of rule %q is not supported for expression", r.Name)
+		}
+	} else if _, ok := conditions[r.Kind]; !ok {
+		return fmt.Errorf("Kind of rule %q is unknown: %v", r.Name, r.Kind)
+	}
+	if r.Hysteresis < 0 || r.Cooldown < 0 {
+		return fmt.Errorf("Hysteresis and cooldown of rule %q cannot be negative", r.Name)
+	}
+	return nil
+}
+
+// Load rules from JSON array.
+func Load(r io.Reader) ([]Rule, error) {
+	rules := make([]Rule, 0)
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	err := d.Decode(&rules)
+	if err != nil {
+		return nil, fmt.Errorf("Rules failed to be decoded: %v", err)
+	}
+	for _, rule := range rules {
+		err = rule.Validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// LoadYAML loads rules from YAML sequence, with the same fields as JSON.
+func LoadYAML(r io.Reader) ([]Rule, error) {
+	var v interface{}
+	err := yaml.NewDecoder(r).Decode(&v)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("Rules failed to be decoded: %v", err)
+	}
+	if v == nil {
+		v = []interface{}{}
+	}
+	// YAML is converted to JSON, so that rules are decoded and validated in the same way
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("Rules failed to be decoded: %v", err)
+	}
+	return Load(bytes.NewReader(b))
+}
+
+// LoadFile loads rules from YAML file if it has extension of ".yaml" or ".yml", otherwise from JSON file.
+func LoadFile(name string) ([]Rule, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return LoadYAML(f)
+	}
+	return Load(f)
+}
@@ -0,0 +1,157 @@
+package alert
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		content string
+		rules   []Rule
+		err     error
+	}{
+		{
+			desc: "Rules",
+			content: `[
+				{"name": "Breakout", "symbol": "00006", "kind": "price_cross_above", "value": 45, "hysteresis": 0.2, "cooldown": "30m"},
+				{"name": "High yield", "symbol": "00006", "kind": "yield_above", "value": 0.06}
+			]`,
+			rules: []Rule{
+				{Name: "Breakout", Symbol: "00006", Kind: PriceCrossAbove, Value: 45, Hysteresis: 0.2, Cooldown: Duration(30 * time.Minute)},
+				{Name: "High yield", Symbol: "00006", Kind: YieldAbove, Value: 0.06},
+			},
+		},
+		{
+			desc:    "UnknownKind",
+			content: `[{"name": "Unknown", "symbol": "00006", "kind": "volume_above", "value": 1}]`,
+			err:     fmt.Errorf(`Kind of rule "Unknown" is unknown: volume_above`),
+		},
+		{
+			desc:    "Expression",
+			content: `[{"name": "Value", "symbol": "00006", "kind": "expr", "expr": "yield > 0.05 && pe < 15"}]`,
+			rules: []Rule{
+				{Name: "Value", Symbol: "00006", Kind: Expression, Expr: "yield > 0.05 && pe < 15"},
+			},
+		},
+		{
+			desc:    "InvalidExpression",
+			content: `[{"name": "Value", "symbol": "00006", "kind": "expr", "expr": "yield > 0.05 && pe"}]`,
+			err:     fmt.Errorf(`Expression of rule "Value" failed to be compiled: Operator "&&" expects bool operands, but got bool and number at column 14`),
+		},
+		{
+			desc:    "InvalidCooldown",
+			content: `[{"name": "Invalid", "symbol": "00006", "kind": "yield_above", "cooldown": "soon"}]`,
+			err:     fmt.Errorf(`Rules failed to be decoded: time: invalid duration "soon"`),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			rules, err := Load(strings.NewReader(tC.content))
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.rules, rules)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		content string
+		rules   []Rule
+		err     error
+	}{
+		{
+			desc: "Rules",
+			content: `
+- name: Breakout
+  symbol: "00006"
+  kind: price_cross_above
+  value: 45
+  hysteresis: 0.2
+  cooldown: 30m
+- name: Trend
+  symbol: "00006"
+  kind: expr
+  expr: price > 1.05 * sma(50)
+`,
+			rules: []Rule{
+				{Name: "Breakout", Symbol: "00006", Kind: PriceCrossAbove, Value: 45, Hysteresis: 0.2, Cooldown: Duration(30 * time.Minute)},
+				{Name: "Trend", Symbol: "00006", Kind: Expression, Expr: "price > 1.05 * sma(50)"},
+			},
+		},
+		{
+			desc:  "Empty",
+			rules: []Rule{},
+		},
+		{
+			desc:    "UnknownField",
+			content: `[{name: Breakout, symbol: "00006", kind: price_cross_above, price: 45}]`,
+			err:     fmt.Errorf(`Rules failed to be decoded: json: unknown field "price"`),
+		},
+		{
+			desc:    "EmptySymbol",
+			content: `[{name: Breakout, kind: price_cross_above, value: 45}]`,
+			err:     fmt.Errorf(`Symbol of rule "Breakout" cannot be empty`),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			rules, err := LoadYAML(strings.NewReader(tC.content))
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.rules, rules)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "alert")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	files := map[string]string{
+		"rules.json": `[{"name": "High yield", "symbol": "00006", "kind": "yield_above", "value": 0.06}]`,
+		"rules.yaml": "- {name: High yield, symbol: \"00006\", kind: yield_above, value: 0.06}\n",
+		"rules.yml":  "- {name: High yield, symbol: \"00006\", kind: yield_above, value: 0.06}\n",
+	}
+	expected := []Rule{{Name: "High yield", Symbol: "00006", Kind: YieldAbove, Value: 0.06}}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			err := ioutil.WriteFile(path, []byte(content), 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rules, err := LoadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff := cmp.Diff(expected, rules)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package api
+
+// OpenAPI document of the API, which is served at /openapi.json.
+const OpenAPI = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "AAStocks API",
+    "description": "Quotes, dividends and historical prices of Hong Kong stocks from AAStocks.",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/v1/quotes/{symbol}": {
+      "get": {
+        "summary": "Get quote of symbol",
+        "operationId": "getQuote",
+        "parameters": [{"$ref": "#/components/parameters/Symbol"}],
+        "responses": {
+          "200": {
+            "description": "Quote of symbol",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Quote"}}}
+          },
+          "404": {"$ref": "#/components/responses/NotFound"},
+          "502": {"$ref": "#/components/responses/UpstreamError"},
+          "504": {"$ref": "#/components/responses/UpstreamTimeout"}
+        }
+      }
+    },
+    "/v1/quotes/{symbol}/dividends": {
+      "get": {
+        "summary": "Get dividends of symbol",
+        "operationId": "getDividends",
+        "parameters": [{"$ref": "#/components/parameters/Symbol"}],
+        "responses": {
+          "200": {
+            "description": "Dividends of symbol, in descending order of announce dates",
+            "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Dividend"}}}}
+          },
+          "404": {"$ref": "#/components/responses/NotFound"},
+          "502": {"$ref": "#/components/responses/UpstreamError"},
+          "504": {"$ref": "#/components/responses/UpstreamTimeout"}
+        }
+      }
+    },
+    "/v1/quotes/{symbol}/history": {
+      "get": {
+        "summary": "Get historical prices of symbol",
+        "operationId": "getHistory",
+        "parameters": [
+          {"$ref": "#/components/parameters/Symbol"},
+          {
+            "name": "freq",
+            "in": "query",
+            "description": "Frequency of prices",
+            "schema": {"type": "string", "enum": ["hourly", "daily", "weekly", "monthly"], "default": "daily"}
+          },
+          {
+            "name": "from",
+            "in": "query",
+            "description": "Prices from the date inclusively",
+            "schema": {"type": "string", "format": "date"}
+          },
+          {
+            "name": "to",
+            "in": "query",
+            "description": "Prices to the date inclusively",
+            "schema": {"type": "string", "format": "date"}
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Historical prices of symbol, in ascending order of time",
+            "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/HistoricalPrice"}}}}
+          },
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "404": {"$ref": "#/components/responses/NotFound"},
+          "502": {"$ref": "#/components/responses/UpstreamError"},
+          "504": {"$ref": "#/components/responses/UpstreamTimeout"}
+        }
+      }
+    }
+  },
+  "components": {
+    "parameters": {
+      "Symbol": {
+        "name": "symbol",
+        "in": "path",
+        "required": true,
+        "description": "Symbol of stock, i.e. 00006",
+        "schema": {"type": "string"}
+      }
+    },
+    "responses": {
+      "BadRequest": {
+        "description": "Parameters are invalid",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}
+      },
+      "NotFound": {
+        "description": "Symbol cannot be found",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}
+      },
+      "UpstreamError": {
+        "description": "AAStocks failed to respond",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}
+      },
+      "UpstreamTimeout": {
+        "description": "AAStocks did not respond in time",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}
+      }
+    },
+    "schemas": {
+      "Quote": {
+        "type": "object",
+        "required": ["symbol", "name", "price", "prev_close", "price_52w_low", "price_52w_high", "yield", "pe_ratio", "pb_ratio", "lots", "eps", "update_time"],
+        "properties": {
+          "symbol": {"type": "string"},
+          "name": {"type": "string"},
+          "industry": {"type": "string"},
+          "price": {"type": "number"},
+          "prev_close": {"type": "number"},
+          "price_52w_low": {"type": "number"},
+          "price_52w_high": {"type": "number"},
+          "yield": {"type": "number", "description": "Dividend yield as fraction"},
+          "pe_ratio": {"type": "number"},
+          "pb_ratio": {"type": "number"},
+          "lots": {"type": "integer", "description": "Board lot size"},
+          "eps": {"type": "number"},
+          "update_time": {"type": "string", "format": "date-time", "description": "Time in Hong Kong with offset +08:00, i.e. 2020-08-25T21:18:38+08:00"}
+        }
+      },
+      "Dividend": {
+        "type": "object",
+        "required": ["event", "particular", "type"],
+        "properties": {
+          "announce_date": {"type": "string", "format": "date"},
+          "year_ended": {"type": "string", "format": "date"},
+          "event": {"type": "string"},
+          "particular": {"type": "string", "description": "Particular of dividend, i.e. D:HKD 0.7700"},
+          "type": {"type": "string"},
+          "ex_date": {"type": "string", "format": "date"},
+          "payable_date": {"type": "string", "format": "date"}
+        }
+      },
+      "HistoricalPrice": {
+        "type": "object",
+        "required": ["time", "open", "high", "low", "close", "volume"],
+        "properties": {
+          "time": {"type": "string", "format": "date-time", "description": "Time in Hong Kong with offset +08:00, i.e. 2020-08-25T00:00:00+08:00"},
+          "open": {"type": "number"},
+          "high": {"type": "number"},
+          "low": {"type": "number"},
+          "close": {"type": "number"},
+          "volume": {"type": "number", "description": "Volume in thousands of shares"}
+        }
+      },
+      "Error": {
+        "type": "object",
+        "required": ["code", "message"],
+        "properties": {
+          "code": {"type": "string", "enum": ["bad_request", "not_found", "method_not_allowed", "upstream_error", "upstream_timeout"]},
+          "message": {"type": "string"}
+        }
+      }
+    }
+  }
+}
+`
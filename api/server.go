@@ -0,0 +1,198 @@
+// Package api serves quotes, dividends and historical prices of AAStocks as HTTP JSON API.
+//
+//	GET /v1/quotes/{symbol}
+//	GET /v1/quotes/{symbol}/dividends
+//	GET /v1/quotes/{symbol}/history?freq=daily&from=2020-01-01&to=2020-12-31
+//	GET /openapi.json
+//
+// Symbols which cannot be found are responded with 404, while failures of AAStocks are responded with 502.
+// Requests to AAStocks should be cached and rate limited by client shared by the server:
+//
+//	client := aastocks.NewCachedClient(30*time.Second, aastocks.NewRateLimiter(200*time.Millisecond, 5))
+//	http.ListenAndServe(":8080", api.NewServer(aastocks.WithClient(client)))
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Server of the API.
+type Server struct {
+	opts []aastocks.Option
+}
+
+// NewServer creates server of the API.
+// Options are applied to quotes fetched by the server (i.e. WithClient).
+func NewServer(opts ...aastocks.Option) *Server {
+	return &Server{opts: opts}
+}
+
+// statusError is error with HTTP status.
+type statusError struct {
+	status int
+	code   string
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &statusError{status: http.StatusBadRequest, code: "bad_request", err: fmt.Errorf(format, args...)}
+}
+
+// statusOf maps error to HTTP status and code.
+func statusOf(err error) (int, string) {
+	var se *statusError
+	switch {
+	case errors.As(err, &se):
+		return se.status, se.code
+	case errors.Is(err, aastocks.ErrSymbolNotFound):
+		return http.StatusNotFound, "not_found"
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, "upstream_timeout"
+	}
+	return http.StatusBadGateway, "upstream_error"
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, &statusError{status: http.StatusMethodNotAllowed, code: "method_not_allowed", err: fmt.Errorf("Method is not allowed: %v", r.Method)})
+		return
+	}
+	if r.URL.Path == "/openapi.json" {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(OpenAPI))
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[0] != "v1" || parts[1] != "quotes" || parts[2] == "" {
+		writeError(w, &statusError{status: http.StatusNotFound, code: "not_found", err: fmt.Errorf("Path cannot be found: %v", r.URL.Path)})
+		return
+	}
+	symbol := parts[2]
+	var v interface{}
+	var err error
+	switch {
+	case len(parts) == 3:
+		v, err = s.quote(symbol)
+	case parts[3] == "dividends":
+		v, err = s.dividends(symbol)
+	case parts[3] == "history":
+		v, err = s.history(symbol, r)
+	default:
+		err = &statusError{status: http.StatusNotFound, code: "not_found", err: fmt.Errorf("Path cannot be found: %v", r.URL.Path)}
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+func (s *Server) quote(symbol string) (interface{}, error) {
+	q, err := aastocks.Get(symbol, s.opts...)
+	if err != nil {
+		return nil, err
+	}
+	result := *q
+	result.UpdateTime = aastocks.HongKongTime(q.UpdateTime)
+	return &result, nil
+}
+
+func (s *Server) dividends(symbol string) (interface{}, error) {
+	q, err := aastocks.Get(symbol, s.opts...)
+	if err != nil {
+		return nil, err
+	}
+	dividends, err := q.Dividends()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Dividend, len(dividends))
+	for i, d := range dividends {
+		result[i] = newDividend(d)
+	}
+	return result, nil
+}
+
+var frequencies = map[string]aastocks.PriceFrequency{
+	"hourly":  aastocks.Hourly,
+	"daily":   aastocks.Daily,
+	"weekly":  aastocks.Weekly,
+	"monthly": aastocks.Monthly,
+}
+
+func (s *Server) history(symbol string, r *http.Request) (interface{}, error) {
+	query := r.URL.Query()
+	freq := query.Get("freq")
+	if freq == "" {
+		freq = "daily"
+	}
+	frequency, ok := frequencies[freq]
+	if !ok {
+		return nil, badRequest("Frequency is unknown: %v", freq)
+	}
+	from, err := parseDate(query.Get("from"))
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDate(query.Get("to"))
+	if err != nil {
+		return nil, err
+	}
+	if !to.IsZero() {
+		// To the end of the date
+		to = to.AddDate(0, 0, 1)
+	}
+
+	q, err := aastocks.Get(symbol, s.opts...)
+	if err != nil {
+		return nil, err
+	}
+	prices, err := q.HistoricalPrices(frequency)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]aastocks.HistoricalPrice, 0, len(prices))
+	for _, p := range prices {
+		if (!from.IsZero() && p.Time.Before(from)) || (!to.IsZero() && !p.Time.Before(to)) {
+			continue
+		}
+		p.Time = aastocks.HongKongTime(p.Time)
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return time.Time{}, badRequest("Date failed to be parsed: %v", s)
+	}
+	return t, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status, code := statusOf(err)
+	writeJSON(w, status, Error{Code: code, Message: err.Error()})
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/horacehylee/aastocks"
+)
+
+// mockTransport serves files of urls.
+type mockTransport map[string]string
+
+func (m mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, ok := m[req.URL.String()]
+	if !ok {
+		return nil, fmt.Errorf("Handler not found for %s", req.URL)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	recorder.Write(b)
+	return recorder.Result(), nil
+}
+
+func testServer() *Server {
+	return NewServer(aastocks.WithClient(&http.Client{
+		Transport: mockTransport{
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006":                                                             "../testdata/detail_quote.html",
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=151511":                                                            "../testdata/detail_quote_not_found.html",
+			"http://www.aastocks.com/en/stocks/analysis/dividend.aspx?symbol=00006":                                                              "../testdata/dividend.html",
+			"http://chartdata1.internet.aastocks.com/servlet/iDataServlet/getdaily?id=00006.HK&type=24&market=1&level=1&period=56&encoding=utf8": "../testdata/historical_price_00006_daily.html",
+		},
+	}))
+}
+
+func TestServer(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		method string
+		path   string
+		status int
+		body   string
+	}{
+		{
+			desc:   "Quote",
+			path:   "/v1/quotes/00006",
+			status: http.StatusOK,
+			body:   `{"symbol":"00006","name":"POWER ASSETS","industry":"Electricity Supply","price":44.65,"prev_close":44.2,"price_52w_low":41.6,"price_52w_high":58.5,"yield":0.06271,"pe_ratio":13.368,"pb_ratio":1.115,"lots":500,"eps":3.34,"update_time":"2020-08-25T21:18:38+08:00"}`,
+		},
+		{
+			desc:   "Dividends",
+			path:   "/v1/quotes/00006/dividends",
+			status: http.StatusOK,
+			body: `[{"announce_date":"2020-08-05","year_ended":"2020-12-01","event":"Interim","particular":"D:HKD 0.7700","type":"Cash","ex_date":"2020-09-03","payable_date":"2020-09-15"},` +
+				`{"announce_date":"2020-03-18","year_ended":"2019-12-01","event":"Final","particular":"D:HKD 2.0300","type":"Cash","ex_date":"2020-05-18","payable_date":"2020-05-28"},` +
+				`{"announce_date":"2013-09-27","event":"Special","particular":"Preferential Offer: 1 HK Electric Investments and HK Electric Investments Limited Share Stapled unit offer price HKD 5.4500 for every 4 Shares held","type":"-","ex_date":"2014-01-08"},` +
+				`{"announce_date":"2013-07-24","year_ended":"2013-12-01","event":"Interim","particular":"D:HKD 0.6500","type":"Cash","ex_date":"2013-08-23","payable_date":"2013-09-04"}]`,
+		},
+		{
+			desc:   "History",
+			path:   "/v1/quotes/00006/history?freq=daily&from=2020-08-24&to=2020-08-25",
+			status: http.StatusOK,
+			body: `[{"time":"2020-08-24T00:00:00+08:00","open":44.2,"high":44.7,"low":44.15,"close":44.2,"volume":1426.486},` +
+				`{"time":"2020-08-25T00:00:00+08:00","open":44.2,"high":44.65,"low":44.05,"close":44.65,"volume":1709.545}]`,
+		},
+		{
+			desc:   "HistoryUnknownFrequency",
+			path:   "/v1/quotes/00006/history?freq=yearly",
+			status: http.StatusBadRequest,
+			body:   `{"code":"bad_request","message":"Frequency is unknown: yearly"}`,
+		},
+		{
+			desc:   "HistoryInvalidDate",
+			path:   "/v1/quotes/00006/history?from=yesterday",
+			status: http.StatusBadRequest,
+			body:   `{"code":"bad_request","message":"Date failed to be parsed: yesterday"}`,
+		},
+		{
+			desc:   "SymbolNotFound",
+			path:   "/v1/quotes/151511",
+			status: http.StatusNotFound,
+			body:   `{"code":"not_found","message":"Symbol cannot be found: 151511"}`,
+		},
+		{
+			desc:   "UpstreamError",
+			path:   "/v1/quotes/00005",
+			status: http.StatusBadGateway,
+			body:   `{"code":"upstream_error","message":"Get \"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00005\": Handler not found for http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00005"}`,
+		},
+		{
+			desc:   "PathNotFound",
+			path:   "/v1/quotes/00006/news",
+			status: http.StatusNotFound,
+			body:   `{"code":"not_found","message":"Path cannot be found: /v1/quotes/00006/news"}`,
+		},
+		{
+			desc:   "MethodNotAllowed",
+			method: http.MethodPost,
+			path:   "/v1/quotes/00006",
+			status: http.StatusMethodNotAllowed,
+			body:   `{"code":"method_not_allowed","message":"Method is not allowed: POST"}`,
+		},
+	}
+	s := testServer()
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			method := tC.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, httptest.NewRequest(method, tC.path, nil))
+			diff := cmp.Diff([]interface{}{tC.status, tC.body}, []interface{}{w.Code, strings.TrimSpace(w.Body.String())})
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestServerOpenAPI(t *testing.T) {
+	w := httptest.NewRecorder()
+	testServer().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var doc struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := make([]string, 0)
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	diff := cmp.Diff([]string{"/v1/quotes/{symbol}", "/v1/quotes/{symbol}/dividends", "/v1/quotes/{symbol}/history"}, paths, cmpopts.SortSlices(func(a, b string) bool {
+		return a < b
+	}))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
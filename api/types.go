@@ -0,0 +1,47 @@
+package api
+
+import (
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Dividend in response, dates are formatted as "2006-01-02" and omitted if they are not available.
+// Quotes and historical prices are responded as they are with times in Hong Kong, while dividend is converted,
+// as its dates would otherwise be formatted as times, with zero times for dates not available.
+type Dividend struct {
+	AnnounceDate string `json:"announce_date,omitempty"`
+	YearEnded    string `json:"year_ended,omitempty"`
+	Event        string `json:"event"`
+	Particular   string `json:"particular"`
+	Type         string `json:"type"`
+	ExDate       string `json:"ex_date,omitempty"`
+	PayableDate  string `json:"payable_date,omitempty"`
+}
+
+func newDividend(d aastocks.Dividend) Dividend {
+	return Dividend{
+		AnnounceDate: formatDate(d.AnnounceDate),
+		YearEnded:    formatDate(d.YearEnded),
+		Event:        d.Event,
+		Particular:   d.Particular,
+		Type:         d.Type,
+		ExDate:       formatDate(d.ExDate),
+		PayableDate:  formatDate(d.PayableDate),
+	}
+}
+
+// Error in response.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+const dateLayout = "2006-01-02"
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
@@ -0,0 +1,222 @@
+// Package backtest replays historical prices of AAStocks through trading strategy.
+//
+// Series of historical prices and dividends are replayed bar by bar, in the time order across all symbols.
+// Orders placed by the strategy are filled on the next bar of the symbol, at its open or close price.
+// Order quantities should be multiples of board lot size of the symbol,
+// and HKD cash dividends are credited on ex-dates for positions held before them.
+//
+// Backtest is run fully offline, series can be fetched once with FetchSeries and then cached.
+//
+//	result, err := backtest.Run(strategy, []backtest.Series{series}, backtest.Config{
+//		Cash: 1000000,
+//	})
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/hkex"
+	"github.com/horacehylee/aastocks/stats"
+)
+
+// Series of a symbol to be replayed.
+type Series struct {
+	Symbol string
+	// Lots is board lot size of the symbol (i.e. Quote.Lots).
+	Lots      int
+	Prices    []aastocks.HistoricalPrice
+	Dividends []aastocks.Dividend
+}
+
+// FetchSeries fetches historical prices and dividends of the quote from AAStocks.
+func FetchSeries(q *aastocks.Quote, frequency aastocks.PriceFrequency) (Series, error) {
+	prices, err := q.HistoricalPrices(frequency)
+	if err != nil {
+		return Series{}, err
+	}
+	dividends, err := q.Dividends()
+	if err != nil {
+		return Series{}, err
+	}
+	return Series{
+		Symbol:    q.Symbol,
+		Lots:      q.Lots,
+		Prices:    prices,
+		Dividends: dividends,
+	}, nil
+}
+
+// Bar of a symbol being replayed.
+type Bar struct {
+	Symbol string
+	aastocks.HistoricalPrice
+}
+
+// Strategy decides orders to be placed on each bar.
+type Strategy interface {
+	OnBar(b Broker, bar Bar)
+}
+
+// StrategyFunc is function adapter for Strategy.
+type StrategyFunc func(b Broker, bar Bar)
+
+// OnBar calls f(b, bar).
+func (f StrategyFunc) OnBar(b Broker, bar Bar) {
+	f(b, bar)
+}
+
+// Fill is the price which orders are filled at.
+type Fill int
+
+const (
+	// NextOpen fills orders at open price of the next bar
+	NextOpen Fill = iota
+	// NextClose fills orders at close price of the next bar
+	NextClose
+)
+
+// Config of backtest.
+type Config struct {
+	// Cash at the start of backtest.
+	Cash float64
+	Fill Fill
+	// Cost of trade to be deducted from cash (i.e. total of hkex.CostModel), no cost is charged if it is nil.
+	Cost func(side hkex.Side, price float64, quantity int) float64
+	// PeriodsPerYear of the series for statistics, 252 is used if it is zero.
+	PeriodsPerYear float64
+	// RiskFree is annual risk-free rate for statistics.
+	RiskFree float64
+}
+
+// Result of backtest.
+type Result struct {
+	Trades    []Trade
+	Rejected  []Rejection
+	Dividends []DividendCredit
+	Equity    []EquityPoint
+	Stats     stats.Stats
+}
+
+// Trade is filled order.
+type Trade struct {
+	Time     time.Time
+	Symbol   string
+	Side     hkex.Side
+	Quantity int
+	Price    float64
+	Cost     float64
+}
+
+// Rejection is order which cannot be filled.
+type Rejection struct {
+	Time   time.Time
+	Order  Order
+	Reason string
+}
+
+// DividendCredit is cash dividend credited for position held before ex-date.
+type DividendCredit struct {
+	Time     time.Time
+	Symbol   string
+	Quantity int
+	Amount   float64
+}
+
+// EquityPoint is cash and market value of positions at close of the time.
+type EquityPoint struct {
+	Time   time.Time
+	Cash   float64
+	Equity float64
+}
+
+// Run backtest of the strategy over the series.
+func Run(strategy Strategy, series []Series, config Config) (*Result, error) {
+	symbols := make(map[string]*symbolState, len(series))
+	times := make([]time.Time, 0)
+	seen := make(map[int64]bool)
+	for _, s := range series {
+		if s.Lots <= 0 {
+			return nil, fmt.Errorf("Lots of %s should be positive: %v", s.Symbol, s.Lots)
+		}
+		if _, ok := symbols[s.Symbol]; ok {
+			return nil, fmt.Errorf("Series of %s is duplicated", s.Symbol)
+		}
+		bars := make(map[int64]aastocks.HistoricalPrice, len(s.Prices))
+		for _, p := range s.Prices {
+			key := p.Time.UnixNano()
+			bars[key] = p
+			if !seen[key] {
+				seen[key] = true
+				times = append(times, p.Time)
+			}
+		}
+		symbols[s.Symbol] = &symbolState{
+			series:   s,
+			bars:     bars,
+			credited: make(map[int]bool),
+		}
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+	ordered := make([]string, 0, len(series))
+	for _, s := range series {
+		ordered = append(ordered, s.Symbol)
+	}
+	sort.Strings(ordered)
+
+	a := &account{
+		config:  config,
+		cash:    config.Cash,
+		symbols: symbols,
+		result: &Result{
+			Trades:    make([]Trade, 0),
+			Rejected:  make([]Rejection, 0),
+			Dividends: make([]DividendCredit, 0),
+			Equity:    make([]EquityPoint, 0, len(times)),
+		},
+	}
+	for _, t := range times {
+		a.time = t
+		key := t.UnixNano()
+		bars := make([]Bar, 0, len(ordered))
+		for _, symbol := range ordered {
+			s := symbols[symbol]
+			p, ok := s.bars[key]
+			if !ok {
+				continue
+			}
+			a.creditDividends(s, t)
+			a.fill(s, p)
+			s.last = p.Close
+			bars = append(bars, Bar{Symbol: symbol, HistoricalPrice: p})
+		}
+		a.result.Equity = append(a.result.Equity, EquityPoint{
+			Time:   t,
+			Cash:   a.cash,
+			Equity: a.Equity(),
+		})
+		for _, bar := range bars {
+			strategy.OnBar(a, bar)
+		}
+	}
+
+	equity := make([]aastocks.HistoricalPrice, len(a.result.Equity))
+	for i, e := range a.result.Equity {
+		equity[i] = aastocks.HistoricalPrice{
+			Time:  e.Time,
+			Open:  e.Equity,
+			High:  e.Equity,
+			Low:   e.Equity,
+			Close: e.Equity,
+		}
+	}
+	a.result.Stats = stats.Summarize("", equity, nil, stats.Config{
+		PeriodsPerYear: config.PeriodsPerYear,
+		RiskFree:       config.RiskFree,
+	})
+	return a.result, nil
+}
@@ -0,0 +1,189 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/hkex"
+)
+
+func day(i int) time.Time {
+	return time.Date(2020, time.August, 3+i, 0, 0, 0, 0, time.UTC)
+}
+
+func testSeries() Series {
+	bars := [][2]float64{
+		{10, 10},
+		{11, 12},
+		{12, 12},
+		{13, 14},
+		{15, 15},
+	}
+	prices := make([]aastocks.HistoricalPrice, len(bars))
+	for i, b := range bars {
+		prices[i] = aastocks.HistoricalPrice{
+			Time:  day(i),
+			Open:  b[0],
+			High:  b[1],
+			Low:   b[0],
+			Close: b[1],
+		}
+	}
+	return Series{
+		Symbol: "00006",
+		Lots:   500,
+		Prices: prices,
+		Dividends: []aastocks.Dividend{
+			{
+				Particular: "D:HKD 1.0000",
+				Type:       "Cash",
+				ExDate:     day(2),
+			},
+		},
+	}
+}
+
+// buyAndSell buys 1000 shares on the first bar, and sells them on the third bar.
+func buyAndSell(b Broker, bar Bar) {
+	switch {
+	case bar.Time.Equal(day(0)):
+		b.Buy(bar.Symbol, 1000)
+	case bar.Time.Equal(day(2)):
+		b.Sell(bar.Symbol, b.Position(bar.Symbol))
+	}
+}
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config Config
+		result Result
+	}{
+		{
+			desc: "NextOpen",
+			config: Config{
+				Cash: 100000,
+			},
+			result: Result{
+				Trades: []Trade{
+					{Time: day(1), Symbol: "00006", Side: hkex.Buy, Quantity: 1000, Price: 11},
+					{Time: day(3), Symbol: "00006", Side: hkex.Sell, Quantity: 1000, Price: 13},
+				},
+				Rejected: []Rejection{},
+				Dividends: []DividendCredit{
+					{Time: day(2), Symbol: "00006", Quantity: 1000, Amount: 1000},
+				},
+				Equity: []EquityPoint{
+					{Time: day(0), Cash: 100000, Equity: 100000},
+					{Time: day(1), Cash: 89000, Equity: 101000},
+					{Time: day(2), Cash: 90000, Equity: 102000},
+					{Time: day(3), Cash: 103000, Equity: 103000},
+					{Time: day(4), Cash: 103000, Equity: 103000},
+				},
+			},
+		},
+		{
+			desc: "NextCloseWithCost",
+			config: Config{
+				Cash: 100000,
+				Fill: NextClose,
+				Cost: func(side hkex.Side, price float64, quantity int) float64 {
+					return 10
+				},
+			},
+			result: Result{
+				Trades: []Trade{
+					{Time: day(1), Symbol: "00006", Side: hkex.Buy, Quantity: 1000, Price: 12, Cost: 10},
+					{Time: day(3), Symbol: "00006", Side: hkex.Sell, Quantity: 1000, Price: 14, Cost: 10},
+				},
+				Rejected: []Rejection{},
+				Dividends: []DividendCredit{
+					{Time: day(2), Symbol: "00006", Quantity: 1000, Amount: 1000},
+				},
+				Equity: []EquityPoint{
+					{Time: day(0), Cash: 100000, Equity: 100000},
+					{Time: day(1), Cash: 87990, Equity: 99990},
+					{Time: day(2), Cash: 88990, Equity: 100990},
+					{Time: day(3), Cash: 102980, Equity: 102980},
+					{Time: day(4), Cash: 102980, Equity: 102980},
+				},
+			},
+		},
+		{
+			desc: "InsufficientCash",
+			config: Config{
+				Cash: 5000,
+			},
+			result: Result{
+				Trades: []Trade{},
+				Rejected: []Rejection{
+					{
+						Time:   day(1),
+						Order:  Order{Time: day(0), Symbol: "00006", Side: hkex.Buy, Quantity: 1000},
+						Reason: "Cash is insufficient",
+					},
+				},
+				Dividends: []DividendCredit{},
+				Equity: []EquityPoint{
+					{Time: day(0), Cash: 5000, Equity: 5000},
+					{Time: day(1), Cash: 5000, Equity: 5000},
+					{Time: day(2), Cash: 5000, Equity: 5000},
+					{Time: day(3), Cash: 5000, Equity: 5000},
+					{Time: day(4), Cash: 5000, Equity: 5000},
+				},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			result, err := Run(StrategyFunc(buyAndSell), []Series{testSeries()}, tC.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff := cmp.Diff(tC.result, *result, cmpopts.IgnoreFields(Result{}, "Stats"))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRunNonHKDDividend(t *testing.T) {
+	s := testSeries()
+	s.Dividends[0].Particular = "D:RMB 1.0000"
+	result, err := Run(StrategyFunc(buyAndSell), []Series{s}, Config{Cash: 100000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff([]DividendCredit{}, result.Dividends)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff(102000.0, result.Equity[len(result.Equity)-1].Equity)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestRunStats(t *testing.T) {
+	result, err := Run(StrategyFunc(buyAndSell), []Series{testSeries()}, Config{Cash: 100000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff(0.03, result.Stats.TotalReturn, cmpopts.EquateApprox(0, 1e-9))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestRunInvalidSeries(t *testing.T) {
+	s := testSeries()
+	s.Lots = 0
+	_, err := Run(StrategyFunc(buyAndSell), []Series{s}, Config{})
+	if err == nil {
+		t.Fatalf("error is expected for series without lots")
+	}
+}
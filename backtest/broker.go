@@ -0,0 +1,180 @@
+package backtest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/hkex"
+)
+
+// Order to be filled on the next bar of the symbol.
+type Order struct {
+	Time     time.Time
+	Symbol   string
+	Side     hkex.Side
+	Quantity int
+}
+
+// Broker places orders and provides account status for strategy.
+type Broker interface {
+	// Buy places order to buy quantity of the symbol, which should be multiple of its lots.
+	Buy(symbol string, quantity int) error
+	// Sell places order to sell quantity of the symbol, which should be multiple of its lots.
+	Sell(symbol string, quantity int) error
+	// Position is quantity of the symbol held.
+	Position(symbol string) int
+	// Lots is board lot size of the symbol.
+	Lots(symbol string) int
+	Cash() float64
+	// Equity is cash and market value of positions at the latest close prices.
+	Equity() float64
+	Time() time.Time
+}
+
+type symbolState struct {
+	series   Series
+	bars     map[int64]aastocks.HistoricalPrice
+	position int
+	last     float64
+	pending  []Order
+	credited map[int]bool
+}
+
+type account struct {
+	config  Config
+	cash    float64
+	time    time.Time
+	symbols map[string]*symbolState
+	result  *Result
+}
+
+func (a *account) Buy(symbol string, quantity int) error {
+	return a.place(symbol, hkex.Buy, quantity)
+}
+
+func (a *account) Sell(symbol string, quantity int) error {
+	return a.place(symbol, hkex.Sell, quantity)
+}
+
+func (a *account) place(symbol string, side hkex.Side, quantity int) error {
+	s, ok := a.symbols[symbol]
+	if !ok {
+		return fmt.Errorf("Series of %s cannot be found", symbol)
+	}
+	if quantity <= 0 {
+		return fmt.Errorf("Quantity should be positive: %v", quantity)
+	}
+	if quantity%s.series.Lots != 0 {
+		return fmt.Errorf("Quantity %v is not multiple of lots %v for %s", quantity, s.series.Lots, symbol)
+	}
+	s.pending = append(s.pending, Order{
+		Time:     a.time,
+		Symbol:   symbol,
+		Side:     side,
+		Quantity: quantity,
+	})
+	return nil
+}
+
+func (a *account) Position(symbol string) int {
+	s, ok := a.symbols[symbol]
+	if !ok {
+		return 0
+	}
+	return s.position
+}
+
+func (a *account) Lots(symbol string) int {
+	s, ok := a.symbols[symbol]
+	if !ok {
+		return 0
+	}
+	return s.series.Lots
+}
+
+func (a *account) Cash() float64 {
+	return a.cash
+}
+
+func (a *account) Equity() float64 {
+	equity := a.cash
+	for _, s := range a.symbols {
+		equity += float64(s.position) * s.last
+	}
+	return equity
+}
+
+func (a *account) Time() time.Time {
+	return a.time
+}
+
+func (a *account) fill(s *symbolState, p aastocks.HistoricalPrice) {
+	price := p.Open
+	if a.config.Fill == NextClose {
+		price = p.Close
+	}
+	for _, o := range s.pending {
+		var cost float64
+		if a.config.Cost != nil {
+			cost = a.config.Cost(o.Side, price, o.Quantity)
+		}
+		value := price * float64(o.Quantity)
+		switch o.Side {
+		case hkex.Buy:
+			if value+cost > a.cash {
+				a.reject(o, "Cash is insufficient")
+				continue
+			}
+			a.cash -= value + cost
+			s.position += o.Quantity
+		case hkex.Sell:
+			if o.Quantity > s.position {
+				a.reject(o, "Position is insufficient")
+				continue
+			}
+			a.cash += value - cost
+			s.position -= o.Quantity
+		}
+		a.result.Trades = append(a.result.Trades, Trade{
+			Time:     p.Time,
+			Symbol:   o.Symbol,
+			Side:     o.Side,
+			Quantity: o.Quantity,
+			Price:    price,
+			Cost:     cost,
+		})
+	}
+	s.pending = nil
+}
+
+func (a *account) reject(o Order, reason string) {
+	a.result.Rejected = append(a.result.Rejected, Rejection{
+		Time:   a.time,
+		Order:  o,
+		Reason: reason,
+	})
+}
+
+// creditDividends credits cash dividends with ex-date on or before the time, for position held before it.
+// Only HKD cash dividends are credited, as prices and cash are in HKD.
+func (a *account) creditDividends(s *symbolState, t time.Time) {
+	for i, d := range s.series.Dividends {
+		if s.credited[i] || d.ExDate.IsZero() || d.ExDate.After(t) {
+			continue
+		}
+		s.credited[i] = true
+		currency, amount, ok := d.Cash()
+		if !ok || currency != "HKD" || s.position == 0 {
+			continue
+		}
+		credit := amount * float64(s.position)
+		a.cash += credit
+		a.result.Dividends = append(a.result.Dividends, DividendCredit{
+			Time:     t,
+			Symbol:   s.series.Symbol,
+			Quantity: s.position,
+			Amount:   credit,
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package backtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBrokerPlaceOrder(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		symbol   string
+		quantity int
+		err      error
+	}{
+		{
+			desc:     "MultipleOfLots",
+			symbol:   "00006",
+			quantity: 1500,
+		},
+		{
+			desc:     "NotMultipleOfLots",
+			symbol:   "00006",
+			quantity: 300,
+			err:      fmt.Errorf("Quantity 300 is not multiple of lots 500 for 00006"),
+		},
+		{
+			desc:     "NonPositiveQuantity",
+			symbol:   "00006",
+			quantity: 0,
+			err:      fmt.Errorf("Quantity should be positive: 0"),
+		},
+		{
+			desc:     "UnknownSymbol",
+			symbol:   "00005",
+			quantity: 500,
+			err:      fmt.Errorf("Series of 00005 cannot be found"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var err error
+			strategy := func(b Broker, bar Bar) {
+				if bar.Time.Equal(day(0)) {
+					err = b.Buy(tC.symbol, tC.quantity)
+				}
+			}
+			_, e := Run(StrategyFunc(strategy), []Series{testSeries()}, Config{Cash: 100000})
+			if e != nil {
+				t.Fatal(e)
+			}
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package aastocks
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// NewCachedClient creates HTTP client for AAStocks, which caches successful responses for TTL
+// and limits requests with the limiter if it is not nil.
+// Concurrent requests of the same URL are made once, so that the client can be shared by quotes
+// of many callers with WithClient.
+func NewCachedClient(ttl time.Duration, limiter *RateLimiter) *http.Client {
+	return NewCachedClientWithTransport(nil, ttl, limiter)
+}
+
+// NewCachedClientWithTransport creates cached HTTP client as NewCachedClient,
+// with requests to AAStocks made by the transport (i.e. to instrument them), http.DefaultTransport is used if it is nil.
+// Cached responses and waits for the limiter do not reach the transport.
+func NewCachedClientWithTransport(next http.RoundTripper, ttl time.Duration, limiter *RateLimiter) *http.Client {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &http.Client{
+		Transport: newCachingTransport(&transport{r: next}, ttl, limiter),
+	}
+}
+
+type cachingTransport struct {
+	next    http.RoundTripper
+	ttl     time.Duration
+	limiter *RateLimiter
+	now     func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	// ready is closed when response is received
+	ready   chan struct{}
+	expires time.Time
+	status  int
+	header  http.Header
+	body    []byte
+	err     error
+}
+
+func newCachingTransport(next http.RoundTripper, ttl time.Duration, limiter *RateLimiter) *cachingTransport {
+	return &cachingTransport{
+		next:    next,
+		ttl:     ttl,
+		limiter: limiter,
+		now:     time.Now,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		err := t.wait(req)
+		if err != nil {
+			return nil, err
+		}
+		return t.next.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	t.mu.Lock()
+	e, ok := t.entries[key]
+	if ok && (!e.received() || t.now().Before(e.expires)) {
+		t.mu.Unlock()
+		select {
+		case <-e.ready:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		return e.response(req)
+	}
+	e = &cacheEntry{ready: make(chan struct{})}
+	t.entries[key] = e
+	t.sweep()
+	t.mu.Unlock()
+
+	e.fetch(t, req)
+
+	t.mu.Lock()
+	if e.err != nil || e.status != http.StatusOK {
+		// Failures are not cached, but requests waiting for it still receive the same result
+		delete(t.entries, key)
+	}
+	t.mu.Unlock()
+	close(e.ready)
+	return e.response(req)
+}
+
+func (t *cachingTransport) wait(req *http.Request) error {
+	if t.limiter == nil {
+		return nil
+	}
+	return t.limiter.Wait(req.Context())
+}
+
+// sweep expired entries, lock should be held.
+func (t *cachingTransport) sweep() {
+	now := t.now()
+	for key, e := range t.entries {
+		if e.received() && !now.Before(e.expires) {
+			delete(t.entries, key)
+		}
+	}
+}
+
+func (e *cacheEntry) fetch(t *cachingTransport, req *http.Request) {
+	e.err = t.wait(req)
+	if e.err != nil {
+		return
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		e.err = err
+		return
+	}
+	defer resp.Body.Close()
+	e.body, e.err = ioutil.ReadAll(resp.Body)
+	e.status = resp.StatusCode
+	e.header = resp.Header
+	e.expires = t.now().Add(t.ttl)
+}
+
+func (e *cacheEntry) received() bool {
+	select {
+	case <-e.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e *cacheEntry) response(req *http.Request) (*http.Response, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	return &http.Response{
+		Status:        http.StatusText(e.status),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,115 @@
+package aastocks
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCachedClient(t *testing.T) {
+	detailURL := "http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006"
+	mock := mockClient()
+	var count countRequests
+	mock.set(map[string]http.HandlerFunc{
+		"GET-" + detailURL: count.serve(serveAll(
+			serveFile("testdata/detail_quote.html"),
+			serveError(fmt.Errorf("testing error")),
+			serveFile("testdata/detail_quote_00006_2.html"),
+		)),
+	})
+	now := time.Date(2020, time.August, 25, 10, 0, 0, 0, time.UTC)
+	ct := newCachingTransport(mock, time.Minute, nil)
+	ct.now = func() time.Time {
+		return now
+	}
+	client := &http.Client{Transport: ct}
+
+	prices := make([]float64, 0)
+	get := func() {
+		q, err := Get("00006", WithClient(client))
+		if err != nil {
+			prices = append(prices, 0)
+			return
+		}
+		prices = append(prices, q.Price)
+	}
+	get()
+	get()
+	now = now.Add(time.Minute)
+	// Error is not cached
+	get()
+	get()
+	get()
+
+	diff := cmp.Diff([]interface{}{[]float64{44.65, 44.65, 0, 44.4, 44.4}, 3}, []interface{}{prices, count.get()})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestCachedClientWithTransport(t *testing.T) {
+	detailURL := "http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006"
+	mock := mockClient()
+	var count countRequests
+	referers := make([]string, 0)
+	mock.set(map[string]http.HandlerFunc{
+		"GET-" + detailURL: count.serve(func(w http.ResponseWriter, r *http.Request) {
+			referers = append(referers, r.Header.Get("Referer"))
+			serveFile("testdata/detail_quote.html")(w, r)
+		}),
+	})
+	client := NewCachedClientWithTransport(mock, time.Minute, nil)
+	for i := 0; i < 2; i++ {
+		_, err := Get("00006", WithClient(client))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Cached response does not reach the transport
+	diff := cmp.Diff([]interface{}{1, []string{detailURL}}, []interface{}{count.get(), referers})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestCachedClientConcurrent(t *testing.T) {
+	detailURL := "http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006"
+	mock := mockClient()
+	var count countRequests
+	release := make(chan struct{})
+	mock.set(map[string]http.HandlerFunc{
+		"GET-" + detailURL: count.serve(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+			serveFile("testdata/detail_quote.html")(w, r)
+		}),
+	})
+	client := &http.Client{Transport: newCachingTransport(mock, time.Minute, NewRateLimiter(time.Millisecond, 1))}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := Get("00006", WithClient(client))
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	diff := cmp.Diff(1, count.get())
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
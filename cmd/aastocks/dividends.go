@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+var dividendHeader = []string{"ANNOUNCED", "YEAR_ENDED", "EVENT", "PARTICULAR", "TYPE", "EX_DATE", "PAYABLE"}
+
+func dividendRecord(d aastocks.Dividend) record {
+	return record{
+		value: d,
+		row: []string{
+			formatDate(d.AnnounceDate),
+			formatDate(d.YearEnded),
+			d.Event,
+			d.Particular,
+			d.Type,
+			formatDate(d.ExDate),
+			formatDate(d.PayableDate),
+		},
+	}
+}
+
+// dividends gets dividends of symbol.
+func dividends(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("dividends", "<symbol>", stderr)
+	f := formatFlag(fs)
+	symbols, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(symbols) != 1 {
+		return usageError{fmt.Errorf("Exactly one symbol is expected")}
+	}
+	format, err := parseFormat(*f)
+	if err != nil {
+		return err
+	}
+
+	q, err := aastocks.Get(symbols[0], options...)
+	if err != nil {
+		return err
+	}
+	dividends, err := q.Dividends()
+	if err != nil {
+		return err
+	}
+	w := newRecordWriter(stdout, format, dividendHeader)
+	for _, d := range dividends {
+		err = w.Write(dividendRecord(d))
+		if err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// formatDate formats date, which is empty if it is not available.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
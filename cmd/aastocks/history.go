@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+var frequencies = map[string]aastocks.PriceFrequency{
+	"hourly":  aastocks.Hourly,
+	"daily":   aastocks.Daily,
+	"weekly":  aastocks.Weekly,
+	"monthly": aastocks.Monthly,
+}
+
+var historyHeader = []string{"TIME", "OPEN", "HIGH", "LOW", "CLOSE", "VOLUME"}
+
+func historyRecord(p aastocks.HistoricalPrice) record {
+	return record{
+		value: p,
+		row: []string{
+			p.Time.Format(time.RFC3339),
+			formatFloat(p.Open),
+			formatFloat(p.High),
+			formatFloat(p.Low),
+			formatFloat(p.Close),
+			formatFloat(p.Volume),
+		},
+	}
+}
+
+// history gets historical prices of symbol, within dates from and to inclusively.
+func history(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("history", "<symbol>", stderr)
+	f := formatFlag(fs)
+	freq := fs.String("freq", "daily", "frequency of prices: hourly, daily, weekly or monthly")
+	from := fs.String("from", "", "prices from the date inclusively, i.e. 2020-01-31")
+	to := fs.String("to", "", "prices to the date inclusively, i.e. 2020-12-31")
+	symbols, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(symbols) != 1 {
+		return usageError{fmt.Errorf("Exactly one symbol is expected")}
+	}
+	format, err := parseFormat(*f)
+	if err != nil {
+		return err
+	}
+	frequency, ok := frequencies[strings.ToLower(*freq)]
+	if !ok {
+		return usageError{fmt.Errorf("Frequency is unknown: %v", *freq)}
+	}
+	start, err := parseDate(*from)
+	if err != nil {
+		return err
+	}
+	end, err := parseDate(*to)
+	if err != nil {
+		return err
+	}
+	if !end.IsZero() {
+		// To the end of the date
+		end = end.AddDate(0, 0, 1)
+	}
+
+	q, err := aastocks.Get(symbols[0], options...)
+	if err != nil {
+		return err
+	}
+	prices, err := q.HistoricalPrices(frequency)
+	if err != nil {
+		return err
+	}
+	w := newRecordWriter(stdout, format, historyHeader)
+	for _, p := range prices {
+		if (!start.IsZero() && p.Time.Before(start)) || (!end.IsZero() && !p.Time.Before(end)) {
+			continue
+		}
+		err = w.Write(historyRecord(p))
+		if err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, usageError{fmt.Errorf("Date failed to be parsed: %v", err)}
+	}
+	return t, nil
+}
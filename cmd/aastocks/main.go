@@ -0,0 +1,205 @@
+// Command aastocks gets financial data of quotes from AAStocks.
+//
+// Usage:
+//
+//	aastocks <command> [flags] [arguments]
+//
+// Commands:
+//
+//	quote       gets quotes of symbols
+//	dividends   gets dividends of symbol
+//	history     gets historical prices of symbol
+//	watch       watches prices of symbols
+//	screen      screens symbols by filters, and ranks the survivors
+//
+// Output is written as table, JSON, NDJSON or CSV with -format flag.
+// Run "aastocks <command> -h" for flags of the command.
+//
+// Exit codes:
+//
+//	0   success
+//	1   other errors
+//	2   invalid usage
+//	3   symbol cannot be found
+//	4   network errors, or error status responded by AAStocks
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Exit codes of command.
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitNotFound = 3
+	exitNetwork  = 4
+)
+
+type command struct {
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) error
+}
+
+var commands = map[string]command{
+	"quote":     {summary: "gets quotes of symbols", run: quote},
+	"dividends": {summary: "gets dividends of symbol", run: dividends},
+	"history":   {summary: "gets historical prices of symbol", run: history},
+	"watch":     {summary: "watches prices of symbols", run: watch},
+	"screen":    {summary: "screens symbols by filters, and ranks the survivors", run: screen},
+}
+
+// options applied to quotes fetched, which are replaced in tests.
+var options []aastocks.Option
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run command with arguments, and returns exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+	c, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "Command is unknown: %v\n", args[0])
+		usage(stderr)
+		return exitUsage
+	}
+	err := c.run(args[1:], stdout, stderr)
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	var reported reportedError
+	if err != nil && !errors.As(err, &reported) {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+	}
+	return exitCode(err)
+}
+
+// usageError is error of invalid flags or arguments.
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e usageError) Unwrap() error {
+	return e.err
+}
+
+// reportedError is error which is already reported by the command.
+type reportedError struct {
+	err error
+}
+
+func (e reportedError) Error() string {
+	return e.err.Error()
+}
+
+func (e reportedError) Unwrap() error {
+	return e.err
+}
+
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var ue usageError
+	if errors.As(err, &ue) {
+		return exitUsage
+	}
+	if errors.Is(err, aastocks.ErrSymbolNotFound) {
+		return exitNotFound
+	}
+	var urlErr *url.Error
+	var netErr net.Error
+	var statusErr *aastocks.StatusError
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) || errors.As(err, &statusErr) {
+		return exitNetwork
+	}
+	return exitError
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: aastocks <command> [flags] [arguments]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12v%v\n", name, commands[name].summary)
+	}
+}
+
+// newFlagSet creates flag set of command, with usage of its arguments.
+func newFlagSet(name string, arguments string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: aastocks %v [flags] %v\n\n", name, arguments)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses flags which may be placed after arguments (i.e. "history 00006 -freq weekly"),
+// and returns the arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	arguments := make([]string, 0)
+	for {
+		err := fs.Parse(args)
+		if err == flag.ErrHelp {
+			return nil, err
+		}
+		if err != nil {
+			return nil, usageError{err}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return arguments, nil
+		}
+		arguments = append(arguments, args[0])
+		args = args[1:]
+	}
+}
+
+// formatFlag defines flag of output format.
+func formatFlag(fs *flag.FlagSet) *string {
+	return fs.String("format", string(formatTable), "output format: table, json, ndjson or csv")
+}
+
+// interruptContext returns context which is cancelled on interrupt signal.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	go func() {
+		select {
+		case <-c:
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(c)
+	}()
+	return ctx, cancel
+}
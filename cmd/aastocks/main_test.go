@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+// mockTransport serves files of urls.
+type mockTransport map[string]string
+
+func (m mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, ok := m[req.URL.String()]
+	if !ok {
+		return nil, fmt.Errorf("Handler not found for %s", req.URL)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	recorder.Write(b)
+	return recorder.Result(), nil
+}
+
+func init() {
+	options = []aastocks.Option{
+		aastocks.WithClient(&http.Client{
+			Transport: mockTransport{
+				"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006":                                                             "../../testdata/detail_quote.html",
+				"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=09923":                                                             "../../testdata/detail_quote_new.html",
+				"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=151511":                                                            "../../testdata/detail_quote_not_found.html",
+				"http://www.aastocks.com/en/stocks/analysis/dividend.aspx?symbol=00006":                                                              "../../testdata/dividend.html",
+				"http://chartdata1.internet.aastocks.com/servlet/iDataServlet/getdaily?id=00006.HK&type=24&market=1&level=1&period=56&encoding=utf8": "../../testdata/historical_price_00006_daily.html",
+			},
+		}),
+	}
+}
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			desc: "QuoteCSV",
+			args: []string{"quote", "00006", "09923", "-format", "csv"},
+			code: exitOK,
+			stdout: "SYMBOL,NAME,INDUSTRY,PRICE,PREV_CLOSE,LOW_52W,HIGH_52W,YIELD,PE,PB,EPS,LOTS,UPDATED\n" +
+				"00006,POWER ASSETS,Electricity Supply,44.65,44.2,41.6,58.5,6.27%,13.368,1.115,3.34,500,2020-08-25T21:18:38Z\n" +
+				"09923,YEAHKA,E-Commerce & Internet Services,59.6,61.2,14.92,80,0.00%,0,0,0,400,2020-08-26T02:50:43Z\n",
+		},
+		{
+			desc: "QuoteNotFound",
+			args: []string{"quote", "00006", "151511", "-format", "csv"},
+			code: exitNotFound,
+			stdout: "SYMBOL,NAME,INDUSTRY,PRICE,PREV_CLOSE,LOW_52W,HIGH_52W,YIELD,PE,PB,EPS,LOTS,UPDATED\n" +
+				"00006,POWER ASSETS,Electricity Supply,44.65,44.2,41.6,58.5,6.27%,13.368,1.115,3.34,500,2020-08-25T21:18:38Z\n",
+			stderr: "Error: Symbol cannot be found: 151511\n",
+		},
+		{
+			desc:   "QuoteNetworkError",
+			args:   []string{"quote", "00005"},
+			code:   exitNetwork,
+			stderr: "Error: Get \"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00005\": Handler not found for http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00005\n",
+		},
+		{
+			desc: "DividendsNDJSON",
+			args: []string{"dividends", "-format", "ndjson", "00006"},
+			code: exitOK,
+			stdout: `{"announce_date":"2020-08-05T00:00:00Z","year_ended":"2020-12-01T00:00:00Z","event":"Interim","particular":"D:HKD 0.7700","type":"Cash","ex_date":"2020-09-03T00:00:00Z","payable_date":"2020-09-15T00:00:00Z"}` + "\n" +
+				`{"announce_date":"2020-03-18T00:00:00Z","year_ended":"2019-12-01T00:00:00Z","event":"Final","particular":"D:HKD 2.0300","type":"Cash","ex_date":"2020-05-18T00:00:00Z","payable_date":"2020-05-28T00:00:00Z"}` + "\n" +
+				`{"announce_date":"2013-09-27T00:00:00Z","year_ended":"0001-01-01T00:00:00Z","event":"Special","particular":"Preferential Offer: 1 HK Electric Investments and HK Electric Investments Limited Share Stapled unit offer price HKD 5.4500 for every 4 Shares held","type":"-","ex_date":"2014-01-08T00:00:00Z","payable_date":"0001-01-01T00:00:00Z"}` + "\n" +
+				`{"announce_date":"2013-07-24T00:00:00Z","year_ended":"2013-12-01T00:00:00Z","event":"Interim","particular":"D:HKD 0.6500","type":"Cash","ex_date":"2013-08-23T00:00:00Z","payable_date":"2013-09-04T00:00:00Z"}` + "\n",
+		},
+		{
+			desc:   "DividendsWithoutSymbol",
+			args:   []string{"dividends"},
+			code:   exitUsage,
+			stderr: "Error: Exactly one symbol is expected\n",
+		},
+		{
+			desc: "History",
+			args: []string{"history", "00006", "--freq", "daily", "--from", "2020-08-24", "--to", "2020-08-25"},
+			code: exitOK,
+			stdout: "TIME                  OPEN    HIGH    LOW     CLOSE   VOLUME\n" +
+				"2020-08-24T00:00:00Z  44.2    44.7    44.15   44.2    1426.486\n" +
+				"2020-08-25T00:00:00Z  44.2    44.65   44.05   44.65   1709.545\n",
+		},
+		{
+			desc:   "HistoryUnknownFrequency",
+			args:   []string{"history", "00006", "--freq", "yearly"},
+			code:   exitUsage,
+			stderr: "Error: Frequency is unknown: yearly\n",
+		},
+		{
+			desc: "ScreenNetworkError",
+			args: []string{"screen", "-format", "csv", "00005", "00011"},
+			code: exitNetwork,
+			stderr: "0 of 0 symbols matched\n" +
+				"Error: 00005: Get \"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00005\": Handler not found for http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00005\n" +
+				"Error: 00011: Get \"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00011\": Handler not found for http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00011\n",
+		},
+		{
+			desc: "ScreenPartialError",
+			args: []string{"screen", "-format", "csv", "-filter", "yield > 0.06", "00006", "00005"},
+			code: exitOK,
+			stdout: "RANK,SYMBOL,NAME,INDUSTRY,PRICE,PREV_CLOSE,LOW_52W,HIGH_52W,YIELD,PE,PB,EPS,LOTS,UPDATED\n" +
+				"1,00006,POWER ASSETS,Electricity Supply,44.65,44.2,41.6,58.5,6.27%,13.368,1.115,3.34,500,2020-08-25T21:18:38Z\n",
+			stderr: "1 of 1 symbols matched\n" +
+				"Error: 00005: Get \"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00005\": Handler not found for http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00005\n",
+		},
+		{
+			desc:   "UnknownFormat",
+			args:   []string{"quote", "00006", "-format", "xml"},
+			code:   exitUsage,
+			stderr: "Error: Format is unknown: xml\n",
+		},
+		{
+			desc:   "WatchJSON",
+			args:   []string{"watch", "00006", "-format", "json"},
+			code:   exitUsage,
+			stderr: "Error: Format json cannot be streamed, use ndjson instead\n",
+		},
+		{
+			desc:   "Watch",
+			args:   []string{"watch", "00006", "-count", "1", "-format", "ndjson"},
+			code:   exitOK,
+			stdout: `{"symbol":"00006","price":44.65,"change":0.010180995475113086,"time":"2020-08-25T21:18:38Z"}` + "\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tC.args, &stdout, &stderr)
+			diff := cmp.Diff(tC.code, code)
+			if diff != "" {
+				t.Fatalf("%v\n%v", diff, stderr.String())
+			}
+			diff = cmp.Diff(tC.stdout, stdout.String())
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.stderr, stderr.String())
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	testCases := []struct {
+		desc string
+		err  error
+		code int
+	}{
+		{desc: "OK", code: exitOK},
+		{desc: "Usage", err: usageError{fmt.Errorf("Symbols cannot be empty")}, code: exitUsage},
+		{desc: "NotFound", err: fmt.Errorf("%w: 151511", aastocks.ErrSymbolNotFound), code: exitNotFound},
+		{desc: "Network", err: &url.Error{Op: "Get", URL: "http://www.aastocks.com", Err: fmt.Errorf("connection refused")}, code: exitNetwork},
+		{
+			desc: "Status",
+			err:  reportedError{&aastocks.StatusError{StatusCode: http.StatusServiceUnavailable, Err: fmt.Errorf("Failed to fetch quote details: 503 Service Unavailable")}},
+			code: exitNetwork,
+		},
+		{desc: "Other", err: fmt.Errorf("Quote details cannot be parsed"), code: exitError},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			diff := cmp.Diff(tC.code, exitCode(tC.err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// format of output.
+type format string
+
+const (
+	formatTable  format = "table"
+	formatJSON   format = "json"
+	formatNDJSON format = "ndjson"
+	formatCSV    format = "csv"
+)
+
+func parseFormat(s string) (format, error) {
+	f := format(strings.ToLower(s))
+	switch f {
+	case formatTable, formatJSON, formatNDJSON, formatCSV:
+		return f, nil
+	}
+	return "", usageError{fmt.Errorf("Format is unknown: %v", s)}
+}
+
+// record to be written, as value for JSON and NDJSON, and as row of fields for table and CSV.
+type record struct {
+	value interface{}
+	row   []string
+}
+
+// recordWriter writes records in format.
+// Records of JSON are written as array on Flush, while records of other formats are written as they come.
+type recordWriter struct {
+	format  format
+	header  []string
+	written bool
+	json    []interface{}
+	table   *tabwriter.Writer
+	csv     *csv.Writer
+	w       io.Writer
+}
+
+func newRecordWriter(w io.Writer, f format, header []string) *recordWriter {
+	rw := &recordWriter{
+		format: f,
+		header: header,
+		json:   make([]interface{}, 0),
+		w:      w,
+	}
+	switch f {
+	case formatTable:
+		rw.table = tabwriter.NewWriter(w, 8, 0, 2, ' ', 0)
+	case formatCSV:
+		rw.csv = csv.NewWriter(w)
+	}
+	return rw
+}
+
+func (rw *recordWriter) Write(r record) error {
+	if !rw.written {
+		rw.written = true
+		err := rw.writeRow(rw.header)
+		if err != nil {
+			return err
+		}
+	}
+	switch rw.format {
+	case formatJSON:
+		rw.json = append(rw.json, r.value)
+		return nil
+	case formatNDJSON:
+		return json.NewEncoder(rw.w).Encode(r.value)
+	}
+	return rw.writeRow(r.row)
+}
+
+func (rw *recordWriter) writeRow(row []string) error {
+	switch rw.format {
+	case formatTable:
+		_, err := fmt.Fprintln(rw.table, strings.Join(row, "\t"))
+		return err
+	case formatCSV:
+		return rw.csv.Write(row)
+	}
+	return nil
+}
+
+// Flush records written, it should be called after each record for streaming.
+func (rw *recordWriter) Flush() error {
+	switch rw.format {
+	case formatJSON:
+		b, err := json.MarshalIndent(rw.json, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(rw.w, string(b))
+		return err
+	case formatTable:
+		return rw.table.Flush()
+	case formatCSV:
+		rw.csv.Flush()
+		return rw.csv.Error()
+	}
+	return nil
+}
+
+func formatFloat(v float64) string {
+	return fmt.Sprint(v)
+}
+
+func formatPercent(v float64) string {
+	return fmt.Sprintf("%.2f%%", v*100)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+var quoteHeader = []string{"SYMBOL", "NAME", "INDUSTRY", "PRICE", "PREV_CLOSE", "LOW_52W", "HIGH_52W", "YIELD", "PE", "PB", "EPS", "LOTS", "UPDATED"}
+
+func quoteRecord(q *aastocks.Quote) record {
+	return record{
+		value: q,
+		row: []string{
+			q.Symbol,
+			q.Name,
+			q.Industry,
+			formatFloat(q.Price),
+			formatFloat(q.PrevClose),
+			formatFloat(q.Price52WLow),
+			formatFloat(q.Price52WHigh),
+			formatPercent(q.Yield),
+			formatFloat(q.PeRatio),
+			formatFloat(q.PbRatio),
+			formatFloat(q.Eps),
+			fmt.Sprint(q.Lots),
+			q.UpdateTime.Format(time.RFC3339),
+		},
+	}
+}
+
+// quote gets quotes of symbols.
+// Symbols failed to be fetched are reported, while the others are still written.
+func quote(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("quote", "<symbol...>", stderr)
+	f := formatFlag(fs)
+	symbols, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(symbols) == 0 {
+		return usageError{fmt.Errorf("Symbols cannot be empty")}
+	}
+	format, err := parseFormat(*f)
+	if err != nil {
+		return err
+	}
+
+	w := newRecordWriter(stdout, format, quoteHeader)
+	var first error
+	for _, symbol := range symbols {
+		q, err := aastocks.Get(symbol, options...)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			if first == nil {
+				first = err
+			}
+			continue
+		}
+		err = w.Write(quoteRecord(q))
+		if err != nil {
+			return err
+		}
+	}
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+	if first != nil {
+		return reportedError{first}
+	}
+	return nil
+}
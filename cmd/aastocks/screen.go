@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/horacehylee/aastocks/internal/symbolfile"
+	"github.com/horacehylee/aastocks/screener"
+)
+
+var screenHeader = append([]string{"RANK"}, quoteHeader...)
+
+func screenRecord(r screener.Row) record {
+	return record{
+		value: r,
+		row:   append([]string{fmt.Sprint(r.Rank)}, quoteRecord(r.Quote).row...),
+	}
+}
+
+// screen symbols by filters, symbols failed to be screened are reported without failing the command,
+// unless none of the symbols is screened.
+func screen(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("screen", "<symbol...>", stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: aastocks screen [flags] <symbol...>")
+		fmt.Fprintln(fs.Output(), "")
+		fmt.Fprintln(fs.Output(), `Example: aastocks screen -universe hsi.txt -filter "yield > 0.06 && pb < 1" -sort yield:desc`)
+		fmt.Fprintln(fs.Output(), "")
+		fs.PrintDefaults()
+	}
+	f := formatFlag(fs)
+	filter := fs.String("filter", "", "expression which quotes must match, i.e. \"yield > 0.06 && pb < 1\"")
+	industry := fs.String("industry", "", "comma separated industries which quotes must be in")
+	sorts := fs.String("sort", "", "comma separated fields to sort by, with optional :desc, i.e. \"yield:desc,pe\"")
+	limit := fs.Int("limit", 0, "limit of rows, all rows are shown if it is zero")
+	concurrency := fs.Int("concurrency", 4, "concurrency of fetching quotes")
+	universe := fs.String("universe", "", "file of symbols to be screened, one per line")
+	symbols, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	format, err := parseFormat(*f)
+	if err != nil {
+		return err
+	}
+	if *universe != "" {
+		s, err := symbolfile.Read(*universe)
+		if err != nil {
+			return err
+		}
+		symbols = append(symbols, s...)
+	}
+	if len(symbols) == 0 {
+		return usageError{fmt.Errorf("Symbols cannot be empty")}
+	}
+
+	config := screener.Config{
+		Limit:       *limit,
+		Concurrency: *concurrency,
+	}
+	if *filter != "" {
+		f, err := screener.Expression(*filter)
+		if err != nil {
+			return usageError{err}
+		}
+		config.Filters = append(config.Filters, f)
+	}
+	if *industry != "" {
+		config.Filters = append(config.Filters, screener.InIndustry(splitList(*industry)...))
+	}
+	config.Sort, err = parseSorts(*sorts)
+	if err != nil {
+		return usageError{err}
+	}
+
+	ctx, cancel := interruptContext()
+	defer cancel()
+	result := screener.New(config, options...).Screen(ctx, symbols)
+
+	w := newRecordWriter(stdout, format, screenHeader)
+	for _, r := range result.Rows {
+		err = w.Write(screenRecord(r))
+		if err != nil {
+			return err
+		}
+	}
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "%v of %v symbols matched\n", len(result.Rows), result.Screened)
+	for _, err := range result.Errors {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+	}
+	if result.Screened == 0 && len(result.Errors) > 0 {
+		return reportedError{result.Errors[0]}
+	}
+	return nil
+}
+
+// parseSorts parses comma separated fields with optional direction, i.e. "yield:desc,pe".
+func parseSorts(s string) ([]screener.Sort, error) {
+	sorts := make([]screener.Sort, 0)
+	for _, item := range splitList(s) {
+		parts := strings.SplitN(item, ":", 2)
+		field, err := screener.ParseField(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		sort := screener.Sort{Field: field}
+		if len(parts) == 2 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				sort.Descending = true
+			default:
+				return nil, fmt.Errorf("Sort direction is unknown: %v", parts[1])
+			}
+		}
+		sorts = append(sorts, sort)
+	}
+	return sorts, nil
+}
+
+func splitList(s string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
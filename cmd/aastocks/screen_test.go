@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks/screener"
+)
+
+func TestParseSorts(t *testing.T) {
+	testCases := []struct {
+		s     string
+		sorts []screener.Sort
+		err   error
+	}{
+		{
+			s:     "",
+			sorts: []screener.Sort{},
+		},
+		{
+			s: "yield:desc, pe",
+			sorts: []screener.Sort{
+				{Field: screener.Yield, Descending: true},
+				{Field: screener.PeRatio},
+			},
+		},
+		{
+			s:   "yield:down",
+			err: fmt.Errorf("Sort direction is unknown: down"),
+		},
+		{
+			s:   "volume",
+			err: fmt.Errorf("Field is unknown: volume"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.s, func(t *testing.T) {
+			sorts, err := parseSorts(tC.s)
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.sorts, sorts)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+var watchHeader = []string{"TIME", "SYMBOL", "PRICE", "CHANGE"}
+
+// watchEvent is record of price watched.
+type watchEvent struct {
+	Symbol string  `json:"symbol"`
+	Price  float64 `json:"price"`
+	// Change as fraction from previous close.
+	Change float64   `json:"change"`
+	Time   time.Time `json:"time"`
+}
+
+func watchRecord(e aastocks.PriceEvent) record {
+	w := watchEvent{
+		Symbol: e.Price.Symbol,
+		Price:  e.Price.Price,
+		Time:   e.Price.Time,
+	}
+	if e.Quote != nil && e.Quote.PrevClose != 0 {
+		w.Change = e.Quote.Price/e.Quote.PrevClose - 1
+	}
+	return record{
+		value: w,
+		row: []string{
+			w.Time.Format(time.RFC3339),
+			w.Symbol,
+			formatFloat(w.Price),
+			formatPercent(w.Change),
+		},
+	}
+}
+
+// watch prices of symbols until interrupted, or the count of prices are written.
+func watch(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("watch", "<symbol...>", stderr)
+	f := formatFlag(fs)
+	interval := fs.Duration("interval", 5*time.Second, "interval between polls of each symbol")
+	count := fs.Int("count", 0, "stop after the count of prices are written, never stop if it is zero")
+	onlyChanges := fs.Bool("changes", false, "write prices only when they are changed")
+	symbols, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(symbols) == 0 {
+		return usageError{fmt.Errorf("Symbols cannot be empty")}
+	}
+	format, err := parseFormat(*f)
+	if err != nil {
+		return err
+	}
+	if format == formatJSON {
+		return usageError{fmt.Errorf("Format json cannot be streamed, use ndjson instead")}
+	}
+
+	ctx, cancel := interruptContext()
+	defer cancel()
+	hub := aastocks.NewPriceHub(ctx, aastocks.HubConfig{Interval: *interval}, options...)
+	defer hub.Close()
+	sub := hub.Subscribe(symbols...)
+	defer sub.Close()
+
+	w := newRecordWriter(stdout, format, watchHeader)
+	written := 0
+	latest := make(map[string]float64)
+	for e := range sub.C() {
+		if e.Err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", e.Err)
+			continue
+		}
+		if p, ok := latest[e.Price.Symbol]; *onlyChanges && ok && p == e.Price.Price {
+			continue
+		}
+		latest[e.Price.Symbol] = e.Price.Price
+		err = w.Write(watchRecord(e))
+		if err != nil {
+			return err
+		}
+		err = w.Flush()
+		if err != nil {
+			return err
+		}
+		written++
+		if *count > 0 && written >= *count {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Command aastocksd serves quotes, dividends and historical prices of AAStocks as HTTP JSON API.
+// Requests to AAStocks are cached and rate limited, and shared by all clients of the server.
+// Live prices are served at /v1/live over Server-Sent Events and WebSocket, polled once per symbol
+// through the same cache, so they are refreshed at most once per TTL.
+//
+// Usage:
+//
+//	aastocksd -addr :8080 -ttl 30s -interval 200ms -burst 5 -live 5s
+//
+// See package api for endpoints, and /openapi.json for the OpenAPI document.
+// See package live for events of live prices.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/api"
+	"github.com/horacehylee/aastocks/live"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	ttl := flag.Duration("ttl", 30*time.Second, "duration which responses of AAStocks are cached for")
+	interval := flag.Duration("interval", 200*time.Millisecond, "interval between requests to AAStocks")
+	burst := flag.Int("burst", 5, "burst of requests to AAStocks")
+	liveInterval := flag.Duration("live", 5*time.Second, "interval between polls of live prices")
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "", log.Flags())
+	limiter := aastocks.NewRateLimiter(*interval, *burst)
+	client := aastocks.NewCachedClient(*ttl, limiter)
+	hubCtx, stopHub := context.WithCancel(context.Background())
+	defer stopHub()
+	// Polls are rate limited by the client on cache misses only, so the hub is not limited by the same limiter again
+	hub := aastocks.NewPriceHub(hubCtx, aastocks.HubConfig{
+		Interval: *liveInterval,
+	}, aastocks.WithClient(client))
+
+	mux := http.NewServeMux()
+	// Live prices are streamed, so they are not timed out nor wrapped by request logging
+	mux.Handle("/v1/live", live.NewHandler(hub, live.Config{}))
+	mux.Handle("/", logRequests(logger, http.TimeoutHandler(api.NewServer(aastocks.WithClient(client)), time.Minute, "")))
+	server := &http.Server{
+		Addr:        *addr,
+		Handler:     mux,
+		ReadTimeout: 10 * time.Second,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt)
+		<-c
+		// Streams of live prices are ended with the hub, so that shutdown is not blocked by them
+		stopHub()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err := server.Shutdown(ctx)
+		if err != nil {
+			logger.Printf("Server failed to be shutdown: %v\n", err)
+		}
+	}()
+
+	logger.Printf("Listening on %v\n", *addr)
+	err := server.ListenAndServe()
+	if err != http.ErrServerClosed {
+		logger.Fatal(err)
+	}
+	<-done
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// logRequests logs method, path, status and duration of requests.
+func logRequests(logger *log.Logger, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(rec, r)
+		logger.Printf("%v %v %v %v\n", r.Method, r.URL.RequestURI(), rec.status, time.Since(start))
+	})
+}
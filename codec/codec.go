@@ -0,0 +1,182 @@
+// Package codec encodes historical prices, dividends and quotes of AAStocks to CSV, NDJSON or XLSX,
+// and decodes them back, so that exports can be opened in Excel or replayed offline.
+//
+// Columns are named by JSON field names of the types (i.e. "ex_date"), and their order can be configured.
+//
+//	err := codec.EncodeDividends(f, dividends, codec.Config{
+//		Format:  codec.XLSX,
+//		Columns: []string{"ex_date", "particular", "payable_date"},
+//	})
+//
+// Decoded prices and dividends can feed backtest.Series without fetching from AAStocks again.
+//
+//	prices, err := codec.DecodePrices(f, codec.Config{Format: codec.CSV})
+//	series := backtest.Series{Symbol: "00006", Lots: 500, Prices: prices, Dividends: dividends}
+//
+// Quotes decoded are not bound to any client, so they cannot fetch historical prices or dividends.
+package codec
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Format of encoding.
+type Format int
+
+const (
+	// CSV with header of column names
+	CSV Format = iota
+	// NDJSON of JSON objects, one per line
+	NDJSON
+	// XLSX of Excel workbook with single sheet, which has header of column names
+	XLSX
+)
+
+var formatNames = map[Format]string{
+	CSV:    "csv",
+	NDJSON: "ndjson",
+	XLSX:   "xlsx",
+}
+
+func (f Format) String() string {
+	name, ok := formatNames[f]
+	if !ok {
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+	return name
+}
+
+// ParseFormat parses format from its name (i.e. "csv"), which is case insensitive.
+func ParseFormat(s string) (Format, error) {
+	for f, name := range formatNames {
+		if strings.EqualFold(s, name) {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("Format is unknown: %v", s)
+}
+
+// Config of encoding and decoding.
+type Config struct {
+	Format Format
+	// Columns to be encoded in order for CSV and XLSX, all columns are encoded if it is empty.
+	// Columns are read from header when decoding, so it is not needed.
+	Columns []string
+	// TimeLayout of times in CSV, time.RFC3339 is used if it is empty.
+	TimeLayout string
+	// DateLayout of dates in CSV (i.e. dates of dividends), "2006-01-02" is used if it is empty.
+	DateLayout string
+	// Location of times in CSV and XLSX, times are encoded in their own location and decoded in UTC if it is nil.
+	// Times in XLSX have no location, so it should be the same for encoding and decoding.
+	Location *time.Location
+	// Comma separating fields in CSV, ',' is used if it is zero.
+	Comma rune
+	// Decimal separator of numbers in CSV (i.e. ',' for Europe locales), '.' is used if it is zero.
+	Decimal rune
+}
+
+func (c Config) timeLayout() string {
+	if c.TimeLayout == "" {
+		return time.RFC3339
+	}
+	return c.TimeLayout
+}
+
+func (c Config) dateLayout() string {
+	if c.DateLayout == "" {
+		return "2006-01-02"
+	}
+	return c.DateLayout
+}
+
+func (c Config) location() *time.Location {
+	if c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
+
+// EncodePrices encodes historical prices to the writer.
+func EncodePrices(w io.Writer, prices []aastocks.HistoricalPrice, config Config) error {
+	return encode(w, priceSchema, reflect.ValueOf(prices), config)
+}
+
+// DecodePrices decodes historical prices from the reader.
+func DecodePrices(r io.Reader, config Config) ([]aastocks.HistoricalPrice, error) {
+	prices := make([]aastocks.HistoricalPrice, 0)
+	err := decode(r, priceSchema, &prices, config)
+	if err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// EncodeDividends encodes dividends to the writer.
+func EncodeDividends(w io.Writer, dividends []aastocks.Dividend, config Config) error {
+	return encode(w, dividendSchema, reflect.ValueOf(dividends), config)
+}
+
+// DecodeDividends decodes dividends from the reader.
+func DecodeDividends(r io.Reader, config Config) ([]aastocks.Dividend, error) {
+	dividends := make([]aastocks.Dividend, 0)
+	err := decode(r, dividendSchema, &dividends, config)
+	if err != nil {
+		return nil, err
+	}
+	return dividends, nil
+}
+
+// EncodeQuotes encodes quotes to the writer.
+func EncodeQuotes(w io.Writer, quotes []*aastocks.Quote, config Config) error {
+	return encode(w, quoteSchema, reflect.ValueOf(quotes), config)
+}
+
+// DecodeQuotes decodes quotes from the reader.
+func DecodeQuotes(r io.Reader, config Config) ([]*aastocks.Quote, error) {
+	quotes := make([]*aastocks.Quote, 0)
+	err := decode(r, quoteSchema, &quotes, config)
+	if err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
+
+func encode(w io.Writer, s *schema, rows reflect.Value, config Config) error {
+	switch config.Format {
+	case CSV:
+		columns, err := s.selectColumns(config.Columns)
+		if err != nil {
+			return err
+		}
+		return encodeCSV(w, columns, rows, config)
+	case NDJSON:
+		return encodeNDJSON(w, rows)
+	case XLSX:
+		columns, err := s.selectColumns(config.Columns)
+		if err != nil {
+			return err
+		}
+		return encodeXLSX(w, s.name, columns, rows, config)
+	}
+	return fmt.Errorf("Format is unknown: %v", config.Format)
+}
+
+// decode rows into pointer of slice.
+func decode(r io.Reader, s *schema, rows interface{}, config Config) error {
+	v := reflect.ValueOf(rows).Elem()
+	switch config.Format {
+	case CSV:
+		return decodeCSV(r, s, v, config)
+	case NDJSON:
+		return decodeNDJSON(r, v)
+	case XLSX:
+		return decodeXLSX(r, s, v, config)
+	}
+	return fmt.Errorf("Format is unknown: %v", config.Format)
+}
@@ -0,0 +1,253 @@
+package codec
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+var prices = []aastocks.HistoricalPrice{
+	{Time: time.Date(2020, time.August, 24, 0, 0, 0, 0, time.UTC), Open: 44.2, High: 44.7, Low: 44.15, Close: 44.2, Volume: 1426.486},
+	{Time: time.Date(2020, time.August, 25, 0, 0, 0, 0, time.UTC), Open: 44.2, High: 44.65, Low: 44.05, Close: 44.65, Volume: 1709.545},
+}
+
+var dividends = []aastocks.Dividend{
+	{
+		AnnounceDate: time.Date(2020, time.August, 5, 0, 0, 0, 0, time.UTC),
+		YearEnded:    time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC),
+		Event:        "Interim",
+		Particular:   "D:HKD 0.7700",
+		Type:         "Cash",
+		ExDate:       time.Date(2020, time.September, 3, 0, 0, 0, 0, time.UTC),
+		PayableDate:  time.Date(2020, time.September, 15, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		AnnounceDate: time.Date(2013, time.September, 27, 0, 0, 0, 0, time.UTC),
+		Event:        "Special",
+		Particular:   "Preferential Offer: 1 HK Electric Investments and HK Electric Investments Limited Share Stapled unit offer price HKD 5.4500 for every 4 Shares held",
+		Type:         "-",
+		ExDate:       time.Date(2014, time.January, 8, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+var quotes = []*aastocks.Quote{
+	{
+		Symbol:       "00006",
+		Name:         "POWER ASSETS",
+		Industry:     "Electricity Supply",
+		Price:        44.65,
+		PrevClose:    44.2,
+		Price52WLow:  41.6,
+		Price52WHigh: 58.5,
+		Yield:        0.06271,
+		PeRatio:      13.368,
+		PbRatio:      1.115,
+		Lots:         500,
+		Eps:          3.34,
+		UpdateTime:   time.Date(2020, time.August, 25, 21, 18, 38, 0, time.UTC),
+	},
+}
+
+func TestParseFormat(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		s        string
+		expected Format
+		err      error
+	}{
+		{
+			desc:     "CSV",
+			s:        "csv",
+			expected: CSV,
+		},
+		{
+			desc:     "CaseInsensitive",
+			s:        "XLSX",
+			expected: XLSX,
+		},
+		{
+			desc: "Unknown",
+			s:    "xml",
+			err:  fmt.Errorf("Format is unknown: xml"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			f, err := ParseFormat(tC.s)
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.expected, f)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	hongKong, err := time.LoadLocation("Asia/Hong_Kong")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		desc   string
+		config Config
+	}{
+		{
+			desc:   "CSV",
+			config: Config{Format: CSV},
+		},
+		{
+			desc:   "CSVLocale",
+			config: Config{Format: CSV, Comma: ';', Decimal: ',', TimeLayout: "02/01/2006 15:04:05", DateLayout: "02/01/2006", Location: hongKong},
+		},
+		{
+			desc:   "NDJSON",
+			config: Config{Format: NDJSON},
+		},
+		{
+			desc:   "XLSX",
+			config: Config{Format: XLSX},
+		},
+		{
+			desc:   "XLSXLocation",
+			config: Config{Format: XLSX, Location: hongKong},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var b bytes.Buffer
+			err := EncodePrices(&b, prices, tC.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decodedPrices, err := DecodePrices(&b, tC.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff := cmp.Diff(prices, decodedPrices)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			b.Reset()
+			err = EncodeDividends(&b, dividends, tC.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decodedDividends, err := DecodeDividends(&b, tC.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff = cmp.Diff(dividends, decodedDividends)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			b.Reset()
+			err = EncodeQuotes(&b, quotes, tC.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decodedQuotes, err := DecodeQuotes(&b, tC.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff = cmp.Diff(quotes, decodedQuotes, cmp.AllowUnexported(aastocks.Quote{}))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestEncodeColumns(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		columns []string
+		err     error
+	}{
+		{
+			desc:    "Known",
+			columns: []string{"close", "time"},
+		},
+		{
+			desc:    "Unknown",
+			columns: []string{"time", "adj_close"},
+			err:     fmt.Errorf("Column is unknown: adj_close"),
+		},
+	}
+	for _, tC := range testCases {
+		for _, format := range []Format{CSV, XLSX} {
+			t.Run(tC.desc+format.String(), func(t *testing.T) {
+				var b bytes.Buffer
+				err := EncodePrices(&b, prices, Config{Format: format, Columns: tC.columns})
+				diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+				if diff != "" {
+					t.Fatalf(diff)
+				}
+				if err != nil {
+					return
+				}
+				decoded, err := DecodePrices(&b, Config{Format: format})
+				if err != nil {
+					t.Fatal(err)
+				}
+				// Columns not encoded are decoded as zero
+				diff = cmp.Diff([]aastocks.HistoricalPrice{
+					{Time: prices[0].Time, Close: prices[0].Close},
+					{Time: prices[1].Time, Close: prices[1].Close},
+				}, decoded)
+				if diff != "" {
+					t.Fatalf(diff)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeNDJSON(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		s        string
+		expected []aastocks.HistoricalPrice
+		err      error
+	}{
+		{
+			desc:     "Empty",
+			s:        "",
+			expected: []aastocks.HistoricalPrice{},
+		},
+		{
+			desc: "Prices",
+			s:    `{"time":"2020-08-24T00:00:00Z","open":44.2,"high":44.7,"low":44.15,"close":44.2,"volume":1426.486}` + "\n",
+			expected: []aastocks.HistoricalPrice{
+				prices[0],
+			},
+		},
+		{
+			desc: "UnknownField",
+			s:    `{"time":"2020-08-24T00:00:00Z","adj_close":44.2}` + "\n",
+			err:  fmt.Errorf(`Row 1 failed to be decoded: json: unknown field "adj_close"`),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			decoded, err := DecodePrices(bytes.NewBufferString(tC.s), Config{Format: NDJSON})
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.expected, decoded)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package codec
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func encodeCSV(w io.Writer, columns []column, rows reflect.Value, config Config) error {
+	cw := csv.NewWriter(w)
+	if config.Comma != 0 {
+		cw.Comma = config.Comma
+	}
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = c.name
+	}
+	err := cw.Write(record)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < rows.Len(); i++ {
+		v, err := row(rows, i)
+		if err != nil {
+			return err
+		}
+		for j, c := range columns {
+			record[j] = formatCell(c, v.Field(c.index), config)
+		}
+		err = cw.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// formatCell formats value of the column, zero time is formatted as empty.
+func formatCell(c column, v reflect.Value, config Config) string {
+	switch c.kind {
+	case kindFloat:
+		s := strconv.FormatFloat(v.Float(), 'f', -1, 64)
+		if config.Decimal != 0 {
+			s = strings.Replace(s, ".", string(config.Decimal), 1)
+		}
+		return s
+	case kindInt:
+		return strconv.FormatInt(v.Int(), 10)
+	case kindTime:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		if config.Location != nil {
+			t = t.In(config.Location)
+		}
+		return t.Format(config.timeLayout())
+	case kindDate:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(config.dateLayout())
+	}
+	return v.String()
+}
+
+func decodeCSV(r io.Reader, s *schema, rows reflect.Value, config Config) error {
+	cr := csv.NewReader(r)
+	if config.Comma != 0 {
+		cr.Comma = config.Comma
+	}
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	columns := make([]column, len(header))
+	for i, name := range header {
+		columns[i], err = s.column(strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+	}
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		v := appendRow(rows)
+		for i, c := range columns {
+			err = parseCell(c, record[i], v.Field(c.index), config)
+			if err != nil {
+				return fmt.Errorf("Column %v failed to be parsed at line %v: %w", c.name, line, err)
+			}
+		}
+	}
+}
+
+// parseCell parses value of the column, empty is parsed as zero value.
+func parseCell(c column, s string, v reflect.Value, config Config) error {
+	if s == "" {
+		return nil
+	}
+	switch c.kind {
+	case kindFloat:
+		if config.Decimal != 0 {
+			s = strings.Replace(s, string(config.Decimal), ".", 1)
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case kindInt:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case kindTime:
+		t, err := time.ParseInLocation(config.timeLayout(), s, config.location())
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+	case kindDate:
+		t, err := time.Parse(config.dateLayout(), s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+	default:
+		v.SetString(s)
+	}
+	return nil
+}
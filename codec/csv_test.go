@@ -0,0 +1,127 @@
+package codec
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+func TestEncodeCSV(t *testing.T) {
+	hongKong, err := time.LoadLocation("Asia/Hong_Kong")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		desc     string
+		config   Config
+		encode   func(b *bytes.Buffer, config Config) error
+		expected string
+	}{
+		{
+			desc:   "Prices",
+			config: Config{},
+			encode: func(b *bytes.Buffer, config Config) error {
+				return EncodePrices(b, prices, config)
+			},
+			expected: "time,open,high,low,close,volume\n" +
+				"2020-08-24T00:00:00Z,44.2,44.7,44.15,44.2,1426.486\n" +
+				"2020-08-25T00:00:00Z,44.2,44.65,44.05,44.65,1709.545\n",
+		},
+		{
+			desc:   "PricesLocale",
+			config: Config{Columns: []string{"time", "close", "volume"}, Comma: ';', Decimal: ',', TimeLayout: "02/01/2006 15:04", Location: hongKong},
+			encode: func(b *bytes.Buffer, config Config) error {
+				return EncodePrices(b, prices, config)
+			},
+			expected: "time;close;volume\n" +
+				"24/08/2020 08:00;44,2;1426,486\n" +
+				"25/08/2020 08:00;44,65;1709,545\n",
+		},
+		{
+			desc:   "Dividends",
+			config: Config{Columns: []string{"ex_date", "particular", "payable_date"}},
+			encode: func(b *bytes.Buffer, config Config) error {
+				return EncodeDividends(b, dividends, config)
+			},
+			expected: "ex_date,particular,payable_date\n" +
+				"2020-09-03,D:HKD 0.7700,2020-09-15\n" +
+				"2014-01-08,Preferential Offer: 1 HK Electric Investments and HK Electric Investments Limited Share Stapled unit offer price HKD 5.4500 for every 4 Shares held,\n",
+		},
+		{
+			desc:   "Quotes",
+			config: Config{Columns: []string{"symbol", "name", "price", "lots", "update_time"}},
+			encode: func(b *bytes.Buffer, config Config) error {
+				return EncodeQuotes(b, quotes, config)
+			},
+			expected: "symbol,name,price,lots,update_time\n" +
+				"00006,POWER ASSETS,44.65,500,2020-08-25T21:18:38Z\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var b bytes.Buffer
+			tC.config.Format = CSV
+			err := tC.encode(&b, tC.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff := cmp.Diff(tC.expected, b.String())
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestDecodeCSV(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		s        string
+		expected []aastocks.Dividend
+		err      error
+	}{
+		{
+			desc:     "Empty",
+			s:        "",
+			expected: []aastocks.Dividend{},
+		},
+		{
+			desc: "HeaderOrder",
+			s: "particular, ex_date\n" +
+				"D:HKD 0.7700,2020-09-03\n",
+			expected: []aastocks.Dividend{
+				{Particular: "D:HKD 0.7700", ExDate: time.Date(2020, time.September, 3, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			desc: "UnknownColumn",
+			s: "ex_date,amount\n" +
+				"2020-09-03,0.77\n",
+			err: fmt.Errorf("Column is unknown: amount"),
+		},
+		{
+			desc: "InvalidDate",
+			s: "particular,ex_date\n" +
+				"D:HKD 0.7700,2020-09-03\n" +
+				"D:HKD 2.0300,18/05/2020\n",
+			err: fmt.Errorf(`Column ex_date failed to be parsed at line 3: parsing time "18/05/2020" as "2006-01-02": cannot parse "18/05/2020" as "2006"`),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			decoded, err := DecodeDividends(bytes.NewBufferString(tC.s), Config{Format: CSV})
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.expected, decoded)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
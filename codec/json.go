@@ -0,0 +1,40 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+func encodeNDJSON(w io.Writer, rows reflect.Value) error {
+	enc := json.NewEncoder(w)
+	for i := 0; i < rows.Len(); i++ {
+		v, err := row(rows, i)
+		if err != nil {
+			return err
+		}
+		err = enc.Encode(v.Interface())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeNDJSON decodes JSON objects, unknown fields are rejected as unknown columns in CSV.
+func decodeNDJSON(r io.Reader, rows reflect.Value) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	for i := 1; ; i++ {
+		v := reflect.New(rows.Type().Elem())
+		err := dec.Decode(v.Interface())
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Row %v failed to be decoded: %w", i, err)
+		}
+		rows.Set(reflect.Append(rows, v.Elem()))
+	}
+}
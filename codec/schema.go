@@ -0,0 +1,122 @@
+package codec
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// kind of column values.
+type kind int
+
+const (
+	kindString kind = iota
+	kindFloat
+	kindInt
+	kindTime
+	// kindDate is time without time of day, which is always in UTC
+	kindDate
+)
+
+type column struct {
+	name  string
+	index int
+	kind  kind
+}
+
+// schema of struct, with columns of its fields named by JSON field names.
+type schema struct {
+	name    string
+	typ     reflect.Type
+	columns []column
+}
+
+var (
+	priceSchema    = newSchema("Prices", aastocks.HistoricalPrice{}, kindTime)
+	dividendSchema = newSchema("Dividends", aastocks.Dividend{}, kindDate)
+	quoteSchema    = newSchema("Quotes", aastocks.Quote{}, kindTime)
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// newSchema creates schema of the struct, fields of time are columns of the time kind.
+func newSchema(name string, v interface{}, timeKind kind) *schema {
+	s := &schema{
+		name: name,
+		typ:  reflect.TypeOf(v),
+	}
+	for i := 0; i < s.typ.NumField(); i++ {
+		f := s.typ.Field(i)
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+		c := column{name: tag, index: i}
+		switch {
+		case f.Type == timeType:
+			c.kind = timeKind
+		case f.Type.Kind() == reflect.String:
+			c.kind = kindString
+		case f.Type.Kind() == reflect.Float64:
+			c.kind = kindFloat
+		case f.Type.Kind() == reflect.Int:
+			c.kind = kindInt
+		default:
+			panic(fmt.Sprintf("Field %v of %v is not supported", f.Name, s.typ))
+		}
+		s.columns = append(s.columns, c)
+	}
+	return s
+}
+
+func (s *schema) column(name string) (column, error) {
+	for _, c := range s.columns {
+		if c.name == name {
+			return c, nil
+		}
+	}
+	return column{}, fmt.Errorf("Column is unknown: %v", name)
+}
+
+// selectColumns of the names in order, all columns are returned if names are empty.
+func (s *schema) selectColumns(names []string) ([]column, error) {
+	if len(names) == 0 {
+		return s.columns, nil
+	}
+	columns := make([]column, len(names))
+	for i, name := range names {
+		c, err := s.column(name)
+		if err != nil {
+			return nil, err
+		}
+		columns[i] = c
+	}
+	return columns, nil
+}
+
+// row at index of rows, which is dereferenced if it is pointer.
+func row(rows reflect.Value, i int) (reflect.Value, error) {
+	v := rows.Index(i)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, fmt.Errorf("Row %v is nil", i)
+		}
+		v = v.Elem()
+	}
+	return v, nil
+}
+
+// appendRow appends zero row to pointer of rows, and returns it to be set.
+func appendRow(rows reflect.Value) reflect.Value {
+	elem := rows.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		v := reflect.New(elem.Elem())
+		rows.Set(reflect.Append(rows, v))
+		return v.Elem()
+	}
+	rows.Set(reflect.Append(rows, reflect.Zero(elem)))
+	return rows.Index(rows.Len() - 1)
+}
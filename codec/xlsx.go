@@ -0,0 +1,424 @@
+package codec
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	spreadsheetNamespace  = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	relationshipNamespace = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+)
+
+const contentTypesXML = `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const relsXML = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="` + relationshipNamespace + `/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const workbookXML = `<workbook xmlns="` + spreadsheetNamespace + `" xmlns:r="` + relationshipNamespace + `">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const workbookRelsXML = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="` + relationshipNamespace + `/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="` + relationshipNamespace + `/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+// stylesXML defines cell formats of times and dates, which are indexed by styleTime and styleDate.
+const stylesXML = `<styleSheet xmlns="` + spreadsheetNamespace + `">` +
+	`<numFmts count="2"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/><numFmt numFmtId="165" formatCode="yyyy-mm-dd"/></numFmts>` +
+	`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`</styleSheet>`
+
+const (
+	styleTime = 1
+	styleDate = 2
+)
+
+var (
+	excelEpoch     = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	excelEpoch1904 = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// serial of time in Excel, which is days since epoch of its wall clock.
+func serial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	seconds := float64(wall.Unix()-excelEpoch.Unix()) + float64(wall.Nanosecond())/1e9
+	return seconds / 86400
+}
+
+// fromSerial converts serial in Excel to time of wall clock in location, which is rounded to milliseconds.
+func fromSerial(f float64, epoch time.Time, loc *time.Location) time.Time {
+	ms := int64(math.Round(f * 86400 * 1000))
+	wall := epoch.Add(time.Duration(ms/1000) * time.Second).Add(time.Duration(ms%1000) * time.Millisecond)
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+}
+
+// columnName of index in Excel (i.e. "AA" for 26).
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// columnIndex of cell reference in Excel (i.e. 26 for "AA2"), -1 is returned if it has no column.
+func columnIndex(ref string) int {
+	i := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		i = i*26 + int(r-'A') + 1
+	}
+	return i - 1
+}
+
+func writeStringCell(b *bytes.Buffer, ref string, s string) {
+	if s == "" {
+		return
+	}
+	fmt.Fprintf(b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+	xml.EscapeText(b, []byte(s))
+	b.WriteString(`</t></is></c>`)
+}
+
+// writeCell writes value of the column as cell, empty strings and zero times are not written.
+func writeCell(b *bytes.Buffer, ref string, c column, v reflect.Value, config Config) {
+	switch c.kind {
+	case kindFloat:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return
+		}
+		fmt.Fprintf(b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(f, 'f', -1, 64))
+	case kindInt:
+		fmt.Fprintf(b, `<c r="%s"><v>%d</v></c>`, ref, v.Int())
+	case kindTime:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return
+		}
+		if config.Location != nil {
+			t = t.In(config.Location)
+		}
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, styleTime, strconv.FormatFloat(serial(t), 'f', -1, 64))
+	case kindDate:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return
+		}
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, styleDate, strconv.FormatFloat(serial(t), 'f', -1, 64))
+	default:
+		writeStringCell(b, ref, v.String())
+	}
+}
+
+func encodeXLSX(w io.Writer, sheet string, columns []column, rows reflect.Value, config Config) error {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	fmt.Fprintf(&b, `<worksheet xmlns="%s"><sheetData>`, spreadsheetNamespace)
+	b.WriteString(`<row r="1">`)
+	for i, c := range columns {
+		writeStringCell(&b, columnName(i)+"1", c.name)
+	}
+	b.WriteString(`</row>`)
+	for i := 0; i < rows.Len(); i++ {
+		v, err := row(rows, i)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, `<row r="%d">`, i+2)
+		for j, c := range columns {
+			writeCell(&b, columnName(j)+strconv.Itoa(i+2), c, v.Field(c.index), config)
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xml.Header + contentTypesXML},
+		{"_rels/.rels", xml.Header + relsXML},
+		{"xl/workbook.xml", xml.Header + fmt.Sprintf(workbookXML, sheet)},
+		{"xl/_rels/workbook.xml.rels", xml.Header + workbookRelsXML},
+		{"xl/styles.xml", xml.Header + stylesXML},
+		{"xl/worksheets/sheet1.xml", b.String()},
+	}
+	zw := zip.NewWriter(w)
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(f, p.content)
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+type xlsxWorkbook struct {
+	Properties struct {
+		Date1904 bool `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxText of plain text, or rich text with runs.
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	s := t.Text
+	for _, r := range t.Runs {
+		s += r.Text
+	}
+	return s
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Cells []xlsxCell `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type xlsxCell struct {
+	Ref    string   `xml:"r,attr"`
+	Type   string   `xml:"t,attr"`
+	Value  string   `xml:"v"`
+	Inline xlsxText `xml:"is"`
+}
+
+// text of cell, shared strings are looked up by its index.
+func (c xlsxCell) text(shared []string) (string, error) {
+	switch c.Type {
+	case "s":
+		i, err := strconv.Atoi(c.Value)
+		if err != nil || i < 0 || i >= len(shared) {
+			return "", fmt.Errorf("Shared string is invalid: %v", c.Value)
+		}
+		return shared[i], nil
+	case "inlineStr":
+		return c.Inline.String(), nil
+	}
+	return c.Value, nil
+}
+
+// isNumber reports whether value of cell is number, which is serial for times and dates.
+func (c xlsxCell) isNumber() bool {
+	return c.Type == "" || c.Type == "n"
+}
+
+// xlsxFiles of workbook by their names.
+type xlsxFiles map[string]*zip.File
+
+func (files xlsxFiles) read(name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("File cannot be found in workbook: %v", name)
+	}
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return xml.NewDecoder(r).Decode(v)
+}
+
+// target of relationship resolved from directory of its source.
+func target(dir string, t string) string {
+	if strings.HasPrefix(t, "/") {
+		return strings.TrimPrefix(t, "/")
+	}
+	return path.Join(dir, t)
+}
+
+// decodeXLSX decodes rows from the first sheet of workbook.
+// Times and dates are read from either serials or texts of the layouts in config.
+func decodeXLSX(r io.Reader, s *schema, rows reflect.Value, config Config) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return err
+	}
+	files := make(xlsxFiles)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var workbook xlsxWorkbook
+	err = files.read("xl/workbook.xml", &workbook)
+	if err != nil {
+		return err
+	}
+	if len(workbook.Sheets) == 0 {
+		return fmt.Errorf("Sheet cannot be found in workbook")
+	}
+	var rels xlsxRelationships
+	err = files.read("xl/_rels/workbook.xml.rels", &rels)
+	if err != nil {
+		return err
+	}
+	sheet := ""
+	shared := make([]string, 0)
+	for _, rel := range rels.Relationships {
+		switch {
+		case rel.ID == workbook.Sheets[0].ID:
+			sheet = target("xl", rel.Target)
+		case strings.HasSuffix(rel.Type, "/sharedStrings"):
+			var sst xlsxSharedStrings
+			err = files.read(target("xl", rel.Target), &sst)
+			if err != nil {
+				return err
+			}
+			for _, item := range sst.Items {
+				shared = append(shared, item.String())
+			}
+		}
+	}
+	if sheet == "" {
+		return fmt.Errorf("Sheet cannot be found in workbook: %v", workbook.Sheets[0].Name)
+	}
+	var worksheet xlsxWorksheet
+	err = files.read(sheet, &worksheet)
+	if err != nil {
+		return err
+	}
+	if len(worksheet.Rows) == 0 {
+		return nil
+	}
+
+	epoch := excelEpoch
+	if workbook.Properties.Date1904 {
+		epoch = excelEpoch1904
+	}
+	// Numbers in XLSX are not localized
+	config.Decimal = 0
+
+	columns := make(map[int]column)
+	err = eachCell(worksheet.Rows[0].Cells, func(i int, cell xlsxCell) error {
+		name, err := cell.text(shared)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			return nil
+		}
+		columns[i], err = s.column(strings.TrimSpace(name))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for n, row := range worksheet.Rows[1:] {
+		if len(row.Cells) == 0 {
+			continue
+		}
+		v := appendRow(rows)
+		err = eachCell(row.Cells, func(i int, cell xlsxCell) error {
+			c, ok := columns[i]
+			if !ok {
+				return nil
+			}
+			text, err := cell.text(shared)
+			if err != nil {
+				return err
+			}
+			if text == "" || !cell.isNumber() || (c.kind != kindTime && c.kind != kindDate) {
+				err = parseCell(c, text, v.Field(c.index), config)
+			} else {
+				err = parseSerial(c, text, v.Field(c.index), epoch, config)
+			}
+			if err != nil {
+				return fmt.Errorf("Column %v failed to be parsed at row %v: %w", c.name, n+2, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eachCell calls f with column index of each cell, cells without reference follow the previous one.
+func eachCell(cells []xlsxCell, f func(i int, cell xlsxCell) error) error {
+	i := -1
+	for _, cell := range cells {
+		i++
+		if cell.Ref != "" {
+			i = columnIndex(cell.Ref)
+		}
+		err := f(i, cell)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseSerial(c column, s string, v reflect.Value, epoch time.Time, config Config) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	if c.kind == kindDate {
+		t := fromSerial(f, epoch, time.UTC)
+		v.Set(reflect.ValueOf(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)))
+		return nil
+	}
+	v.Set(reflect.ValueOf(fromSerial(f, epoch, config.location())))
+	return nil
+}
@@ -0,0 +1,160 @@
+package codec
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+func TestColumnName(t *testing.T) {
+	testCases := []struct {
+		index int
+		name  string
+	}{
+		{index: 0, name: "A"},
+		{index: 25, name: "Z"},
+		{index: 26, name: "AA"},
+		{index: 701, name: "ZZ"},
+		{index: 702, name: "AAA"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			diff := cmp.Diff(tC.name, columnName(tC.index))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.index, columnIndex(fmt.Sprintf("%v12", tC.name)))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestSerial(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		t      time.Time
+		serial float64
+	}{
+		{
+			desc:   "Date",
+			t:      time.Date(2020, time.August, 25, 0, 0, 0, 0, time.UTC),
+			serial: 44068,
+		},
+		{
+			desc:   "Time",
+			t:      time.Date(2020, time.August, 25, 18, 0, 0, 0, time.UTC),
+			serial: 44068.75,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			diff := cmp.Diff(tC.serial, serial(tC.t))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.t, fromSerial(tC.serial, excelEpoch, time.UTC))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+// workbook creates XLSX with sheet, as it is saved by Excel with shared strings.
+func workbook(t *testing.T, date1904 bool, sheet string) *bytes.Buffer {
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	parts := map[string]string{
+		"xl/workbook.xml": fmt.Sprintf(`<workbook xmlns="`+spreadsheetNamespace+`" xmlns:r="`+relationshipNamespace+`">`+
+			`<workbookPr date1904="%v"/><sheets><sheet name="Dividends" sheetId="1" r:id="rId3"/></sheets></workbook>`, date1904),
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="` + relationshipNamespace + `/sharedStrings" Target="sharedStrings.xml"/>` +
+			`<Relationship Id="rId3" Type="` + relationshipNamespace + `/worksheet" Target="/xl/worksheets/dividends.xml"/>` +
+			`</Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="` + spreadsheetNamespace + `">` +
+			`<si><t>ex_date</t></si><si><t>particular</t></si><si><r><t>D:HKD </t></r><r><t>0.7700</t></r></si>` +
+			`</sst>`,
+		"xl/worksheets/dividends.xml": `<worksheet xmlns="` + spreadsheetNamespace + `"><sheetData>` + sheet + `</sheetData></worksheet>`,
+	}
+	for name, content := range parts {
+		f, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = f.Write([]byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := zw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &b
+}
+
+func TestDecodeXLSX(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		date1904 bool
+		sheet    string
+		expected []aastocks.Dividend
+		err      error
+	}{
+		{
+			desc:  "SharedStrings",
+			sheet: `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row><row r="2"><c r="A2" s="1"><v>44077</v></c><c r="C2" t="s"><v>2</v></c></row>`,
+			expected: []aastocks.Dividend{
+				{Particular: "D:HKD 0.7700", ExDate: time.Date(2020, time.September, 3, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			desc:     "Date1904",
+			date1904: true,
+			sheet:    `<row><c t="s"><v>0</v></c></row><row><c><v>42615</v></c></row>`,
+			expected: []aastocks.Dividend{
+				{ExDate: time.Date(2020, time.September, 3, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			desc:  "DateText",
+			sheet: `<row><c t="inlineStr"><is><t>ex_date</t></is></c></row><row></row><row><c t="str"><v>2020-09-03</v></c></row>`,
+			expected: []aastocks.Dividend{
+				{ExDate: time.Date(2020, time.September, 3, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			desc:  "UnknownColumn",
+			sheet: `<row><c t="inlineStr"><is><t>amount</t></is></c></row>`,
+			err:   fmt.Errorf("Column is unknown: amount"),
+		},
+		{
+			desc:  "InvalidDate",
+			sheet: `<row><c t="s"><v>0</v></c></row><row r="2"><c t="inlineStr"><is><t>TBC</t></is></c></row>`,
+			err:   fmt.Errorf(`Column ex_date failed to be parsed at row 2: parsing time "TBC" as "2006-01-02": cannot parse "TBC" as "2006"`),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			decoded, err := DecodeDividends(workbook(t, tC.date1904, tC.sheet), Config{Format: XLSX})
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			if err != nil {
+				return
+			}
+			diff = cmp.Diff(tC.expected, decoded)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Command aastocks-parquet backfills historical prices and dividends of symbols from AAStocks
+// into Parquet dataset partitioned by symbol and year, which is merged with files written before.
+// Symbols failed to be fetched are logged and skipped, and exit code is 1 if there is any.
+//
+// Usage:
+//
+//	aastocks-parquet -dir lake -universe hk.txt -freq daily,hourly -dividends -interval 200ms -burst 5 [symbol...]
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/columnar"
+	"github.com/horacehylee/aastocks/internal/symbolfile"
+)
+
+var frequencies = map[string]aastocks.PriceFrequency{
+	"hourly":  aastocks.Hourly,
+	"daily":   aastocks.Daily,
+	"weekly":  aastocks.Weekly,
+	"monthly": aastocks.Monthly,
+}
+
+func main() {
+	dir := flag.String("dir", "lake", "directory of dataset")
+	universe := flag.String("universe", "", "file of symbols to be backfilled, one per line")
+	freq := flag.String("freq", "daily", "frequencies of prices separated by comma: hourly, daily, weekly or monthly")
+	dividends := flag.Bool("dividends", false, "backfill dividends too")
+	interval := flag.Duration("interval", 200*time.Millisecond, "interval between requests to AAStocks")
+	burst := flag.Int("burst", 5, "burst of requests to AAStocks")
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "", log.Flags())
+	symbols := flag.Args()
+	if *universe != "" {
+		s, err := symbolfile.Read(*universe)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		symbols = append(symbols, s...)
+	}
+	if len(symbols) == 0 {
+		logger.Fatal("Symbols cannot be empty")
+	}
+	freqs := make([]aastocks.PriceFrequency, 0)
+	for _, name := range strings.Split(*freq, ",") {
+		f, ok := frequencies[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			logger.Fatalf("Frequency is unknown: %v", name)
+		}
+		freqs = append(freqs, f)
+	}
+
+	d, err := columnar.OpenDataset(*dir, columnar.Config{})
+	if err != nil {
+		logger.Fatal(err)
+	}
+	// Responses are not cached, as each of them is fetched once
+	client := aastocks.NewCachedClient(0, aastocks.NewRateLimiter(*interval, *burst))
+	failed := 0
+	for _, symbol := range symbols {
+		err := backfill(d, symbol, freqs, *dividends, client)
+		if err != nil {
+			logger.Printf("Error: %v: %v\n", symbol, err)
+			failed++
+			continue
+		}
+		logger.Printf("Backfilled %v\n", symbol)
+	}
+	if failed > 0 {
+		logger.Printf("%v of %v symbols failed to be backfilled\n", failed, len(symbols))
+		os.Exit(1)
+	}
+}
+
+func backfill(d *columnar.Dataset, symbol string, freqs []aastocks.PriceFrequency, dividends bool, client *http.Client) error {
+	q, err := aastocks.Get(symbol, aastocks.WithClient(client))
+	if err != nil {
+		return err
+	}
+	for _, freq := range freqs {
+		prices, err := q.HistoricalPrices(freq)
+		if err != nil {
+			return err
+		}
+		err = d.WritePrices(columnar.PriceSeries{Symbol: symbol, Frequency: freq, Prices: prices})
+		if err != nil {
+			return err
+		}
+	}
+	if !dividends {
+		return nil
+	}
+	ds, err := q.Dividends()
+	if err != nil {
+		return err
+	}
+	return d.WriteDividends(columnar.DividendSeries{Symbol: symbol, Dividends: ds})
+}
@@ -0,0 +1,242 @@
+// Package columnar converts historical prices and dividends of AAStocks to Apache Arrow record batches,
+// and writes them as Parquet files for data lake, which can be read by Spark without parsing CSV.
+//
+// Each row has symbol of its series, and prices have frequency too, so that series of many symbols
+// can be written as single record. Times of AAStocks are wall clock of Hong Kong,
+// so they are written as timestamps in Hong Kong time zone, while dividend dates are written as dates.
+//
+//	err := columnar.WritePrices(f, []columnar.PriceSeries{
+//		{Symbol: "00006", Frequency: aastocks.Daily, Prices: prices},
+//	}, columnar.Config{})
+//
+// Dataset writes files partitioned by symbol and year, which are merged with those written before.
+//
+//	d, err := columnar.OpenDataset("lake", columnar.Config{})
+//	err = d.WritePrices(columnar.PriceSeries{Symbol: "00006", Frequency: aastocks.Daily, Prices: prices})
+package columnar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/horacehylee/aastocks"
+)
+
+// TimeZone of timestamps in schemas.
+const TimeZone = "Asia/Hong_Kong"
+
+// PriceSchema of historical prices.
+var PriceSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "symbol", Type: arrow.BinaryTypes.String},
+	{Name: "frequency", Type: arrow.BinaryTypes.String},
+	{Name: "time", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: TimeZone}},
+	{Name: "open", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "high", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "low", Type: arrow.PrimitiveTypes.Float64},
+	{Name: "close", Type: arrow.PrimitiveTypes.Float64},
+	// volume in thousands of shares
+	{Name: "volume", Type: arrow.PrimitiveTypes.Float64},
+}, nil)
+
+// DividendSchema of dividends, dates not available are null.
+var DividendSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "symbol", Type: arrow.BinaryTypes.String},
+	{Name: "announce_date", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+	{Name: "year_ended", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+	{Name: "event", Type: arrow.BinaryTypes.String},
+	{Name: "particular", Type: arrow.BinaryTypes.String},
+	{Name: "type", Type: arrow.BinaryTypes.String},
+	{Name: "ex_date", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+	{Name: "payable_date", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+}, nil)
+
+var frequencyNames = map[aastocks.PriceFrequency]string{
+	aastocks.Hourly:  "hourly",
+	aastocks.Daily:   "daily",
+	aastocks.Weekly:  "weekly",
+	aastocks.Monthly: "monthly",
+}
+
+func frequencyName(freq aastocks.PriceFrequency) (string, error) {
+	name, ok := frequencyNames[freq]
+	if !ok {
+		return "", fmt.Errorf("Frequency is unknown: %v", freq)
+	}
+	return name, nil
+}
+
+func parseFrequency(name string) (aastocks.PriceFrequency, error) {
+	for freq, n := range frequencyNames {
+		if n == name {
+			return freq, nil
+		}
+	}
+	return 0, fmt.Errorf("Frequency is unknown: %v", name)
+}
+
+// PriceSeries of historical prices of symbol with frequency.
+type PriceSeries struct {
+	Symbol    string
+	Frequency aastocks.PriceFrequency
+	Prices    []aastocks.HistoricalPrice
+}
+
+// DividendSeries of dividends of symbol.
+type DividendSeries struct {
+	Symbol    string
+	Dividends []aastocks.Dividend
+}
+
+// timestamp of wall clock in Hong Kong.
+func timestamp(wall time.Time) arrow.Timestamp {
+	return arrow.Timestamp(aastocks.HongKongTime(wall).UnixNano() / int64(time.Millisecond))
+}
+
+// NewPriceRecord creates record of the series with PriceSchema, which should be released after use.
+func NewPriceRecord(mem memory.Allocator, series ...PriceSeries) (arrow.Record, error) {
+	b := array.NewRecordBuilder(mem, PriceSchema)
+	defer b.Release()
+	for _, s := range series {
+		freq, err := frequencyName(s.Frequency)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range s.Prices {
+			b.Field(0).(*array.StringBuilder).Append(s.Symbol)
+			b.Field(1).(*array.StringBuilder).Append(freq)
+			b.Field(2).(*array.TimestampBuilder).Append(timestamp(p.Time))
+			b.Field(3).(*array.Float64Builder).Append(p.Open)
+			b.Field(4).(*array.Float64Builder).Append(p.High)
+			b.Field(5).(*array.Float64Builder).Append(p.Low)
+			b.Field(6).(*array.Float64Builder).Append(p.Close)
+			b.Field(7).(*array.Float64Builder).Append(p.Volume)
+		}
+	}
+	return b.NewRecord(), nil
+}
+
+// NewDividendRecord creates record of the series with DividendSchema, which should be released after use.
+func NewDividendRecord(mem memory.Allocator, series ...DividendSeries) arrow.Record {
+	b := array.NewRecordBuilder(mem, DividendSchema)
+	defer b.Release()
+	appendDate := func(i int, t time.Time) {
+		if t.IsZero() {
+			b.Field(i).AppendNull()
+			return
+		}
+		b.Field(i).(*array.Date32Builder).Append(arrow.Date32FromTime(t))
+	}
+	for _, s := range series {
+		for _, d := range s.Dividends {
+			b.Field(0).(*array.StringBuilder).Append(s.Symbol)
+			appendDate(1, d.AnnounceDate)
+			appendDate(2, d.YearEnded)
+			b.Field(3).(*array.StringBuilder).Append(d.Event)
+			b.Field(4).(*array.StringBuilder).Append(d.Particular)
+			b.Field(5).(*array.StringBuilder).Append(d.Type)
+			appendDate(6, d.ExDate)
+			appendDate(7, d.PayableDate)
+		}
+	}
+	return b.NewRecord()
+}
+
+// checkSchema checks if fields of schema are of the expected one.
+// Units of timestamps are not checked, as files written by others may have different units.
+func checkSchema(schema *arrow.Schema, expected *arrow.Schema) error {
+	if len(schema.Fields()) != len(expected.Fields()) {
+		return fmt.Errorf("Schema is unexpected: %v", schema)
+	}
+	for i, f := range expected.Fields() {
+		actual := schema.Field(i)
+		if actual.Name != f.Name || actual.Type.ID() != f.Type.ID() {
+			return fmt.Errorf("Schema is unexpected: %v", schema)
+		}
+	}
+	return nil
+}
+
+// PriceSeriesOf record with PriceSchema, consecutive rows of the same symbol and frequency are in the same series.
+// Series can be appended to those of previous record.
+func PriceSeriesOf(record arrow.Record, series []PriceSeries) ([]PriceSeries, error) {
+	err := checkSchema(record.Schema(), PriceSchema)
+	if err != nil {
+		return nil, err
+	}
+	symbols := record.Column(0).(*array.String)
+	frequencies := record.Column(1).(*array.String)
+	times := record.Column(2).(*array.Timestamp)
+	unit := record.Schema().Field(2).Type.(*arrow.TimestampType).Unit
+	open := record.Column(3).(*array.Float64)
+	high := record.Column(4).(*array.Float64)
+	low := record.Column(5).(*array.Float64)
+	close := record.Column(6).(*array.Float64)
+	volume := record.Column(7).(*array.Float64)
+	for i := 0; i < int(record.NumRows()); i++ {
+		freq, err := parseFrequency(frequencies.Value(i))
+		if err != nil {
+			return nil, err
+		}
+		n := len(series)
+		if n == 0 || series[n-1].Symbol != symbols.Value(i) || series[n-1].Frequency != freq {
+			series = append(series, PriceSeries{
+				Symbol:    symbols.Value(i),
+				Frequency: freq,
+				Prices:    make([]aastocks.HistoricalPrice, 0),
+			})
+			n++
+		}
+		series[n-1].Prices = append(series[n-1].Prices, aastocks.HistoricalPrice{
+			Time:   aastocks.WallClock(times.Value(i).ToTime(unit)),
+			Open:   open.Value(i),
+			High:   high.Value(i),
+			Low:    low.Value(i),
+			Close:  close.Value(i),
+			Volume: volume.Value(i),
+		})
+	}
+	return series, nil
+}
+
+// DividendSeriesOf record with DividendSchema, consecutive rows of the same symbol are in the same series.
+// Series can be appended to those of previous record.
+func DividendSeriesOf(record arrow.Record, series []DividendSeries) ([]DividendSeries, error) {
+	err := checkSchema(record.Schema(), DividendSchema)
+	if err != nil {
+		return nil, err
+	}
+	symbols := record.Column(0).(*array.String)
+	date := func(column int, i int) time.Time {
+		dates := record.Column(column).(*array.Date32)
+		if dates.IsNull(i) {
+			return time.Time{}
+		}
+		return dates.Value(i).ToTime()
+	}
+	text := func(column int, i int) string {
+		return record.Column(column).(*array.String).Value(i)
+	}
+	for i := 0; i < int(record.NumRows()); i++ {
+		n := len(series)
+		if n == 0 || series[n-1].Symbol != symbols.Value(i) {
+			series = append(series, DividendSeries{
+				Symbol:    symbols.Value(i),
+				Dividends: make([]aastocks.Dividend, 0),
+			})
+			n++
+		}
+		series[n-1].Dividends = append(series[n-1].Dividends, aastocks.Dividend{
+			AnnounceDate: date(1, i),
+			YearEnded:    date(2, i),
+			Event:        text(3, i),
+			Particular:   text(4, i),
+			Type:         text(5, i),
+			ExDate:       date(6, i),
+			PayableDate:  date(7, i),
+		})
+	}
+	return series, nil
+}
@@ -0,0 +1,114 @@
+package columnar
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+var prices = []aastocks.HistoricalPrice{
+	{Time: time.Date(2020, time.August, 24, 0, 0, 0, 0, time.UTC), Open: 44.2, High: 44.7, Low: 44.15, Close: 44.2, Volume: 1426.486},
+	{Time: time.Date(2020, time.August, 25, 0, 0, 0, 0, time.UTC), Open: 44.2, High: 44.65, Low: 44.05, Close: 44.65, Volume: 1709.545},
+}
+
+var dividends = []aastocks.Dividend{
+	{
+		AnnounceDate: time.Date(2013, time.September, 27, 0, 0, 0, 0, time.UTC),
+		Event:        "Special",
+		Particular:   "Preferential Offer: 1 HK Electric Investments and HK Electric Investments Limited Share Stapled unit offer price HKD 5.4500 for every 4 Shares held",
+		Type:         "-",
+		ExDate:       time.Date(2014, time.January, 8, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		AnnounceDate: time.Date(2020, time.August, 5, 0, 0, 0, 0, time.UTC),
+		YearEnded:    time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC),
+		Event:        "Interim",
+		Particular:   "D:HKD 0.7700",
+		Type:         "Cash",
+		ExDate:       time.Date(2020, time.September, 3, 0, 0, 0, 0, time.UTC),
+		PayableDate:  time.Date(2020, time.September, 15, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestPriceRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	series := []PriceSeries{
+		{Symbol: "00006", Frequency: aastocks.Daily, Prices: prices},
+		{Symbol: "00006", Frequency: aastocks.Hourly, Prices: prices[:1]},
+		{Symbol: "00005", Frequency: aastocks.Daily, Prices: prices[1:]},
+	}
+	record, err := NewPriceRecord(mem, series...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer record.Release()
+
+	diff := cmp.Diff(int64(4), record.NumRows())
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	// Wall clock of Hong Kong is timestamp in Hong Kong
+	times := record.Column(2).(*array.Timestamp)
+	diff = cmp.Diff(time.Date(2020, time.August, 23, 16, 0, 0, 0, time.UTC), times.Value(0).ToTime(arrow.Millisecond))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	decoded, err := PriceSeriesOf(record, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff = cmp.Diff(series, decoded)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestPriceRecordUnknownFrequency(t *testing.T) {
+	_, err := NewPriceRecord(memory.DefaultAllocator, PriceSeries{Symbol: "00006", Frequency: aastocks.PriceFrequency(1), Prices: prices})
+	diff := cmp.Diff("Frequency is unknown: 1", fmt.Sprint(err))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestDividendRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	series := []DividendSeries{
+		{Symbol: "00006", Dividends: dividends},
+		{Symbol: "00005", Dividends: []aastocks.Dividend{}},
+	}
+	record := NewDividendRecord(mem, series...)
+	defer record.Release()
+
+	// Dates not available are null
+	diff := cmp.Diff(true, record.Column(7).IsNull(0))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	decoded, err := DividendSeriesOf(record, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Series without dividends has no rows
+	diff = cmp.Diff(series[:1], decoded)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	_, err = PriceSeriesOf(record, nil)
+	if err == nil {
+		t.Fatalf("Error should be returned for record of dividends")
+	}
+}
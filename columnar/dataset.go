@@ -0,0 +1,319 @@
+package columnar
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/internal/atomicfile"
+)
+
+// Dataset of Parquet files under directory, partitioned by symbol and year:
+//
+//	prices/<symbol>/<year>/<frequency>.parquet
+//	dividends/<symbol>/<year>/dividends.parquet
+//
+// Symbol is in rows too, so directories are not named as Hive partitions, which Spark rejects as duplicated columns.
+// Dataset is safe for concurrent use, but directory should not be shared by multiple processes.
+type Dataset struct {
+	dir    string
+	config Config
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// OpenDataset under the directory, which is created if it does not exist.
+func OpenDataset(dir string, config Config) (*Dataset, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &Dataset{
+		dir:    dir,
+		config: config,
+		locks:  make(map[string]*sync.Mutex),
+	}, nil
+}
+
+// checkSymbol checks if symbol can be used as name of its directory.
+func checkSymbol(symbol string) error {
+	if symbol == "" || symbol == "." || symbol == ".." || filepath.Base(symbol) != symbol {
+		return fmt.Errorf("Symbol is invalid: %q", symbol)
+	}
+	return nil
+}
+
+// lock file of the path, and returns function to unlock it.
+func (d *Dataset) lock(path string) func() {
+	d.mu.Lock()
+	l, ok := d.locks[path]
+	if !ok {
+		l = &sync.Mutex{}
+		d.locks[path] = l
+	}
+	d.mu.Unlock()
+	l.Lock()
+	return l.Unlock
+}
+
+// years of partitions under directory of the symbol, in ascending order.
+func years(dir string) ([]int, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	years := make([]int, 0, len(infos))
+	for _, info := range infos {
+		year, err := strconv.Atoi(info.Name())
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	return years, nil
+}
+
+// readFile of the path, nil is returned if it does not exist.
+func readFile(path string) (*bytes.Reader, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(b), nil
+}
+
+func (d *Dataset) pricePath(symbol string, year int, freq aastocks.PriceFrequency) (string, error) {
+	err := checkSymbol(symbol)
+	if err != nil {
+		return "", err
+	}
+	name, err := frequencyName(freq)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d.dir, "prices", symbol, strconv.Itoa(year), name+".parquet"), nil
+}
+
+// WritePrices writes series into partitions of years of its prices.
+// Prices are merged with those written before, and prices of the same time are replaced.
+func (d *Dataset) WritePrices(series PriceSeries) error {
+	byYear := make(map[int][]aastocks.HistoricalPrice)
+	for _, p := range series.Prices {
+		byYear[p.Time.Year()] = append(byYear[p.Time.Year()], p)
+	}
+	for year, prices := range byYear {
+		path, err := d.pricePath(series.Symbol, year, series.Frequency)
+		if err != nil {
+			return err
+		}
+		err = d.mergePrices(path, PriceSeries{Symbol: series.Symbol, Frequency: series.Frequency, Prices: prices})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dataset) mergePrices(path string, series PriceSeries) error {
+	unlock := d.lock(path)
+	defer unlock()
+
+	stored, err := d.readPrices(path)
+	if err != nil {
+		return err
+	}
+	byTime := make(map[int64]int, len(stored)+len(series.Prices))
+	merged := make([]aastocks.HistoricalPrice, 0, len(stored)+len(series.Prices))
+	for _, p := range append(stored, series.Prices...) {
+		if i, ok := byTime[p.Time.UnixNano()]; ok {
+			merged[i] = p
+			continue
+		}
+		byTime[p.Time.UnixNano()] = len(merged)
+		merged = append(merged, p)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+	series.Prices = merged
+	return atomicfile.Write(path, func(f io.Writer) error {
+		return WritePrices(f, []PriceSeries{series}, d.config)
+	})
+}
+
+// readPrices of partition file, empty prices are returned if it does not exist.
+func (d *Dataset) readPrices(path string) ([]aastocks.HistoricalPrice, error) {
+	r, err := readFile(path)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	series, err := ReadPrices(r, d.config)
+	if err != nil {
+		return nil, fmt.Errorf("Prices failed to be read from %v: %w", path, err)
+	}
+	prices := make([]aastocks.HistoricalPrice, 0)
+	for _, s := range series {
+		prices = append(prices, s.Prices...)
+	}
+	return prices, nil
+}
+
+// Prices of the symbol with frequency from partitions of all years.
+// Series with empty prices is returned if none is written.
+func (d *Dataset) Prices(symbol string, freq aastocks.PriceFrequency) (PriceSeries, error) {
+	series := PriceSeries{
+		Symbol:    symbol,
+		Frequency: freq,
+		Prices:    make([]aastocks.HistoricalPrice, 0),
+	}
+	_, err := d.pricePath(symbol, 0, freq)
+	if err != nil {
+		return PriceSeries{}, err
+	}
+	years, err := years(filepath.Join(d.dir, "prices", symbol))
+	if err != nil {
+		return PriceSeries{}, err
+	}
+	for _, year := range years {
+		path, _ := d.pricePath(symbol, year, freq)
+		unlock := d.lock(path)
+		prices, err := d.readPrices(path)
+		unlock()
+		if err != nil {
+			return PriceSeries{}, err
+		}
+		series.Prices = append(series.Prices, prices...)
+	}
+	return series, nil
+}
+
+func (d *Dataset) dividendPath(symbol string, year int) (string, error) {
+	err := checkSymbol(symbol)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d.dir, "dividends", symbol, strconv.Itoa(year), "dividends.parquet"), nil
+}
+
+// dividendKey identifies dividend, as dividends are revised over time without identifiers.
+type dividendKey struct {
+	announceDate int64
+	event        string
+	particular   string
+}
+
+func keyOf(d aastocks.Dividend) dividendKey {
+	return dividendKey{
+		announceDate: d.AnnounceDate.UnixNano(),
+		event:        d.Event,
+		particular:   d.Particular,
+	}
+}
+
+// WriteDividends writes series into partitions of years of announce dates of its dividends.
+// Dividends are merged with those written before, and dividends of the same announce date, event and particular are replaced.
+func (d *Dataset) WriteDividends(series DividendSeries) error {
+	byYear := make(map[int][]aastocks.Dividend)
+	for _, dividend := range series.Dividends {
+		year := dividend.AnnounceDate.Year()
+		byYear[year] = append(byYear[year], dividend)
+	}
+	for year, dividends := range byYear {
+		path, err := d.dividendPath(series.Symbol, year)
+		if err != nil {
+			return err
+		}
+		err = d.mergeDividends(path, DividendSeries{Symbol: series.Symbol, Dividends: dividends})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dataset) mergeDividends(path string, series DividendSeries) error {
+	unlock := d.lock(path)
+	defer unlock()
+
+	stored, err := d.readDividends(path)
+	if err != nil {
+		return err
+	}
+	byKey := make(map[dividendKey]int, len(stored)+len(series.Dividends))
+	merged := make([]aastocks.Dividend, 0, len(stored)+len(series.Dividends))
+	for _, dividend := range append(stored, series.Dividends...) {
+		if i, ok := byKey[keyOf(dividend)]; ok {
+			merged[i] = dividend
+			continue
+		}
+		byKey[keyOf(dividend)] = len(merged)
+		merged = append(merged, dividend)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].AnnounceDate.Before(merged[j].AnnounceDate)
+	})
+	series.Dividends = merged
+	return atomicfile.Write(path, func(f io.Writer) error {
+		return WriteDividends(f, []DividendSeries{series}, d.config)
+	})
+}
+
+// readDividends of partition file, empty dividends are returned if it does not exist.
+func (d *Dataset) readDividends(path string) ([]aastocks.Dividend, error) {
+	r, err := readFile(path)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	series, err := ReadDividends(r, d.config)
+	if err != nil {
+		return nil, fmt.Errorf("Dividends failed to be read from %v: %w", path, err)
+	}
+	dividends := make([]aastocks.Dividend, 0)
+	for _, s := range series {
+		dividends = append(dividends, s.Dividends...)
+	}
+	return dividends, nil
+}
+
+// Dividends of the symbol from partitions of all years, in ascending order of announce dates.
+// Series with empty dividends is returned if none is written.
+func (d *Dataset) Dividends(symbol string) (DividendSeries, error) {
+	series := DividendSeries{
+		Symbol:    symbol,
+		Dividends: make([]aastocks.Dividend, 0),
+	}
+	err := checkSymbol(symbol)
+	if err != nil {
+		return DividendSeries{}, err
+	}
+	years, err := years(filepath.Join(d.dir, "dividends", symbol))
+	if err != nil {
+		return DividendSeries{}, err
+	}
+	for _, year := range years {
+		path, _ := d.dividendPath(symbol, year)
+		unlock := d.lock(path)
+		dividends, err := d.readDividends(path)
+		unlock()
+		if err != nil {
+			return DividendSeries{}, err
+		}
+		series.Dividends = append(series.Dividends, dividends...)
+	}
+	return series, nil
+}
@@ -0,0 +1,137 @@
+package columnar
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+func testDataset(t *testing.T) *Dataset {
+	dir, err := ioutil.TempDir("", "columnar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	d, err := OpenDataset(dir, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestDatasetPrices(t *testing.T) {
+	d := testDataset(t)
+	lastYear := aastocks.HistoricalPrice{Time: time.Date(2019, time.December, 31, 0, 0, 0, 0, time.UTC), Close: 58}
+	err := d.WritePrices(PriceSeries{Symbol: "00006", Frequency: aastocks.Daily, Prices: []aastocks.HistoricalPrice{prices[1], lastYear}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Prices of the same time are replaced
+	revised := prices[1]
+	revised.Close = 44.7
+	err = d.WritePrices(PriceSeries{Symbol: "00006", Frequency: aastocks.Daily, Prices: []aastocks.HistoricalPrice{prices[0], revised}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"prices/00006/2019/daily.parquet", "prices/00006/2020/daily.parquet"} {
+		_, err = os.Stat(filepath.Join(d.dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	series, err := d.Prices("00006", aastocks.Daily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff(PriceSeries{
+		Symbol:    "00006",
+		Frequency: aastocks.Daily,
+		Prices:    []aastocks.HistoricalPrice{lastYear, prices[0], revised},
+	}, series)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	series, err = d.Prices("00006", aastocks.Hourly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff = cmp.Diff(PriceSeries{Symbol: "00006", Frequency: aastocks.Hourly, Prices: []aastocks.HistoricalPrice{}}, series)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestDatasetDividends(t *testing.T) {
+	d := testDataset(t)
+	err := d.WriteDividends(DividendSeries{Symbol: "00006", Dividends: dividends})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Dividends of the same announce date, event and particular are replaced
+	revised := dividends[1]
+	revised.PayableDate = time.Date(2020, time.September, 16, 0, 0, 0, 0, time.UTC)
+	err = d.WriteDividends(DividendSeries{Symbol: "00006", Dividends: []aastocks.Dividend{revised}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	series, err := d.Dividends("00006")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff(DividendSeries{Symbol: "00006", Dividends: []aastocks.Dividend{dividends[0], revised}}, series)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestDatasetInvalid(t *testing.T) {
+	d := testDataset(t)
+	testCases := []struct {
+		desc string
+		f    func() error
+		err  error
+	}{
+		{
+			desc: "Symbol",
+			f: func() error {
+				return d.WritePrices(PriceSeries{Symbol: "../00006", Frequency: aastocks.Daily, Prices: prices})
+			},
+			err: fmt.Errorf(`Symbol is invalid: "../00006"`),
+		},
+		{
+			desc: "Frequency",
+			f: func() error {
+				_, err := d.Prices("00006", aastocks.PriceFrequency(1))
+				return err
+			},
+			err: fmt.Errorf("Frequency is unknown: 1"),
+		},
+		{
+			desc: "DividendsSymbol",
+			f: func() error {
+				_, err := d.Dividends("")
+				return err
+			},
+			err: fmt.Errorf(`Symbol is invalid: ""`),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := tC.f()
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package columnar
+
+import (
+	"context"
+	"io"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+)
+
+// Config of Parquet files.
+type Config struct {
+	// Allocator of Arrow records, memory.DefaultAllocator is used if it is nil.
+	Allocator memory.Allocator
+	// Properties of Parquet writer (i.e. compression and row group length),
+	// Snappy compression with default properties is used if it is nil.
+	Properties *parquet.WriterProperties
+}
+
+func (c Config) allocator() memory.Allocator {
+	if c.Allocator == nil {
+		return memory.DefaultAllocator
+	}
+	return c.Allocator
+}
+
+func (c Config) properties() *parquet.WriterProperties {
+	if c.Properties == nil {
+		return parquet.NewWriterProperties(
+			parquet.WithCompression(compress.Codecs.Snappy),
+			parquet.WithAllocator(c.allocator()),
+		)
+	}
+	return c.Properties
+}
+
+// WritePrices writes series of historical prices to the writer as Parquet file with PriceSchema.
+func WritePrices(w io.Writer, series []PriceSeries, config Config) error {
+	record, err := NewPriceRecord(config.allocator(), series...)
+	if err != nil {
+		return err
+	}
+	defer record.Release()
+	return writeParquet(w, record, config)
+}
+
+// ReadPrices reads series of historical prices from Parquet file with PriceSchema.
+func ReadPrices(r parquet.ReaderAtSeeker, config Config) ([]PriceSeries, error) {
+	series := make([]PriceSeries, 0)
+	err := readParquet(r, config, func(record arrow.Record) error {
+		var err error
+		series, err = PriceSeriesOf(record, series)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// WriteDividends writes series of dividends to the writer as Parquet file with DividendSchema.
+func WriteDividends(w io.Writer, series []DividendSeries, config Config) error {
+	record := NewDividendRecord(config.allocator(), series...)
+	defer record.Release()
+	return writeParquet(w, record, config)
+}
+
+// ReadDividends reads series of dividends from Parquet file with DividendSchema.
+func ReadDividends(r parquet.ReaderAtSeeker, config Config) ([]DividendSeries, error) {
+	series := make([]DividendSeries, 0)
+	err := readParquet(r, config, func(record arrow.Record) error {
+		var err error
+		series, err = DividendSeriesOf(record, series)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// unclosableWriter prevents writer from being closed by Parquet writer, as it is owned by caller.
+type unclosableWriter struct {
+	io.Writer
+}
+
+// writeParquet writes record with its Arrow schema stored, so that time zone of timestamps is kept.
+func writeParquet(w io.Writer, record arrow.Record, config Config) error {
+	fw, err := pqarrow.NewFileWriter(record.Schema(), unclosableWriter{w}, config.properties(),
+		pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema(), pqarrow.WithAllocator(config.allocator())))
+	if err != nil {
+		return err
+	}
+	err = fw.Write(record)
+	if err != nil {
+		fw.Close()
+		return err
+	}
+	return fw.Close()
+}
+
+// readParquet reads records of Parquet file, in order of rows.
+func readParquet(r parquet.ReaderAtSeeker, config Config, f func(record arrow.Record) error) error {
+	table, err := pqarrow.ReadTable(context.Background(), r, nil, pqarrow.ArrowReadProperties{}, config.allocator())
+	if err != nil {
+		return err
+	}
+	defer table.Release()
+	tr := array.NewTableReader(table, table.NumRows())
+	defer tr.Release()
+	for tr.Next() {
+		err = f(tr.Record())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package columnar
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+func TestPricesParquet(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config Config
+	}{
+		{
+			desc:   "Default",
+			config: Config{},
+		},
+		{
+			desc: "Properties",
+			config: Config{
+				Allocator:  memory.NewGoAllocator(),
+				Properties: parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Gzip), parquet.WithMaxRowGroupLength(1)),
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			series := []PriceSeries{
+				{Symbol: "00006", Frequency: aastocks.Daily, Prices: prices},
+				{Symbol: "00005", Frequency: aastocks.Weekly, Prices: prices[:1]},
+			}
+			var b bytes.Buffer
+			err := WritePrices(&b, series, tC.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Time zone of timestamps is kept with stored schema
+			table, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(b.Bytes()), nil, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer table.Release()
+			diff := cmp.Diff("timestamp[ms, tz=Asia/Hong_Kong]", table.Schema().Field(2).Type.String())
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			decoded, err := ReadPrices(bytes.NewReader(b.Bytes()), tC.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff = cmp.Diff(series, decoded)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestDividendsParquet(t *testing.T) {
+	series := []DividendSeries{
+		{Symbol: "00006", Dividends: dividends},
+	}
+	var b bytes.Buffer
+	err := WriteDividends(&b, series, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ReadDividends(bytes.NewReader(b.Bytes()), Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff(series, decoded)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	_, err = ReadPrices(bytes.NewReader(b.Bytes()), Config{})
+	if err == nil {
+		t.Fatalf("Error should be returned for file of dividends")
+	}
+}
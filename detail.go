@@ -1,6 +1,7 @@
 package aastocks
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -13,19 +14,25 @@ import (
 
 const na = "N/A"
 
-func (q *Quote) details() error {
+// details fetches quote details, request is aborted when context is done.
+func (q *Quote) details(ctx context.Context) error {
 	url := fmt.Sprintf(`http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=%s`, q.Symbol)
-	resp, err := q.client.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Failed to fetch quote details")
+	resp, err := q.client.Do(req)
+	if err != nil {
+		return err
 	}
 
 	defer resp.Body.Close()
 
+	err = checkStatus(resp, "quote details")
+	if err != nil {
+		return err
+	}
+
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
 		return err
@@ -37,21 +44,26 @@ func (q *Quote) details() error {
 	}
 
 	type errorOp func() error
-	ops := []errorOp{
-		name(q, doc),
-		price(q, doc),
-		yield(q, doc),
-		peRatio(q, doc),
-		pbRatio(q, doc),
-		eps(q, doc),
-		lots(q, doc),
-		updateTime(q, doc),
-		price52W(q, doc),
+	ops := []struct {
+		field string
+		op    errorOp
+	}{
+		{"name", name(q, doc)},
+		{"industry", industry(q, doc)},
+		{"price", price(q, doc)},
+		{"prev_close", prevClose(q, doc)},
+		{"yield", yield(q, doc)},
+		{"pe_ratio", peRatio(q, doc)},
+		{"pb_ratio", pbRatio(q, doc)},
+		{"eps", eps(q, doc)},
+		{"lots", lots(q, doc)},
+		{"update_time", updateTime(q, doc)},
+		{"price_52w", price52W(q, doc)},
 	}
 	for _, op := range ops {
-		err = op()
+		err = op.op()
 		if err != nil {
-			return fmt.Errorf("Quote details cannot be parsed: %v", err)
+			return &ParseError{Field: op.field, Err: fmt.Errorf("Quote details cannot be parsed: %v", err)}
 		}
 	}
 	return nil
@@ -63,7 +75,7 @@ func checkSymbolFound(q *Quote, doc *goquery.Document) error {
 		return err
 	}
 	if html != "" {
-		return fmt.Errorf("Symbol cannot be found: %v", q.Symbol)
+		return fmt.Errorf("%w: %v", ErrSymbolNotFound, q.Symbol)
 	}
 	return nil
 }
@@ -79,6 +91,15 @@ func name(q *Quote, doc *goquery.Document) func() error {
 	}
 }
 
+func industry(q *Quote, doc *goquery.Document) func() error {
+	return func() error {
+		// Industry is not available for ETFs
+		industry, _ := doc.Find("#cp_lnkIndustry").Attr("title")
+		q.Industry = strings.TrimSpace(industry)
+		return nil
+	}
+}
+
 func price(q *Quote, doc *goquery.Document) func() error {
 	return func() error {
 		price := strings.TrimSpace(doc.Find("#labelLast").Text())
@@ -94,6 +115,24 @@ func price(q *Quote, doc *goquery.Document) func() error {
 	}
 }
 
+func prevClose(q *Quote, doc *goquery.Document) func() error {
+	return func() error {
+		// Previous close is not available on some detail pages, which is left as zero
+		prevClose := strings.TrimSpace(doc.Find(".colPrevClose .cls").Text())
+		// Previous close and open are separated by "/", while "N/A" contains it as well
+		if prevClose == "" || strings.HasPrefix(prevClose, na) {
+			return nil
+		}
+		s := strings.Split(prevClose, "/")
+		c, err := strconv.ParseFloat(strings.TrimSpace(s[0]), 64)
+		if err != nil {
+			return fmt.Errorf("Previous close failed to be parsed: %v", err)
+		}
+		q.PrevClose = c
+		return nil
+	}
+}
+
 func peRatio(q *Quote, doc *goquery.Document) func() error {
 	return func() error {
 		peRatio := strings.TrimSpace(doc.Find("#tbPERatio .float_r.cls").Text())
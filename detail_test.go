@@ -29,7 +29,9 @@ func TestGetQuote(t *testing.T) {
 			quote: Quote{
 				Symbol:       "00006",
 				Name:         "POWER ASSETS",
+				Industry:     "Electricity Supply",
 				Price:        44.65,
+				PrevClose:    44.2,
 				Price52WLow:  41.6,
 				Price52WHigh: 58.5,
 				Yield:        0.06271,
@@ -48,7 +50,9 @@ func TestGetQuote(t *testing.T) {
 			quote: Quote{
 				Symbol:       "09923",
 				Name:         "YEAHKA",
+				Industry:     "E-Commerce & Internet Services",
 				Price:        59.6,
+				PrevClose:    61.2,
 				Price52WLow:  14.92,
 				Price52WHigh: 80,
 				Yield:        0,
@@ -64,7 +68,7 @@ func TestGetQuote(t *testing.T) {
 			requests: map[string]http.HandlerFunc{
 				"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=151511": serveFile("testdata/detail_quote_not_found.html"),
 			},
-			err: errors.New("Symbol cannot be found: 151511"),
+			err: fmt.Errorf("%w: 151511", ErrSymbolNotFound),
 		},
 		{
 			symbol: "3033",
@@ -103,6 +107,51 @@ func TestGetQuote(t *testing.T) {
 	}
 }
 
+func TestGetQuoteNotFound(t *testing.T) {
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=151511": serveFile("testdata/detail_quote_not_found.html"),
+	})
+	_, err := Get("151511", WithClient(mock.client))
+	if !errors.Is(err, ErrSymbolNotFound) {
+		t.Fatalf("expected error to be ErrSymbolNotFound, but got %v", err)
+	}
+}
+
+func TestGetQuoteStatusError(t *testing.T) {
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": serveError(fmt.Errorf("testing error")),
+	})
+	_, err := Get("00006", WithClient(mock.client))
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected error to be StatusError, but got %v", err)
+	}
+	diff := cmp.Diff(http.StatusInternalServerError, statusErr.StatusCode)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestGetQuoteParseError(t *testing.T) {
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`<html><body></body></html>`))
+		},
+	})
+	_, err := Get("00006", WithClient(mock.client))
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected error to be ParseError, but got %v", err)
+	}
+	diff := cmp.Diff([]string{"name", "Quote details cannot be parsed: Name cannot be found"}, []string{parseErr.Field, err.Error()})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
 func TestDetailsParseFunc(t *testing.T) {
 	testCases := []struct {
 		desc      string
@@ -125,6 +174,26 @@ func TestDetailsParseFunc(t *testing.T) {
 			parseFunc: name,
 			err:       fmt.Errorf("Name cannot be found"),
 		},
+		{
+			desc:      "PrevClose",
+			content:   `<table><tr><td class="vat colPrevClose"><div><div class="ss1">Prev. Close/Open</div><div class="cls bold ss3">44.200 / 44.300</div></div></td></tr></table>`,
+			parseFunc: prevClose,
+			quote: Quote{
+				PrevClose: 44.2,
+			},
+		},
+		{
+			desc:      "PrevClose/NotAvailable",
+			content:   `<table><tr><td class="vat colPrevClose"><div><div class="ss1">Prev. Close/Open</div><div class="cls bold ss3">N/A / 20.000</div></div></td></tr></table>`,
+			parseFunc: prevClose,
+			quote:     Quote{},
+		},
+		{
+			desc:      "PrevClose/NotFound",
+			content:   `<table><tr><td class="vat"><div><div class="ss1">Open</div><div class="cls bold ss3">44.300</div></div></td></tr></table>`,
+			parseFunc: prevClose,
+			quote:     Quote{},
+		},
 		{
 			desc:      "UpdateTime",
 			content:   `<script>var ServerDate = new Date('2020-08-29T00:55:31');</script>`,
@@ -2,6 +2,8 @@ package aastocks
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,13 +12,29 @@ import (
 
 // Dividend fetched from AAStocks
 type Dividend struct {
-	AnnounceDate time.Time
-	YearEnded    time.Time
-	Event        string
-	Particular   string
-	Type         string
-	ExDate       time.Time
-	PayableDate  time.Time
+	AnnounceDate time.Time `json:"announce_date"`
+	YearEnded    time.Time `json:"year_ended"`
+	Event        string    `json:"event"`
+	Particular   string    `json:"particular"`
+	Type         string    `json:"type"`
+	ExDate       time.Time `json:"ex_date"`
+	PayableDate  time.Time `json:"payable_date"`
+}
+
+var cashDividendRegex = regexp.MustCompile(`^D:\s*([A-Z]+)\s*([0-9]*\.?[0-9]+)`)
+
+// Cash dividend per share and its currency parsed from particular of the dividend (i.e. "D:HKD 0.7700").
+// False is returned if it is not cash dividend, such as preferential offer.
+func (d Dividend) Cash() (currency string, amount float64, ok bool) {
+	matches := cashDividendRegex.FindStringSubmatch(strings.TrimSpace(d.Particular))
+	if matches == nil {
+		return "", 0, false
+	}
+	amount, err := strconv.ParseFloat(matches[2], 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return matches[1], amount, true
 }
 
 // Dividends of the quote from AAStocks
@@ -28,6 +46,11 @@ func (q *Quote) Dividends() ([]Dividend, error) {
 	}
 	defer resp.Body.Close()
 
+	err = checkStatus(resp, "dividends")
+	if err != nil {
+		return nil, err
+	}
+
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
 		return nil, err
@@ -37,6 +60,7 @@ func (q *Quote) Dividends() ([]Dividend, error) {
 
 type tableMapping struct {
 	header  string
+	field   string
 	index   int
 	mapFunc func(*Dividend, *goquery.Selection) error
 }
@@ -74,7 +98,7 @@ func dividends(doc *goquery.Document) ([]Dividend, error) {
 			s := row.Eq(mapping.index)
 			err := mapping.mapFunc(&d, s)
 			if err != nil {
-				return nil, fmt.Errorf("Dividend failed to be parsed for %s of row %v: %v", mapping.header, i, err)
+				return nil, &ParseError{Field: mapping.field, Err: fmt.Errorf("Dividend failed to be parsed for %s of row %v: %v", mapping.header, i, err)}
 			}
 		}
 		result = append(result, d)
@@ -89,6 +113,7 @@ func getTableMappings(headers *goquery.Selection) ([]*tableMapping, error) {
 	mappings := []*tableMapping{
 		{
 			header: "Announce Date",
+			field:  "announce_date",
 			mapFunc: func(d *Dividend, s *goquery.Selection) error {
 				var err error
 				date, err := getTime(s, dateLayout)
@@ -98,6 +123,7 @@ func getTableMappings(headers *goquery.Selection) ([]*tableMapping, error) {
 		},
 		{
 			header: "Year Ended",
+			field:  "year_ended",
 			mapFunc: func(d *Dividend, s *goquery.Selection) error {
 				var err error
 				date, err := getTime(s, monthLayout)
@@ -107,6 +133,7 @@ func getTableMappings(headers *goquery.Selection) ([]*tableMapping, error) {
 		},
 		{
 			header: "Event",
+			field:  "event",
 			mapFunc: func(d *Dividend, s *goquery.Selection) error {
 				d.Event = s.Text()
 				return nil
@@ -114,6 +141,7 @@ func getTableMappings(headers *goquery.Selection) ([]*tableMapping, error) {
 		},
 		{
 			header: "Particular",
+			field:  "particular",
 			mapFunc: func(d *Dividend, s *goquery.Selection) error {
 				d.Particular = s.Text()
 				return nil
@@ -121,6 +149,7 @@ func getTableMappings(headers *goquery.Selection) ([]*tableMapping, error) {
 		},
 		{
 			header: "Type",
+			field:  "type",
 			mapFunc: func(d *Dividend, s *goquery.Selection) error {
 				d.Type = s.Text()
 				return nil
@@ -128,6 +157,7 @@ func getTableMappings(headers *goquery.Selection) ([]*tableMapping, error) {
 		},
 		{
 			header: "Ex-Date",
+			field:  "ex_date",
 			mapFunc: func(d *Dividend, s *goquery.Selection) error {
 				var err error
 				date, err := getTime(s, dateLayout)
@@ -137,6 +167,7 @@ func getTableMappings(headers *goquery.Selection) ([]*tableMapping, error) {
 		},
 		{
 			header: "Payable Date",
+			field:  "payable_date",
 			mapFunc: func(d *Dividend, s *goquery.Selection) error {
 				var err error
 				date, err := getTime(s, dateLayout)
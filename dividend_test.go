@@ -92,3 +92,40 @@ func TestDividends(t *testing.T) {
 		})
 	}
 }
+
+func TestDividendCash(t *testing.T) {
+	testCases := []struct {
+		particular string
+		currency   string
+		amount     float64
+		ok         bool
+	}{
+		{
+			particular: "D:HKD 0.7700",
+			currency:   "HKD",
+			amount:     0.77,
+			ok:         true,
+		},
+		{
+			particular: "D:RMB 1.2500",
+			currency:   "RMB",
+			amount:     1.25,
+			ok:         true,
+		},
+		{
+			particular: "Preferential Offer: 1 HK Electric Investments and HK Electric Investments Limited Share Stapled unit offer price HKD 5.4500 for every 4 Shares held",
+		},
+		{
+			particular: "-",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.particular, func(t *testing.T) {
+			currency, amount, ok := Dividend{Particular: tC.particular}.Cash()
+			diff := cmp.Diff([]interface{}{tC.currency, tC.amount, tC.ok}, []interface{}{currency, amount, ok})
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
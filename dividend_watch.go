@@ -0,0 +1,231 @@
+package aastocks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/horacehylee/aastocks/internal/atomicfile"
+)
+
+// DividendEvent is the result of serving dividend announcements.
+// Previous is nil when the dividend is newly announced, otherwise it holds
+// the dividend before it was changed.
+type DividendEvent struct {
+	Symbol   string    `json:"symbol"`
+	Dividend Dividend  `json:"dividend"`
+	Previous *Dividend `json:"previous,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// DividendState persists dividends last seen for symbols,
+// so that restarting ServeDividends will not emit them again.
+type DividendState interface {
+	// Load dividends last seen for the symbol, nil should be returned if it is never saved.
+	Load(symbol string) ([]Dividend, error)
+	// Save dividends seen for the symbol.
+	Save(symbol string, dividends []Dividend) error
+}
+
+// maxPendingErrors not received yet, the oldest errors are dropped beyond it.
+const maxPendingErrors = 16
+
+// ServeDividends continuously fetching dividends from AAStocks,
+// and emits newly announced or changed dividends only.
+// It will start goroutine to fetch dividends.
+//
+// Dividends last seen are loaded from and saved to the state.
+// If nothing is saved for the symbol yet, the first fetch is taken as baseline and no events will be emitted for it.
+// State is saved only after all events of a fetch are received, so events will not be missed across restarts.
+// Errors of fetching dividends and saving state are sent separately, the oldest are dropped if too many are not received.
+func (q *Quote) ServeDividends(ctx context.Context, delay time.Duration, state DividendState) (<-chan DividendEvent, <-chan error) {
+	events := make(chan DividendEvent)
+	errors := make(chan error)
+
+	if state == nil {
+		state = &memoryDividendState{}
+	}
+
+	go func() {
+		var eventChan chan<- DividendEvent
+		var errChan chan<- error
+		qq := q.clone()
+
+		// errs pending to be received, so that failure of saving state does not hide failure of fetching or vice versa
+		var errs []error
+		report := func(err error) {
+			if len(errs) >= maxPendingErrors {
+				errs = errs[1:]
+			}
+			errs = append(errs, err)
+		}
+		save := func(dividends []Dividend) {
+			err := state.Save(qq.Symbol, dividends)
+			if err != nil {
+				report(fmt.Errorf("Dividend state failed to be saved: %v", err))
+			}
+		}
+
+		seen, err := state.Load(qq.Symbol)
+		if err != nil {
+			report(fmt.Errorf("Dividend state failed to be loaded: %v", err))
+		}
+
+		var pending []DividendEvent
+		var unsaved []Dividend
+		var event DividendEvent
+		timeout := time.After(0)
+		for {
+			eventChan = nil
+			if len(pending) > 0 {
+				event = pending[0]
+				eventChan = events
+			}
+			errChan = nil
+			err = nil
+			if len(errs) > 0 {
+				err = errs[0]
+				errChan = errors
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case errChan <- err:
+				errs = errs[1:]
+			case eventChan <- event:
+				pending = pending[1:]
+				if len(pending) == 0 {
+					save(unsaved)
+				}
+			case <-timeout:
+				if ctx.Err() != nil {
+					return
+				}
+				if len(pending) > 0 {
+					// Events of last fetch are not received yet
+					timeout = time.After(delay)
+					continue
+				}
+				dividends, e := qq.Dividends()
+				// Delay is counted from the end of fetch, so that results are
+				// received before next fetch even if fetching is slow
+				timeout = time.After(delay)
+				if e != nil {
+					report(e)
+					continue
+				}
+				if seen != nil {
+					pending = diffDividends(qq.Symbol, seen, dividends, time.Now())
+				}
+				seen = dividends
+				unsaved = dividends
+				if len(pending) == 0 {
+					save(unsaved)
+				}
+			}
+		}
+	}()
+	return events, errors
+}
+
+type dividendKey struct {
+	announceDate time.Time
+	yearEnded    time.Time
+	event        string
+}
+
+func keyOf(d Dividend) dividendKey {
+	return dividendKey{
+		announceDate: d.AnnounceDate,
+		yearEnded:    d.YearEnded,
+		event:        d.Event,
+	}
+}
+
+// diffDividends returns events for dividends which are newly appeared or changed,
+// in the order of the latest dividends.
+func diffDividends(symbol string, previous, latest []Dividend, now time.Time) []DividendEvent {
+	seen := make(map[dividendKey]Dividend, len(previous))
+	for _, d := range previous {
+		seen[keyOf(d)] = d
+	}
+	events := make([]DividendEvent, 0)
+	for _, d := range latest {
+		p, ok := seen[keyOf(d)]
+		if ok && p == d {
+			continue
+		}
+		e := DividendEvent{
+			Symbol:   symbol,
+			Dividend: d,
+			Time:     now,
+		}
+		if ok {
+			prev := p
+			e.Previous = &prev
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+type memoryDividendState struct {
+	dividends map[string][]Dividend
+}
+
+func (s *memoryDividendState) Load(symbol string) ([]Dividend, error) {
+	return s.dividends[symbol], nil
+}
+
+func (s *memoryDividendState) Save(symbol string, dividends []Dividend) error {
+	if s.dividends == nil {
+		s.dividends = make(map[string][]Dividend)
+	}
+	s.dividends[symbol] = dividends
+	return nil
+}
+
+// FileDividendState persists dividends as JSON file per symbol under the directory.
+type FileDividendState struct {
+	Dir string
+}
+
+// NewFileDividendState creates dividend state persisted under the directory.
+func NewFileDividendState(dir string) *FileDividendState {
+	return &FileDividendState{Dir: dir}
+}
+
+func (s *FileDividendState) path(symbol string) string {
+	return filepath.Join(s.Dir, fmt.Sprintf("dividends_%s.json", symbol))
+}
+
+// Load dividends last seen for the symbol from its file.
+func (s *FileDividendState) Load(symbol string) ([]Dividend, error) {
+	b, err := ioutil.ReadFile(s.path(symbol))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	dividends := make([]Dividend, 0)
+	err = json.Unmarshal(b, &dividends)
+	if err != nil {
+		return nil, err
+	}
+	return dividends, nil
+}
+
+// Save dividends seen for the symbol to its file.
+// File is replaced atomically, so partially written state will not be loaded.
+func (s *FileDividendState) Save(symbol string, dividends []Dividend) error {
+	b, err := json.Marshal(dividends)
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(s.path(symbol), b)
+}
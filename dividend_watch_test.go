@@ -0,0 +1,285 @@
+package aastocks
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestServeDividends(t *testing.T) {
+	interim := Dividend{
+		AnnounceDate: time.Date(2020, time.August, 5, 0, 0, 0, 0, time.UTC),
+		YearEnded:    time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC),
+		Event:        "Interim",
+		Particular:   "D:HKD 0.7700",
+		Type:         "Cash",
+		ExDate:       time.Date(2020, time.September, 3, 0, 0, 0, 0, time.UTC),
+		PayableDate:  time.Date(2020, time.September, 15, 0, 0, 0, 0, time.UTC),
+	}
+	changedInterim := interim
+	changedInterim.PayableDate = time.Date(2020, time.September, 16, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		symbol   string
+		requests map[string]http.HandlerFunc
+		err      error
+		events   []DividendEvent
+	}{
+		{
+			desc:   "WithNewAndChangedDividends",
+			symbol: "00006",
+			requests: map[string]http.HandlerFunc{
+				"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": serveFile("testdata/detail_quote.html"),
+				"GET-http://www.aastocks.com/en/stocks/analysis/dividend.aspx?symbol=00006": serveRepeat(
+					serveFile("testdata/dividend.html"), // Baseline
+					serveFile("testdata/dividend.html"),
+					serveFile("testdata/dividend_00006_2.html"),
+				),
+			},
+			events: []DividendEvent{
+				{
+					Symbol: "00006",
+					Dividend: Dividend{
+						AnnounceDate: time.Date(2020, time.September, 10, 0, 0, 0, 0, time.UTC),
+						YearEnded:    time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC),
+						Event:        "Special",
+						Particular:   "D:HKD 0.5000",
+						Type:         "Cash",
+						ExDate:       time.Date(2020, time.October, 5, 0, 0, 0, 0, time.UTC),
+						PayableDate:  time.Date(2020, time.October, 20, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				{
+					Symbol:   "00006",
+					Dividend: changedInterim,
+					Previous: &interim,
+				},
+			},
+		},
+		{
+			desc:   "WithErrorChannel",
+			symbol: "00006",
+			requests: map[string]http.HandlerFunc{
+				"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": serveFile("testdata/detail_quote.html"),
+				"GET-http://www.aastocks.com/en/stocks/analysis/dividend.aspx?symbol=00006": serveRepeat(
+					serveFile("testdata/dividend.html"),
+					serveError(fmt.Errorf("testing error")),
+				),
+			},
+			err:    &StatusError{StatusCode: 500, Err: fmt.Errorf("Failed to fetch dividends: 500 Internal Server Error")},
+			events: []DividendEvent{},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			// Goroutine of previous case may still be polling after it is cancelled
+			mock := mockClient()
+			mock.set(tC.requests)
+
+			checkErrorFunc(t, tC.err, func() error {
+				quote, err := Get(tC.symbol, WithClient(mock.client))
+				if err != nil {
+					return err
+				}
+
+				ctx := context.Background()
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+
+				eventsChan, errChan := quote.ServeDividends(ctx, 100*time.Millisecond, nil)
+
+				events := make([]DividendEvent, 0)
+				timeout := time.After(2 * time.Second)
+
+			Loop:
+				for {
+					select {
+					case e := <-eventsChan:
+						events = append(events, e)
+						if len(events) == len(tC.events) {
+							cancel()
+							break Loop
+						}
+					case err = <-errChan:
+						cancel()
+						break Loop
+					case <-timeout:
+						return fmt.Errorf("Timeout is triggered, expect case to terminate normally")
+					}
+				}
+
+				diff := cmp.Diff(tC.events, events, cmpopts.IgnoreFields(DividendEvent{}, "Time"))
+				if diff != "" {
+					t.Fatalf(diff)
+				}
+				return err
+			})
+		})
+	}
+}
+
+func TestFileDividendState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aastocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	state := NewFileDividendState(dir)
+	dividends, err := state.Load("00006")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dividends != nil {
+		t.Fatalf("dividends should be nil when it is never saved, but got %v", dividends)
+	}
+
+	expected := []Dividend{
+		{
+			AnnounceDate: time.Date(2020, time.August, 5, 0, 0, 0, 0, time.UTC),
+			YearEnded:    time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC),
+			Event:        "Interim",
+			Particular:   "D:HKD 0.7700",
+			Type:         "Cash",
+			ExDate:       time.Date(2020, time.September, 3, 0, 0, 0, 0, time.UTC),
+			PayableDate:  time.Date(2020, time.September, 15, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	err = state.Save("00006", expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dividends, err = state.Load("00006")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff(expected, dividends)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+// notifyState notifies saves of dividends to the state.
+type notifyState struct {
+	DividendState
+	saved chan []Dividend
+	err   error
+}
+
+func (s *notifyState) Save(symbol string, dividends []Dividend) error {
+	if s.err != nil {
+		s.saved <- dividends
+		return s.err
+	}
+	err := s.DividendState.Save(symbol, dividends)
+	s.saved <- dividends
+	return err
+}
+
+// serveDividendsOnce serves dividends of quote with the state, until the count of saves are made and errors are received.
+func serveDividendsOnce(t *testing.T, quote *Quote, state *notifyState, saves int, errors int) ([]DividendEvent, []error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventsChan, errChan := quote.ServeDividends(ctx, 50*time.Millisecond, state)
+
+	events := make([]DividendEvent, 0)
+	errs := make([]error, 0)
+	timeout := time.After(2 * time.Second)
+	for saves > 0 || len(errs) < errors {
+		select {
+		case e := <-eventsChan:
+			events = append(events, e)
+		case err := <-errChan:
+			errs = append(errs, err)
+		case <-state.saved:
+			saves--
+		case <-timeout:
+			t.Fatalf("Timeout is triggered, expect dividends to be saved")
+		}
+	}
+	return events, errs
+}
+
+func TestServeDividendsRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aastocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": serveFile("testdata/detail_quote.html"),
+		"GET-http://www.aastocks.com/en/stocks/analysis/dividend.aspx?symbol=00006": serveRepeat(
+			serveFile("testdata/dividend.html"), // Baseline
+			serveFile("testdata/dividend_00006_2.html"),
+			// Restarted
+			serveFile("testdata/dividend_00006_2.html"),
+			serveFile("testdata/dividend_00006_2.html"),
+		),
+	})
+	quote, err := Get("00006", WithClient(mock.client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &notifyState{DividendState: NewFileDividendState(dir), saved: make(chan []Dividend)}
+	events, errs := serveDividendsOnce(t, quote, state, 2, 0)
+	if len(events) != 2 || len(errs) != 0 {
+		t.Fatalf("expected 2 events without errors before restart, but got %v events and errors %v", len(events), errs)
+	}
+	saved, err := NewFileDividendState(dir).Load("00006")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved == nil {
+		t.Fatalf("expected dividends to be saved before restart")
+	}
+
+	state = &notifyState{DividendState: NewFileDividendState(dir), saved: make(chan []Dividend)}
+	events, errs = serveDividendsOnce(t, quote, state, 2, 0)
+	if len(events) != 0 || len(errs) != 0 {
+		t.Fatalf("expected no events after restart, but got events %v and errors %v", events, errs)
+	}
+}
+
+func TestServeDividendsSaveError(t *testing.T) {
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": serveFile("testdata/detail_quote.html"),
+		"GET-http://www.aastocks.com/en/stocks/analysis/dividend.aspx?symbol=00006": serveRepeat(
+			serveFile("testdata/dividend.html"),
+			serveError(fmt.Errorf("testing error")),
+			serveFile("testdata/dividend.html"),
+		),
+	})
+	quote, err := Get("00006", WithClient(mock.client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &notifyState{
+		DividendState: &memoryDividendState{},
+		saved:         make(chan []Dividend),
+		err:           fmt.Errorf("testing error"),
+	}
+	_, errs := serveDividendsOnce(t, quote, state, 2, 3)
+	expected := []error{
+		fmt.Errorf("Dividend state failed to be saved: testing error"),
+		fmt.Errorf("Failed to fetch dividends: 500 Internal Server Error"),
+		fmt.Errorf("Dividend state failed to be saved: testing error"),
+	}
+	diff := cmp.Diff(expected, errs, equateErrorMessage)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -14,6 +14,7 @@
 //
 // Prices can be served in real time by polling AAStocks for its price.
 // Context can be used to control and stop the real time prices.
+// Schedule can be provided to poll only when market is open (i.e. with hkex.MarketCalendar).
 //
 // 	priceChan, errChan := quote.ServePrice(context.Background(), 5*time.Second)
 // 	for {
@@ -25,4 +26,41 @@
 // 		}
 // 	}
 //
+// Price Stream
+//
+// Price stream closes its channel once it is ended by Close or context cancellation,
+// so that it can be ranged over. Buffering with drop policy prevents slow consumer from stalling polling.
+//
+// 	stream := quote.StreamPrices(ctx, 5*time.Second, aastocks.WithBuffer(1, aastocks.Coalesce))
+// 	defer stream.Close()
+// 	for e := range stream.C() {
+// 		if e.Err != nil {
+// 			logger.Printf("error: %v\n", e.Err)
+// 			continue
+// 		}
+// 		logger.Printf("price: %v\n", e.Price)
+// 	}
+//
+// Price Hub
+//
+// Price hub polls many symbols on shared rate limited worker pool, each symbol is polled once
+// regardless of the number of its subscribers.
+//
+// 	hub := aastocks.NewPriceHub(ctx, aastocks.HubConfig{
+// 		Interval: 5 * time.Second,
+// 		Limiter:  aastocks.NewRateLimiter(100*time.Millisecond, 5),
+// 	})
+// 	sub := hub.Subscribe("00005", "00006")
+// 	for e := range sub.C() {
+// 		logger.Printf("price: %v\n", e.Price)
+// 	}
+//
+// Dividend Announcements
+//
+// Newly announced or changed dividends can be served by polling AAStocks for its dividends.
+// Dividends last seen are persisted with state, so restarts will not emit them again.
+//
+// 	state := aastocks.NewFileDividendState("state")
+// 	eventChan, errChan := quote.ServeDividends(context.Background(), time.Hour, state)
+//
 package aastocks
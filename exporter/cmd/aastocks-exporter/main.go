@@ -0,0 +1,69 @@
+// Command aastocks-exporter exports quotes of symbols from AAStocks as Prometheus metrics at /metrics,
+// along with health metrics of scraping them. Quotes are cached for TTL, so that scrapes of Prometheus
+// do not request AAStocks more often than that.
+//
+// Usage:
+//
+//	aastocks-exporter -addr :9110 -universe hsi.txt -ttl 1m -interval 200ms -burst 5 [symbol...]
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/exporter"
+	"github.com/horacehylee/aastocks/internal/symbolfile"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func main() {
+	addr := flag.String("addr", ":9110", "address to listen on")
+	universe := flag.String("universe", "", "file of symbols to be exported, one per line")
+	ttl := flag.Duration("ttl", time.Minute, "duration which responses of AAStocks are cached for")
+	interval := flag.Duration("interval", 200*time.Millisecond, "interval between requests to AAStocks")
+	burst := flag.Int("burst", 5, "burst of requests to AAStocks")
+	concurrency := flag.Int("concurrency", 4, "concurrency of fetching quotes on each scrape")
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "", log.Flags())
+	symbols := flag.Args()
+	if *universe != "" {
+		s, err := symbolfile.Read(*universe)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		symbols = append(symbols, s...)
+	}
+	if len(symbols) == 0 {
+		logger.Fatal("Symbols cannot be empty")
+	}
+
+	limiter := aastocks.NewRateLimiter(*interval, *burst)
+	metrics := exporter.NewMetrics(limiter)
+	client := aastocks.NewCachedClientWithTransport(metrics.Transport(nil), *ttl, limiter)
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		metrics,
+		exporter.NewCollector(exporter.Config{
+			Symbols:     symbols,
+			Client:      client,
+			Metrics:     metrics,
+			Concurrency: *concurrency,
+		}),
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorLog: logger}))
+	logger.Printf("Listening on %v\n", *addr)
+	err := http.ListenAndServe(*addr, mux)
+	if err != nil {
+		logger.Fatal(err)
+	}
+}
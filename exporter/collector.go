@@ -0,0 +1,149 @@
+// Package exporter exports quotes of AAStocks as Prometheus metrics, along with health metrics of scraping them.
+//
+// Quotes are fetched on each scrape, so client of collector should be cached for scrapes not to request AAStocks each time.
+// Requests reaching AAStocks are instrumented by transport of the cached client.
+//
+//	limiter := aastocks.NewRateLimiter(200*time.Millisecond, 5)
+//	metrics := exporter.NewMetrics(limiter)
+//	client := aastocks.NewCachedClientWithTransport(metrics.Transport(nil), time.Minute, limiter)
+//	registry := prometheus.NewRegistry()
+//	registry.MustRegister(metrics, exporter.NewCollector(exporter.Config{
+//		Symbols: []string{"00005", "00006"},
+//		Client:  client,
+//		Metrics: metrics,
+//	}))
+//	http.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+package exporter
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Config of collector.
+type Config struct {
+	// Symbols of quotes to be exported.
+	Symbols []string
+	// Client fetching quotes, which should be cached (i.e. aastocks.NewCachedClient).
+	// Default client of aastocks is used if it is nil, which requests AAStocks on each scrape.
+	Client *http.Client
+	// Metrics counting parse failures of quotes, they are not counted if it is nil.
+	Metrics *Metrics
+	// Concurrency of fetching quotes on each scrape, 4 is used if it is zero.
+	Concurrency int
+}
+
+// Collector of gauges of quotes, which are fetched with bounded concurrency on each scrape.
+// Gauges of symbol failed to be fetched are not exported, and its up gauge is 0.
+type Collector struct {
+	config Config
+	opts   []aastocks.Option
+	now    func() time.Time
+
+	up        *prometheus.Desc
+	info      *prometheus.Desc
+	price     *prometheus.Desc
+	volume    *prometheus.Desc
+	yield     *prometheus.Desc
+	peRatio   *prometheus.Desc
+	high52W   *prometheus.Desc
+	low52W    *prometheus.Desc
+	updateAge *prometheus.Desc
+}
+
+// NewCollector creates collector of quotes of the symbols.
+func NewCollector(config Config) *Collector {
+	if config.Concurrency <= 0 {
+		config.Concurrency = 4
+	}
+	opts := make([]aastocks.Option, 0)
+	if config.Client != nil {
+		opts = append(opts, aastocks.WithClient(config.Client))
+	}
+	symbol := []string{"symbol"}
+	return &Collector{
+		config:    config,
+		opts:      opts,
+		now:       time.Now,
+		up:        prometheus.NewDesc("aastocks_up", "Whether quote of the symbol is fetched from AAStocks.", symbol, nil),
+		info:      prometheus.NewDesc("aastocks_quote_info", "Name and industry of the symbol.", []string{"symbol", "name", "industry"}, nil),
+		price:     prometheus.NewDesc("aastocks_price", "Price of the symbol.", symbol, nil),
+		volume:    prometheus.NewDesc("aastocks_volume_shares", "Volume of the symbol in the latest daily historical price.", symbol, nil),
+		yield:     prometheus.NewDesc("aastocks_yield_ratio", "Dividend yield of the symbol.", symbol, nil),
+		peRatio:   prometheus.NewDesc("aastocks_pe_ratio", "PE ratio of the symbol.", symbol, nil),
+		high52W:   prometheus.NewDesc("aastocks_price_52w_high", "52 week high price of the symbol.", symbol, nil),
+		low52W:    prometheus.NewDesc("aastocks_price_52w_low", "52 week low price of the symbol.", symbol, nil),
+		updateAge: prometheus.NewDesc("aastocks_update_age_seconds", "Seconds since update time of quote of the symbol.", symbol, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.up
+	ch <- c.info
+	ch <- c.price
+	ch <- c.volume
+	ch <- c.yield
+	ch <- c.peRatio
+	ch <- c.high52W
+	ch <- c.low52W
+	ch <- c.updateAge
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	symbols := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < c.config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for symbol := range symbols {
+				c.collect(ch, symbol)
+			}
+		}()
+	}
+	for _, symbol := range c.config.Symbols {
+		symbols <- symbol
+	}
+	close(symbols)
+	wg.Wait()
+}
+
+func (c *Collector) collect(ch chan<- prometheus.Metric, symbol string) {
+	q, err := aastocks.Get(symbol, c.opts...)
+	if err != nil {
+		c.observeError(err)
+		ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 0, symbol)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 1, symbol)
+	ch <- prometheus.MustNewConstMetric(c.info, prometheus.GaugeValue, 1, symbol, q.Name, q.Industry)
+	ch <- prometheus.MustNewConstMetric(c.price, prometheus.GaugeValue, q.Price, symbol)
+	ch <- prometheus.MustNewConstMetric(c.yield, prometheus.GaugeValue, q.Yield, symbol)
+	ch <- prometheus.MustNewConstMetric(c.peRatio, prometheus.GaugeValue, q.PeRatio, symbol)
+	ch <- prometheus.MustNewConstMetric(c.high52W, prometheus.GaugeValue, q.Price52WHigh, symbol)
+	ch <- prometheus.MustNewConstMetric(c.low52W, prometheus.GaugeValue, q.Price52WLow, symbol)
+	ch <- prometheus.MustNewConstMetric(c.updateAge, prometheus.GaugeValue, c.now().Sub(aastocks.HongKongTime(q.UpdateTime)).Seconds(), symbol)
+
+	prices, err := q.HistoricalPrices(aastocks.Daily)
+	if err != nil {
+		c.observeError(err)
+		return
+	}
+	if len(prices) == 0 {
+		return
+	}
+	// Volume is in thousands of shares
+	ch <- prometheus.MustNewConstMetric(c.volume, prometheus.GaugeValue, prices[len(prices)-1].Volume*1000, symbol)
+}
+
+func (c *Collector) observeError(err error) {
+	if c.config.Metrics != nil {
+		c.config.Metrics.ObserveError(err)
+	}
+}
@@ -0,0 +1,137 @@
+package exporter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// mockTransport serves files of urls.
+type mockTransport map[string]string
+
+func (m mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, ok := m[req.URL.String()]
+	if !ok {
+		return nil, fmt.Errorf("Handler not found for %s", req.URL)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	recorder.Write(b)
+	return recorder.Result(), nil
+}
+
+var transport = mockTransport{
+	"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006":                                                             "../testdata/detail_quote.html",
+	"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=151511":                                                            "../testdata/detail_quote_not_found.html",
+	"http://chartdata1.internet.aastocks.com/servlet/iDataServlet/getdaily?id=00006.HK&type=24&market=1&level=1&period=56&encoding=utf8": "../testdata/historical_price_00006_daily.html",
+}
+
+func TestCollector(t *testing.T) {
+	client := &http.Client{Transport: transport}
+	q, err := aastocks.Get("00006", aastocks.WithClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prices, err := q.HistoricalPrices(aastocks.Daily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	volume := prices[len(prices)-1].Volume * 1000
+
+	c := NewCollector(Config{
+		Symbols: []string{"00006", "151511"},
+		Client:  client,
+	})
+	c.now = func() time.Time {
+		return time.Date(2020, time.August, 25, 21, 20, 8, 0, aastocks.HongKong)
+	}
+	expected := fmt.Sprintf(`
+# HELP aastocks_up Whether quote of the symbol is fetched from AAStocks.
+# TYPE aastocks_up gauge
+aastocks_up{symbol="00006"} 1
+aastocks_up{symbol="151511"} 0
+# HELP aastocks_quote_info Name and industry of the symbol.
+# TYPE aastocks_quote_info gauge
+aastocks_quote_info{industry="Electricity Supply",name="POWER ASSETS",symbol="00006"} 1
+# HELP aastocks_price Price of the symbol.
+# TYPE aastocks_price gauge
+aastocks_price{symbol="00006"} 44.65
+# HELP aastocks_volume_shares Volume of the symbol in the latest daily historical price.
+# TYPE aastocks_volume_shares gauge
+aastocks_volume_shares{symbol="00006"} %v
+# HELP aastocks_yield_ratio Dividend yield of the symbol.
+# TYPE aastocks_yield_ratio gauge
+aastocks_yield_ratio{symbol="00006"} 0.06271
+# HELP aastocks_pe_ratio PE ratio of the symbol.
+# TYPE aastocks_pe_ratio gauge
+aastocks_pe_ratio{symbol="00006"} 13.368
+# HELP aastocks_price_52w_high 52 week high price of the symbol.
+# TYPE aastocks_price_52w_high gauge
+aastocks_price_52w_high{symbol="00006"} 58.5
+# HELP aastocks_price_52w_low 52 week low price of the symbol.
+# TYPE aastocks_price_52w_low gauge
+aastocks_price_52w_low{symbol="00006"} 41.6
+# HELP aastocks_update_age_seconds Seconds since update time of quote of the symbol.
+# TYPE aastocks_update_age_seconds gauge
+aastocks_update_age_seconds{symbol="00006"} 90
+`, volume)
+	err = testutil.CollectAndCompare(c, strings.NewReader(expected))
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+// concurrencyTransport records the maximum of concurrent requests.
+type concurrencyTransport struct {
+	mu       sync.Mutex
+	inFlight int
+	max      int
+}
+
+func (c *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.inFlight++
+	if c.inFlight > c.max {
+		c.max = c.inFlight
+	}
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.inFlight--
+		c.mu.Unlock()
+	}()
+	time.Sleep(10 * time.Millisecond)
+	return transport.RoundTrip(req)
+}
+
+func TestCollectorConcurrency(t *testing.T) {
+	rt := &concurrencyTransport{}
+	symbols := make([]string, 10)
+	for i := range symbols {
+		// Symbols are not served, which are collected as not up
+		symbols[i] = fmt.Sprintf("9%04d", i)
+	}
+	c := NewCollector(Config{
+		Symbols:     symbols,
+		Client:      &http.Client{Transport: rt},
+		Concurrency: 2,
+	})
+	count := testutil.CollectAndCount(c)
+	if count != len(symbols) {
+		t.Fatalf("expected %v metrics, but got %v", len(symbols), count)
+	}
+	if rt.max > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, but got %v", rt.max)
+	}
+}
@@ -0,0 +1,11 @@
+module github.com/horacehylee/aastocks/exporter
+
+go 1.14
+
+require (
+	github.com/horacehylee/aastocks v0.1.0
+	github.com/prometheus/client_golang v1.8.0
+)
+
+// Root module in the same repository is used for development, tagged version is required otherwise
+replace github.com/horacehylee/aastocks => ../
@@ -0,0 +1,133 @@
+package exporter
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Endpoints of AAStocks as labels of requests.
+const (
+	endpointQuote     = "quote"
+	endpointDividends = "dividends"
+	endpointHistory   = "history"
+	endpointOther     = "other"
+)
+
+// endpoint of AAStocks requested by the request.
+func endpoint(req *http.Request) string {
+	path := req.URL.Path
+	switch {
+	case strings.HasSuffix(path, "/detail-quote.aspx"):
+		return endpointQuote
+	case strings.HasSuffix(path, "/dividend.aspx"):
+		return endpointDividends
+	case strings.HasSuffix(path, "/getdaily"):
+		return endpointHistory
+	}
+	return endpointOther
+}
+
+// Metrics of scraper health: requests to AAStocks per endpoint, parse failures per field and waits for rate limiter.
+// It is collector to be registered along with Collector.
+type Metrics struct {
+	requests      *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	parseFailures *prometheus.CounterVec
+	limiter       *aastocks.RateLimiter
+	waits         *prometheus.Desc
+	waited        *prometheus.Desc
+}
+
+// NewMetrics creates metrics of scraper health, waits are collected from the limiter if it is not nil.
+func NewMetrics(limiter *aastocks.RateLimiter) *Metrics {
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "aastocks_requests_total",
+			Help: "Requests made to AAStocks, by endpoint and status code, which is \"error\" if response is not received.",
+		}, []string{"endpoint", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "aastocks_request_duration_seconds",
+			Help:    "Latency of requests made to AAStocks, by endpoint.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+		parseFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "aastocks_parse_failures_total",
+			Help: "Failures of parsing data from AAStocks, by field.",
+		}, []string{"field"}),
+		limiter: limiter,
+		waits: prometheus.NewDesc(
+			"aastocks_rate_limit_waits_total",
+			"Requests waited for rate limiter.",
+			nil, nil,
+		),
+		waited: prometheus.NewDesc(
+			"aastocks_rate_limit_wait_seconds_total",
+			"Total duration waited by requests for rate limiter.",
+			nil, nil,
+		),
+	}
+}
+
+// Transport instruments requests made by next transport, http.DefaultTransport is used if it is nil.
+// It should be the transport of cached client (i.e. aastocks.NewCachedClientWithTransport),
+// so that only requests reaching AAStocks are counted.
+func (m *Metrics) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		e := endpoint(req)
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+		m.duration.WithLabelValues(e).Observe(time.Since(start).Seconds())
+		if err != nil {
+			m.requests.WithLabelValues(e, "error").Inc()
+			return nil, err
+		}
+		m.requests.WithLabelValues(e, strconv.Itoa(resp.StatusCode)).Inc()
+		return resp, nil
+	})
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// ObserveError counts parse failure of the field if error is aastocks.ParseError.
+func (m *Metrics) ObserveError(err error) {
+	var parseErr *aastocks.ParseError
+	if errors.As(err, &parseErr) {
+		m.parseFailures.WithLabelValues(parseErr.Field).Inc()
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.duration.Describe(ch)
+	m.parseFailures.Describe(ch)
+	if m.limiter != nil {
+		ch <- m.waits
+		ch <- m.waited
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.duration.Collect(ch)
+	m.parseFailures.Collect(ch)
+	if m.limiter != nil {
+		stats := m.limiter.Stats()
+		ch <- prometheus.MustNewConstMetric(m.waits, prometheus.CounterValue, float64(stats.Waits))
+		ch <- prometheus.MustNewConstMetric(m.waited, prometheus.CounterValue, stats.Waited.Seconds())
+	}
+}
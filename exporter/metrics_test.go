@@ -0,0 +1,109 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006", endpointQuote},
+		{"http://www.aastocks.com/en/stocks/analysis/dividend.aspx?symbol=00006", endpointDividends},
+		{"http://chartdata1.internet.aastocks.com/servlet/iDataServlet/getdaily?id=00006.HK&type=24", endpointHistory},
+		{"http://www.aastocks.com/en/", endpointOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := endpoint(req)
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMetricsTransport(t *testing.T) {
+	m := NewMetrics(nil)
+	client := &http.Client{Transport: m.Transport(transport)}
+	urls := []string{
+		"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006",
+		"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=151511",
+		"http://www.aastocks.com/en/stocks/analysis/dividend.aspx?symbol=00006",
+	}
+	for _, url := range urls {
+		resp, err := client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+	expected := `
+# HELP aastocks_requests_total Requests made to AAStocks, by endpoint and status code, which is "error" if response is not received.
+# TYPE aastocks_requests_total counter
+aastocks_requests_total{code="200",endpoint="quote"} 2
+aastocks_requests_total{code="error",endpoint="dividends"} 1
+`
+	err := testutil.CollectAndCompare(m, strings.NewReader(expected), "aastocks_requests_total")
+	if err != nil {
+		t.Error(err)
+	}
+	count := testutil.CollectAndCount(m, "aastocks_request_duration_seconds")
+	if count != 2 {
+		t.Errorf("expected 2 histograms, but got %v", count)
+	}
+}
+
+func TestMetricsObserveError(t *testing.T) {
+	m := NewMetrics(nil)
+	m.ObserveError(&aastocks.ParseError{Field: "price", Err: fmt.Errorf("Quote details cannot be parsed")})
+	m.ObserveError(fmt.Errorf("Quote failed: %w", &aastocks.ParseError{Field: "price", Err: fmt.Errorf("Quote details cannot be parsed")}))
+	m.ObserveError(&aastocks.ParseError{Field: "close", Err: fmt.Errorf("Historical prices cannot be parsed")})
+	m.ObserveError(fmt.Errorf("Quote cannot be found"))
+	expected := `
+# HELP aastocks_parse_failures_total Failures of parsing data from AAStocks, by field.
+# TYPE aastocks_parse_failures_total counter
+aastocks_parse_failures_total{field="close"} 1
+aastocks_parse_failures_total{field="price"} 2
+`
+	err := testutil.CollectAndCompare(m, strings.NewReader(expected), "aastocks_parse_failures_total")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMetricsRateLimiter(t *testing.T) {
+	limiter := aastocks.NewRateLimiter(10*time.Millisecond, 1)
+	m := NewMetrics(limiter)
+	for i := 0; i < 3; i++ {
+		err := limiter.Wait(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := `
+# HELP aastocks_rate_limit_waits_total Requests waited for rate limiter.
+# TYPE aastocks_rate_limit_waits_total counter
+aastocks_rate_limit_waits_total 2
+`
+	err := testutil.CollectAndCompare(m, strings.NewReader(expected), "aastocks_rate_limit_waits_total")
+	if err != nil {
+		t.Error(err)
+	}
+	count := testutil.CollectAndCount(m, "aastocks_rate_limit_wait_seconds_total")
+	if count != 1 {
+		t.Errorf("expected 1 counter of seconds waited, but got %v", count)
+	}
+}
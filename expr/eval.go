@@ -0,0 +1,265 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/indicators"
+)
+
+type variable struct {
+	t     Type
+	value func(q *aastocks.Quote) interface{}
+}
+
+var variables = map[string]*variable{
+	"symbol":     {String, func(q *aastocks.Quote) interface{} { return q.Symbol }},
+	"name":       {String, func(q *aastocks.Quote) interface{} { return q.Name }},
+	"industry":   {String, func(q *aastocks.Quote) interface{} { return q.Industry }},
+	"price":      {Number, func(q *aastocks.Quote) interface{} { return q.Price }},
+	"prev_close": {Number, func(q *aastocks.Quote) interface{} { return q.PrevClose }},
+	"change": {Number, func(q *aastocks.Quote) interface{} {
+		if q.PrevClose == 0 {
+			return math.NaN()
+		}
+		return q.Price/q.PrevClose - 1
+	}},
+	"low_52w":  {Number, func(q *aastocks.Quote) interface{} { return q.Price52WLow }},
+	"high_52w": {Number, func(q *aastocks.Quote) interface{} { return q.Price52WHigh }},
+	"yield":    {Number, func(q *aastocks.Quote) interface{} { return q.Yield }},
+	"pe":       {Number, func(q *aastocks.Quote) interface{} { return q.PeRatio }},
+	"pb":       {Number, func(q *aastocks.Quote) interface{} { return q.PbRatio }},
+	"eps":      {Number, func(q *aastocks.Quote) interface{} { return q.Eps }},
+	"lots":     {Number, func(q *aastocks.Quote) interface{} { return float64(q.Lots) }},
+}
+
+type param struct {
+	name string
+	t    Type
+	// period must be constant positive integer, not greater than maxPeriod
+	period bool
+}
+
+// maxPeriod is the maximum period of indicators, which is about 40 years of trading days.
+const maxPeriod = 10000
+
+type function struct {
+	name    string
+	params  []param
+	result  Type
+	history bool
+	call    func(env *Env, args []float64) (float64, error)
+}
+
+var functions = map[string]*function{}
+
+func init() {
+	math1 := []param{{name: "x", t: Number}}
+	math2 := []param{{name: "x", t: Number}, {name: "y", t: Number}}
+	define(&function{name: "abs", params: math1, call: func(env *Env, args []float64) (float64, error) {
+		return math.Abs(args[0]), nil
+	}})
+	define(&function{name: "min", params: math2, call: func(env *Env, args []float64) (float64, error) {
+		return math.Min(args[0], args[1]), nil
+	}})
+	define(&function{name: "max", params: math2, call: func(env *Env, args []float64) (float64, error) {
+		return math.Max(args[0], args[1]), nil
+	}})
+
+	defineIndicator("sma", func(prices []aastocks.HistoricalPrice, n int) float64 {
+		s := indicators.NewSMA(n)
+		for _, p := range prices {
+			s.Update(p.Close)
+		}
+		return s.Value()
+	})
+	defineIndicator("ema", func(prices []aastocks.HistoricalPrice, n int) float64 {
+		e := indicators.NewEMA(n)
+		for _, p := range prices {
+			e.Update(p.Close)
+		}
+		return e.Value()
+	})
+	defineIndicator("rsi", func(prices []aastocks.HistoricalPrice, n int) float64 {
+		r := indicators.NewRSI(n)
+		for _, p := range prices {
+			r.Update(p.Close)
+		}
+		return r.Value()
+	})
+	defineIndicator("atr", func(prices []aastocks.HistoricalPrice, n int) float64 {
+		a := indicators.NewATR(n)
+		for _, p := range prices {
+			a.Update(p)
+		}
+		return a.Value()
+	})
+	defineIndicator("highest", func(prices []aastocks.HistoricalPrice, n int) float64 {
+		if len(prices) < n {
+			return math.NaN()
+		}
+		v := math.Inf(-1)
+		for _, p := range prices[len(prices)-n:] {
+			v = math.Max(v, p.High)
+		}
+		return v
+	})
+	defineIndicator("lowest", func(prices []aastocks.HistoricalPrice, n int) float64 {
+		if len(prices) < n {
+			return math.NaN()
+		}
+		v := math.Inf(1)
+		for _, p := range prices[len(prices)-n:] {
+			v = math.Min(v, p.Low)
+		}
+		return v
+	})
+	defineIndicator("avg_volume", func(prices []aastocks.HistoricalPrice, n int) float64 {
+		s := indicators.NewSMA(n)
+		for _, p := range prices {
+			s.Update(p.Volume)
+		}
+		return s.Value()
+	})
+
+	bollinger := []param{{name: "n", t: Number, period: true}, {name: "k", t: Number}}
+	bands := func(env *Env, args []float64) (indicators.BollingerValue, error) {
+		n := int(args[0])
+		if len(env.Prices) < n {
+			return indicators.BollingerValue{}, ErrInsufficient
+		}
+		b := indicators.NewBollinger(n, args[1])
+		for _, p := range env.Prices {
+			b.Update(p.Close)
+		}
+		if !b.Ready() {
+			return indicators.BollingerValue{}, ErrInsufficient
+		}
+		return b.Value(), nil
+	}
+	define(&function{name: "bollinger_upper", params: bollinger, history: true, call: func(env *Env, args []float64) (float64, error) {
+		v, err := bands(env, args)
+		return v.Upper, err
+	}})
+	define(&function{name: "bollinger_lower", params: bollinger, history: true, call: func(env *Env, args []float64) (float64, error) {
+		v, err := bands(env, args)
+		return v.Lower, err
+	}})
+}
+
+// ErrInsufficient is returned by evaluation when historical prices are insufficient for indicators.
+var ErrInsufficient = errors.New("Historical prices are insufficient")
+
+func define(f *function) {
+	f.result = Number
+	functions[f.name] = f
+}
+
+// defineIndicator defines function of indicator with period, which is NaN if historical prices are insufficient.
+func defineIndicator(name string, indicator func(prices []aastocks.HistoricalPrice, n int) float64) {
+	define(&function{
+		name:    name,
+		params:  []param{{name: "n", t: Number, period: true}},
+		history: true,
+		call: func(env *Env, args []float64) (float64, error) {
+			n := int(args[0])
+			if len(env.Prices) < n {
+				return 0, ErrInsufficient
+			}
+			v := indicator(env.Prices, n)
+			if math.IsNaN(v) {
+				return 0, ErrInsufficient
+			}
+			return v, nil
+		},
+	})
+}
+
+func eval(n node, env *Env) (interface{}, error) {
+	switch n := n.(type) {
+	case *literal:
+		return n.value, nil
+	case *variableNode:
+		return n.v.value(env.Quote), nil
+	case *unaryNode:
+		x, err := eval(n.x, env)
+		if err != nil {
+			return nil, err
+		}
+		if n.op == tokenNot {
+			return !x.(bool), nil
+		}
+		return -x.(float64), nil
+	case *binaryNode:
+		return evalBinary(n, env)
+	case *callNode:
+		args := make([]float64, len(n.args))
+		for i, arg := range n.args {
+			v, err := eval(arg, env)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = v.(float64)
+		}
+		v, err := n.f.call(env, args)
+		if err != nil {
+			return nil, fmt.Errorf("Function %v failed to be evaluated: %w", n.f.name, err)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("Node %T cannot be evaluated", n)
+}
+
+func evalBinary(n *binaryNode, env *Env) (interface{}, error) {
+	x, err := eval(n.x, env)
+	if err != nil {
+		return nil, err
+	}
+	// Short circuit logical operators
+	switch n.op {
+	case tokenAnd:
+		if !x.(bool) {
+			return false, nil
+		}
+		return eval(n.y, env)
+	case tokenOr:
+		if x.(bool) {
+			return true, nil
+		}
+		return eval(n.y, env)
+	}
+	y, err := eval(n.y, env)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case tokenEq:
+		return x == y, nil
+	case tokenNe:
+		return x != y, nil
+	}
+	a, b := x.(float64), y.(float64)
+	switch n.op {
+	case tokenLt:
+		return a < b, nil
+	case tokenLe:
+		return a <= b, nil
+	case tokenGt:
+		return a > b, nil
+	case tokenGe:
+		return a >= b, nil
+	case tokenAdd:
+		return a + b, nil
+	case tokenSub:
+		return a - b, nil
+	case tokenMul:
+		return a * b, nil
+	case tokenDiv:
+		return a / b, nil
+	case tokenMod:
+		return math.Mod(a, b), nil
+	}
+	return nil, fmt.Errorf("Operator %q cannot be evaluated", operators[n.op])
+}
@@ -0,0 +1,161 @@
+// Package expr compiles and evaluates expressions over quotes of AAStocks,
+// for custom screens and alerts.
+//
+// Expressions are compiled once and type checked, so that errors are reported with their columns
+// before any quote is evaluated. Compiled programs are safe for concurrent use,
+// so a program can be evaluated against many quotes in batch.
+//
+//	p, err := expr.Compile(`price > 1.05 * sma(50) && yield > 0.05`)
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	prices, err := quote.HistoricalPrices(aastocks.Daily)
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	ok, err := p.EvalBool(expr.Env{Quote: quote, Prices: prices})
+//
+// Variables are fields of quote: symbol, name, industry, price, prev_close, change (fraction from previous close),
+// low_52w, high_52w, yield, pe, pb, eps and lots.
+//
+// Functions of indicators are computed from historical prices, with constant periods:
+// sma(n), ema(n), rsi(n), atr(n), highest(n), lowest(n), avg_volume(n),
+// bollinger_upper(n, k) and bollinger_lower(n, k).
+// Functions of numbers are abs(x), min(x, y) and max(x, y).
+//
+// Operators are || && ! == != < <= > >= + - * / % in the usual precedence.
+package expr
+
+import (
+	"fmt"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Type of expression.
+type Type int
+
+const (
+	// Invalid type
+	Invalid Type = iota
+	// Number is float64
+	Number
+	// Bool is bool
+	Bool
+	// String is string
+	String
+)
+
+func (t Type) String() string {
+	switch t {
+	case Number:
+		return "number"
+	case Bool:
+		return "bool"
+	case String:
+		return "string"
+	}
+	return "invalid"
+}
+
+// Error of compiling expression.
+type Error struct {
+	// Column of the error in expression, starting from 1
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v at column %v", e.Msg, e.Column)
+}
+
+// Env is the environment which expression is evaluated in.
+// Prices are historical prices in ascending order of time, and are required only
+// if indicator functions are used.
+type Env struct {
+	Quote  *aastocks.Quote
+	Prices []aastocks.HistoricalPrice
+}
+
+// Program is compiled expression.
+type Program struct {
+	source  string
+	root    node
+	history bool
+}
+
+// Compile expression into program.
+func Compile(source string) (*Program, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &Program{
+		source:  source,
+		root:    root,
+		history: p.history,
+	}, nil
+}
+
+// CompileBool compiles expression which must be evaluated to bool, i.e. conditions of screens and alerts.
+func CompileBool(source string) (*Program, error) {
+	p, err := Compile(source)
+	if err != nil {
+		return nil, err
+	}
+	if p.Type() != Bool {
+		return nil, &Error{Column: 1, Msg: fmt.Sprintf("Expression expects bool, but got %v", p.Type())}
+	}
+	return p, nil
+}
+
+// Type of the value evaluated by the program.
+func (p *Program) Type() Type {
+	return p.root.typ()
+}
+
+// UsesHistory returns whether historical prices are required to evaluate the program.
+func (p *Program) UsesHistory() bool {
+	return p.history
+}
+
+func (p *Program) String() string {
+	return p.source
+}
+
+// Eval program, and returns value of float64, bool or string according to its type.
+func (p *Program) Eval(env Env) (interface{}, error) {
+	if env.Quote == nil {
+		return nil, fmt.Errorf("Quote cannot be nil")
+	}
+	return eval(p.root, &env)
+}
+
+// EvalBool evaluates program of bool type.
+func (p *Program) EvalBool(env Env) (bool, error) {
+	if p.Type() != Bool {
+		return false, fmt.Errorf("Expression is %v, but not bool", p.Type())
+	}
+	v, err := p.Eval(env)
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
+
+// EvalNumber evaluates program of number type.
+func (p *Program) EvalNumber(env Env) (float64, error) {
+	if p.Type() != Number {
+		return 0, fmt.Errorf("Expression is %v, but not number", p.Type())
+	}
+	v, err := p.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	return v.(float64), nil
+}
@@ -0,0 +1,185 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+func testQuote() *aastocks.Quote {
+	return &aastocks.Quote{
+		Symbol:       "00006",
+		Name:         "POWER ASSETS",
+		Industry:     "Electricity Supply",
+		Price:        44.65,
+		PrevClose:    44.2,
+		Price52WLow:  41.6,
+		Price52WHigh: 58.5,
+		Yield:        0.06271,
+		PeRatio:      13.368,
+		PbRatio:      1.115,
+		Eps:          3.34,
+		Lots:         500,
+	}
+}
+
+// testPrices returns closes of 40, 41, ..., 44 with high and low 1 away from close.
+func testPrices() []aastocks.HistoricalPrice {
+	prices := make([]aastocks.HistoricalPrice, 0)
+	for i := 0; i < 5; i++ {
+		c := float64(40 + i)
+		prices = append(prices, aastocks.HistoricalPrice{
+			Time:   time.Date(2020, time.August, 17+i, 0, 0, 0, 0, time.UTC),
+			Open:   c,
+			High:   c + 1,
+			Low:    c - 1,
+			Close:  c,
+			Volume: float64(1000 * (i + 1)),
+		})
+	}
+	return prices
+}
+
+func TestCompile(t *testing.T) {
+	testCases := []struct {
+		source  string
+		typ     Type
+		history bool
+		err     error
+	}{
+		{source: `price > 1.05 * sma(50) && yield > 0.05`, typ: Bool, history: true},
+		{source: `(price - prev_close) / prev_close`, typ: Number},
+		{source: `symbol == "00006" || !(pe < 10)`, typ: Bool},
+		{source: `bollinger_lower(20, 2)`, typ: Number, history: true},
+		{source: `name`, typ: String},
+		{source: `price > `, err: fmt.Errorf("Token end of expression is unexpected at column 9")},
+		{source: `price >> 1`, err: fmt.Errorf("Token > is unexpected at column 8")},
+		{source: `price && yield`, err: fmt.Errorf(`Operator "&&" expects bool operands, but got number and number at column 7`)},
+		{source: `symbol == 6`, err: fmt.Errorf(`Operator "==" cannot compare string with number at column 8`)},
+		{source: `!price`, err: fmt.Errorf(`Operator "!" expects bool operand, but got number at column 1`)},
+		{source: `volume > 1`, err: fmt.Errorf("Variable volume is unknown at column 1")},
+		{source: `price > wma(10)`, err: fmt.Errorf("Function wma is unknown at column 9")},
+		{source: `sma(10, 20)`, err: fmt.Errorf("Function sma expects 1 arguments, but got 2 at column 1")},
+		{source: `sma(lots)`, err: fmt.Errorf("Argument n of sma must be constant at column 5")},
+		{source: `sma(2.5)`, err: fmt.Errorf("Argument n of sma must be positive integer, but got 2.5 at column 5")},
+		{source: `sma(-5)`, err: fmt.Errorf("Argument n of sma must be positive integer, but got -5 at column 5")},
+		{source: `sma(100000000000000)`, err: fmt.Errorf("Argument n of sma must not be greater than 10000, but got 1e+14 at column 5")},
+		{source: `abs(true)`, err: fmt.Errorf("Argument x of abs expects number, but got bool at column 5")},
+		{source: `(price > 1`, err: fmt.Errorf(`Expected ")", but got end of expression at column 11`)},
+		{source: `price # 1`, err: fmt.Errorf(`Character '#' is unexpected at column 7`)},
+		{source: `name == "POWER`, err: fmt.Errorf("String is not terminated at column 9")},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.source, func(t *testing.T) {
+			p, err := Compile(tC.source)
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			if err != nil {
+				return
+			}
+			diff = cmp.Diff([]interface{}{tC.typ, tC.history}, []interface{}{p.Type(), p.UsesHistory()})
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestCompileBool(t *testing.T) {
+	_, err := CompileBool(`price * 2`)
+	diff := cmp.Diff("Expression expects bool, but got number at column 1", fmt.Sprint(err))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestEval(t *testing.T) {
+	testCases := []struct {
+		source string
+		prices []aastocks.HistoricalPrice
+		value  interface{}
+		err    error
+	}{
+		{source: `price > 1.05 * sma(5) && yield > 0.05`, prices: testPrices(), value: true},
+		{source: `price > 1.1 * sma(5) && yield > 0.05`, prices: testPrices(), value: false},
+		{source: `sma(5)`, prices: testPrices(), value: 42.0},
+		{source: `highest(2) - lowest(3)`, prices: testPrices(), value: 4.0},
+		{source: `avg_volume(2)`, prices: testPrices(), value: 4500.0},
+		{source: `round(rsi(4))`, err: fmt.Errorf("Function round is unknown at column 1")},
+		{source: `rsi(4)`, prices: testPrices(), value: 100.0},
+		{source: `change > 0.01`, value: true},
+		{source: `-price + 2 * 3 % 4`, value: -42.65},
+		{source: `max(pe, pb) - min(abs(-eps), lots)`, value: 13.368 - 3.34},
+		{source: `symbol == "00006" && name != "HSBC"`, value: true},
+		{source: `industry == "Banks"`, value: false},
+		{source: `pe < 10 || pe / 0 > 1`, value: true},
+		// Indicators are not evaluated when they are short circuited
+		{source: `pe < 10 && sma(5) > 0`, value: false},
+		{source: `sma(10) > 0`, prices: testPrices(), err: fmt.Errorf("Function sma failed to be evaluated: Historical prices are insufficient")},
+		{source: `bollinger_upper(20, 2) > 0`, err: fmt.Errorf("Function bollinger_upper failed to be evaluated: Historical prices are insufficient")},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.source, func(t *testing.T) {
+			p, err := Compile(tC.source)
+			if err == nil {
+				var v interface{}
+				v, err = p.Eval(Env{Quote: testQuote(), Prices: tC.prices})
+				diff := cmp.Diff(tC.value, v, cmp.Comparer(func(a, b float64) bool {
+					return math.Abs(a-b) < 1e-9
+				}))
+				if diff != "" {
+					t.Fatalf(diff)
+				}
+			}
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestEvalInsufficient(t *testing.T) {
+	sources := []string{
+		`price > sma(10)`,
+		`price > sma(10000)`,
+		`price > highest(10000)`,
+		`price > bollinger_upper(10000, 2)`,
+	}
+	for _, source := range sources {
+		t.Run(source, func(t *testing.T) {
+			p, err := Compile(source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = p.EvalBool(Env{Quote: testQuote(), Prices: testPrices()})
+			if !errors.Is(err, ErrInsufficient) {
+				t.Fatalf("expected error to be ErrInsufficient, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestEvalBool(t *testing.T) {
+	p, err := Compile(`price`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = p.EvalBool(Env{Quote: testQuote()})
+	diff := cmp.Diff("Expression is number, but not bool", fmt.Sprint(err))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	_, err = p.EvalNumber(Env{})
+	diff = cmp.Diff("Quote cannot be nil", fmt.Sprint(err))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,158 @@
+package expr
+
+import (
+	"strconv"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenAdd
+	tokenSub
+	tokenMul
+	tokenDiv
+	tokenMod
+	tokenNot
+	tokenAnd
+	tokenOr
+	tokenEq
+	tokenNe
+	tokenLt
+	tokenLe
+	tokenGt
+	tokenGe
+)
+
+var operators = map[tokenKind]string{
+	tokenLParen: "(",
+	tokenRParen: ")",
+	tokenComma:  ",",
+	tokenAdd:    "+",
+	tokenSub:    "-",
+	tokenMul:    "*",
+	tokenDiv:    "/",
+	tokenMod:    "%",
+	tokenNot:    "!",
+	tokenAnd:    "&&",
+	tokenOr:     "||",
+	tokenEq:     "==",
+	tokenNe:     "!=",
+	tokenLt:     "<",
+	tokenLe:     "<=",
+	tokenGt:     ">",
+	tokenGe:     ">=",
+}
+
+type token struct {
+	kind tokenKind
+	// pos is byte offset of the token in source
+	pos  int
+	text string
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenNumber, tokenString, tokenIdent:
+		return t.text
+	}
+	return operators[t.kind]
+}
+
+// lex splits source into tokens, ended with EOF token.
+func lex(source string) ([]token, error) {
+	tokens := make([]token, 0)
+	i := 0
+	for i < len(source) {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isDigit(c) || (c == '.' && i+1 < len(source) && isDigit(source[i+1])):
+			start := i
+			for i < len(source) && (isDigit(source[i]) || source[i] == '.') {
+				i++
+			}
+			if i < len(source) && (source[i] == 'e' || source[i] == 'E') {
+				i++
+				if i < len(source) && (source[i] == '+' || source[i] == '-') {
+					i++
+				}
+				for i < len(source) && isDigit(source[i]) {
+					i++
+				}
+			}
+			text := source[start:i]
+			if _, err := strconv.ParseFloat(text, 64); err != nil {
+				return nil, errorf(start, "Number %v is invalid", text)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, pos: start, text: text})
+		case isLetter(c):
+			start := i
+			for i < len(source) && (isLetter(source[i]) || isDigit(source[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, pos: start, text: source[start:i]})
+		case c == '"':
+			start := i
+			i++
+			for i < len(source) && source[i] != '"' {
+				if source[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(source) {
+				return nil, errorf(start, "String is not terminated")
+			}
+			i++
+			text := source[start:i]
+			if _, err := strconv.Unquote(text); err != nil {
+				return nil, errorf(start, "String %v is invalid", text)
+			}
+			tokens = append(tokens, token{kind: tokenString, pos: start, text: text})
+		default:
+			kind, n := operator(source[i:])
+			if n == 0 {
+				return nil, errorf(i, "Character %q is unexpected", c)
+			}
+			tokens = append(tokens, token{kind: kind, pos: i})
+			i += n
+		}
+	}
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(source)})
+	return tokens, nil
+}
+
+// operator matches the longest operator at start of s, and returns its length.
+func operator(s string) (tokenKind, int) {
+	if len(s) >= 2 {
+		for k, op := range operators {
+			if len(op) == 2 && s[:2] == op {
+				return k, 2
+			}
+		}
+	}
+	for k, op := range operators {
+		if len(op) == 1 && s[:1] == op {
+			return k, 1
+		}
+	}
+	return tokenEOF, 0
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,26 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLex(t *testing.T) {
+	tokens, err := lex(`price>=1.5e2&&!(name!="A\"B")||sma(10)%2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := make([]string, len(tokens))
+	for i, tok := range tokens {
+		actual[i] = tok.String()
+	}
+	expected := []string{
+		"price", ">=", "1.5e2", "&&", "!", "(", "name", "!=", `"A\"B"`, ")", "||",
+		"sma", "(", "10", ")", "%", "2", "end of expression",
+	}
+	diff := cmp.Diff(expected, actual)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
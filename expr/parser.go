@@ -0,0 +1,290 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// node of syntax tree, which is type checked when it is parsed.
+type node interface {
+	pos() int
+	typ() Type
+}
+
+type literal struct {
+	p     int
+	t     Type
+	value interface{}
+}
+
+type variableNode struct {
+	p int
+	v *variable
+}
+
+type unaryNode struct {
+	p  int
+	op tokenKind
+	x  node
+}
+
+type binaryNode struct {
+	p    int
+	op   tokenKind
+	x, y node
+	t    Type
+}
+
+type callNode struct {
+	p    int
+	f    *function
+	args []node
+}
+
+func (n *literal) pos() int      { return n.p }
+func (n *literal) typ() Type     { return n.t }
+func (n *variableNode) pos() int { return n.p }
+func (n *variableNode) typ() Type {
+	return n.v.t
+}
+func (n *unaryNode) pos() int   { return n.p }
+func (n *unaryNode) typ() Type  { return n.x.typ() }
+func (n *binaryNode) pos() int  { return n.p }
+func (n *binaryNode) typ() Type { return n.t }
+func (n *callNode) pos() int    { return n.p }
+func (n *callNode) typ() Type   { return n.f.result }
+
+type parser struct {
+	tokens []token
+	i      int
+	// history is whether historical prices are used
+	history bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, errorf(t.pos, "Expected %q, but got %v", operators[kind], t)
+	}
+	return t, nil
+}
+
+// parse the whole expression.
+func (p *parser) parse() (node, error) {
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	if t.kind != tokenEOF {
+		return nil, errorf(t.pos, "Token %v is unexpected", t)
+	}
+	return n, nil
+}
+
+func (p *parser) or() (node, error) {
+	return p.binary(p.and, tokenOr)
+}
+
+func (p *parser) and() (node, error) {
+	return p.binary(p.comparison, tokenAnd)
+}
+
+func (p *parser) comparison() (node, error) {
+	return p.binary(p.additive, tokenEq, tokenNe, tokenLt, tokenLe, tokenGt, tokenGe)
+}
+
+func (p *parser) additive() (node, error) {
+	return p.binary(p.multiplicative, tokenAdd, tokenSub)
+}
+
+func (p *parser) multiplicative() (node, error) {
+	return p.binary(p.unary, tokenMul, tokenDiv, tokenMod)
+}
+
+// binary parses left associative operators of the same precedence.
+func (p *parser) binary(operand func() (node, error), ops ...tokenKind) (node, error) {
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if !contains(ops, t.kind) {
+			return x, nil
+		}
+		p.next()
+		y, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		x, err = checkBinary(t, x, y)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	t := p.peek()
+	if t.kind != tokenNot && t.kind != tokenSub {
+		return p.primary()
+	}
+	p.next()
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	expected := Number
+	if t.kind == tokenNot {
+		expected = Bool
+	}
+	if x.typ() != expected {
+		return nil, errorf(t.pos, "Operator %q expects %v operand, but got %v", operators[t.kind], expected, x.typ())
+	}
+	if l, ok := x.(*literal); ok && t.kind == tokenSub {
+		// Fold negative number, so that it is still constant
+		return &literal{p: t.pos, t: Number, value: -l.value.(float64)}, nil
+	}
+	return &unaryNode{p: t.pos, op: t.kind, x: x}, nil
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, errorf(t.pos, "Number %v is invalid", t.text)
+		}
+		return &literal{p: t.pos, t: Number, value: v}, nil
+	case tokenString:
+		v, err := strconv.Unquote(t.text)
+		if err != nil {
+			return nil, errorf(t.pos, "String %v is invalid", t.text)
+		}
+		return &literal{p: t.pos, t: String, value: v}, nil
+	case tokenLParen:
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.expect(tokenRParen)
+		if err != nil {
+			return nil, err
+		}
+		return n, nil
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			return p.call(t)
+		}
+		switch t.text {
+		case "true", "false":
+			return &literal{p: t.pos, t: Bool, value: t.text == "true"}, nil
+		}
+		v, ok := variables[t.text]
+		if !ok {
+			return nil, errorf(t.pos, "Variable %v is unknown", t.text)
+		}
+		return &variableNode{p: t.pos, v: v}, nil
+	}
+	return nil, errorf(t.pos, "Token %v is unexpected", t)
+}
+
+func (p *parser) call(name token) (node, error) {
+	f, ok := functions[name.text]
+	if !ok {
+		return nil, errorf(name.pos, "Function %v is unknown", name.text)
+	}
+	p.next()
+	args := make([]node, 0)
+	if p.peek().kind != tokenRParen {
+		for {
+			arg, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	_, err := p.expect(tokenRParen)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != len(f.params) {
+		return nil, errorf(name.pos, "Function %v expects %v arguments, but got %v", name.text, len(f.params), len(args))
+	}
+	for i, arg := range args {
+		param := f.params[i]
+		if arg.typ() != param.t {
+			return nil, errorf(arg.pos(), "Argument %v of %v expects %v, but got %v", param.name, name.text, param.t, arg.typ())
+		}
+		if param.period {
+			l, ok := arg.(*literal)
+			if !ok {
+				return nil, errorf(arg.pos(), "Argument %v of %v must be constant", param.name, name.text)
+			}
+			v := l.value.(float64)
+			if v <= 0 || v != float64(int(v)) {
+				return nil, errorf(arg.pos(), "Argument %v of %v must be positive integer, but got %v", param.name, name.text, v)
+			}
+			if v > maxPeriod {
+				return nil, errorf(arg.pos(), "Argument %v of %v must not be greater than %v, but got %v", param.name, name.text, maxPeriod, v)
+			}
+		}
+	}
+	if f.history {
+		p.history = true
+	}
+	return &callNode{p: name.pos, f: f, args: args}, nil
+}
+
+func checkBinary(op token, x, y node) (node, error) {
+	var operand, result Type
+	switch op.kind {
+	case tokenAnd, tokenOr:
+		operand, result = Bool, Bool
+	case tokenLt, tokenLe, tokenGt, tokenGe:
+		operand, result = Number, Bool
+	case tokenEq, tokenNe:
+		operand, result = x.typ(), Bool
+	default:
+		operand, result = Number, Number
+	}
+	if x.typ() != operand || y.typ() != operand {
+		if op.kind == tokenEq || op.kind == tokenNe {
+			return nil, errorf(op.pos, "Operator %q cannot compare %v with %v", operators[op.kind], x.typ(), y.typ())
+		}
+		return nil, errorf(op.pos, "Operator %q expects %v operands, but got %v and %v", operators[op.kind], operand, x.typ(), y.typ())
+	}
+	return &binaryNode{p: op.pos, op: op.kind, x: x, y: y, t: result}, nil
+}
+
+func contains(kinds []tokenKind, kind tokenKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func errorf(pos int, format string, args ...interface{}) error {
+	return &Error{Column: pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
@@ -12,11 +12,13 @@ import (
 
 // HistoricalPrice is the historical price of quote.
 type HistoricalPrice struct {
-	Time  time.Time
-	Open  float64
-	High  float64
-	Low   float64
-	Close float64
+	Time  time.Time `json:"time"`
+	Open  float64   `json:"open"`
+	High  float64   `json:"high"`
+	Low   float64   `json:"low"`
+	Close float64   `json:"close"`
+	// Volume in thousands of shares
+	Volume float64 `json:"volume"`
 }
 
 // PriceFrequency is the frequency of historical data to be provided.
@@ -42,6 +44,11 @@ func (q *Quote) HistoricalPrices(frequency PriceFrequency) ([]HistoricalPrice, e
 	}
 	defer resp.Body.Close()
 
+	err = checkStatus(resp, "historical prices")
+	if err != nil {
+		return nil, err
+	}
+
 	prices := make([]HistoricalPrice, 0)
 	r := newPriceScanner(resp.Body)
 	for r.Scan() {
@@ -58,6 +65,7 @@ type priceScanner struct {
 	price   HistoricalPrice
 	blank   bool
 	err     error
+	now     func() time.Time
 }
 
 func newPriceScanner(r io.Reader) *priceScanner {
@@ -66,6 +74,7 @@ func newPriceScanner(r io.Reader) *priceScanner {
 
 	p := &priceScanner{
 		scanner: s,
+		now:     time.Now,
 	}
 	// First scan is name of quote
 	p.scanner.Scan()
@@ -125,10 +134,12 @@ func (s *priceScanner) parsePrice(str string) (HistoricalPrice, error) {
 	type parseFunc func(parts []string, idx int) (func(p *HistoricalPrice), error)
 	parseFuncs := []struct {
 		name      string
+		field     string
 		parseFunc parseFunc
 	}{
 		{
-			name: "Time",
+			name:  "Time",
+			field: "time",
 			parseFunc: func(parts []string, idx int) (func(p *HistoricalPrice), error) {
 				var err error
 				t, err := s.priceTime(parts)
@@ -139,7 +150,8 @@ func (s *priceScanner) parsePrice(str string) (HistoricalPrice, error) {
 			},
 		},
 		{
-			name: "Open price",
+			name:  "Open price",
+			field: "open",
 			parseFunc: func(parts []string, idx int) (func(p *HistoricalPrice), error) {
 				var err error
 				v, err := strconv.ParseFloat(parts[idx], 64)
@@ -150,7 +162,8 @@ func (s *priceScanner) parsePrice(str string) (HistoricalPrice, error) {
 			},
 		},
 		{
-			name: "High price",
+			name:  "High price",
+			field: "high",
 			parseFunc: func(parts []string, idx int) (func(p *HistoricalPrice), error) {
 				var err error
 				v, err := strconv.ParseFloat(parts[idx], 64)
@@ -161,7 +174,8 @@ func (s *priceScanner) parsePrice(str string) (HistoricalPrice, error) {
 			},
 		},
 		{
-			name: "Low price",
+			name:  "Low price",
+			field: "low",
 			parseFunc: func(parts []string, idx int) (func(p *HistoricalPrice), error) {
 				var err error
 				v, err := strconv.ParseFloat(parts[idx], 64)
@@ -172,7 +186,8 @@ func (s *priceScanner) parsePrice(str string) (HistoricalPrice, error) {
 			},
 		},
 		{
-			name: "Close price",
+			name:  "Close price",
+			field: "close",
 			parseFunc: func(parts []string, idx int) (func(p *HistoricalPrice), error) {
 				var err error
 				v, err := strconv.ParseFloat(parts[idx], 64)
@@ -182,6 +197,18 @@ func (s *priceScanner) parsePrice(str string) (HistoricalPrice, error) {
 				return f, err
 			},
 		},
+		{
+			name:  "Volume",
+			field: "volume",
+			parseFunc: func(parts []string, idx int) (func(p *HistoricalPrice), error) {
+				var err error
+				v, err := strconv.ParseFloat(parts[idx], 64)
+				f := func(p *HistoricalPrice) {
+					p.Volume = v
+				}
+				return f, err
+			},
+		},
 	}
 
 	startIdx := 0
@@ -193,7 +220,7 @@ func (s *priceScanner) parsePrice(str string) (HistoricalPrice, error) {
 	for i, f := range parseFuncs {
 		opt, err := f.parseFunc(parts, startIdx+i)
 		if err != nil {
-			return HistoricalPrice{}, fmt.Errorf("%s failed to be parsed: %v", f.name, err)
+			return HistoricalPrice{}, &ParseError{Field: f.field, Err: fmt.Errorf("%s failed to be parsed: %v", f.name, err)}
 		}
 		opt(&p)
 	}
@@ -210,7 +237,15 @@ func (s *priceScanner) priceTime(parts []string) (time.Time, error) {
 		if err != nil {
 			return time.Time{}, err
 		}
-		return time.Date(time.Now().Year(), pd.Month(), pd.Day(), ptt.Hour(), ptt.Minute(), ptt.Second(), ptt.Nanosecond(), time.UTC), nil
+		// Year is not given, it is the current year unless the price would be later than now,
+		// which is the previous year when prices of December are fetched in January.
+		now := s.now().In(HongKong)
+		now = time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), time.UTC)
+		t := time.Date(now.Year(), pd.Month(), pd.Day(), ptt.Hour(), ptt.Minute(), ptt.Second(), ptt.Nanosecond(), time.UTC)
+		if t.After(now.Add(24 * time.Hour)) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t, nil
 	}
 
 	pt, err := time.Parse(monthDayYearLayout, parts[0])
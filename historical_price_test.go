@@ -1,6 +1,7 @@
 package aastocks
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 	"time"
@@ -10,6 +11,11 @@ import (
 
 func TestHistoricalPrice(t *testing.T) {
 	mock := mockClient()
+	// hourly prices without year are of the previous year if they would be later than now
+	hourlyYear := time.Now().Year()
+	if time.Now().Before(time.Date(hourlyYear, time.July, 30, 10, 0, 0, 0, time.UTC)) {
+		hourlyYear--
+	}
 	testCases := []struct {
 		desc         string
 		symbol       string
@@ -29,11 +35,12 @@ func TestHistoricalPrice(t *testing.T) {
 			frequency:    Hourly,
 			pricesLength: 370,
 			firstPrice: HistoricalPrice{
-				Time:  time.Date(time.Now().Year(), time.July, 31, 10, 0, 0, 0, time.UTC),
-				Open:  42.75,
-				High:  43.15,
-				Low:   42.7,
-				Close: 43.05,
+				Time:   time.Date(hourlyYear, time.July, 31, 10, 0, 0, 0, time.UTC),
+				Open:   42.75,
+				High:   43.15,
+				Low:    42.7,
+				Close:  43.05,
+				Volume: 209.111,
 			},
 		},
 		{
@@ -46,11 +53,12 @@ func TestHistoricalPrice(t *testing.T) {
 			frequency:    Daily,
 			pricesLength: 1482,
 			firstPrice: HistoricalPrice{
-				Time:  time.Date(2015, time.August, 26, 0, 0, 0, 0, time.UTC),
-				Open:  45.48,
-				High:  48.03,
-				Low:   45.23,
-				Close: 47.23,
+				Time:   time.Date(2015, time.August, 26, 0, 0, 0, 0, time.UTC),
+				Open:   45.48,
+				High:   48.03,
+				Low:    45.23,
+				Close:  47.23,
+				Volume: 5279.115,
 			},
 		},
 	}
@@ -82,3 +90,57 @@ func TestHistoricalPrice(t *testing.T) {
 		})
 	}
 }
+
+func TestHistoricalPriceParseError(t *testing.T) {
+	s := &priceScanner{}
+	_, err := s.parsePrice("08/25/2020;44.2;44.65;44.05;-;1709.545;0")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected error to be ParseError, but got %v", err)
+	}
+	diff := cmp.Diff([]string{"close", `Close price failed to be parsed: strconv.ParseFloat: parsing "-": invalid syntax`}, []string{parseErr.Field, err.Error()})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestHistoricalPriceYear(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		now      time.Time
+		str      string
+		expected time.Time
+	}{
+		{
+			desc:     "SameYear",
+			now:      time.Date(2020, time.August, 27, 8, 0, 0, 0, time.UTC),
+			str:      "08/27;15:00:00;44.2;44.65;44.05;44.65;1709.545;0",
+			expected: time.Date(2020, time.August, 27, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "DecemberFetchedInJanuary",
+			now:      time.Date(2021, time.January, 4, 2, 0, 0, 0, time.UTC),
+			str:      "12/31;11:00:00;44.2;44.65;44.05;44.65;1709.545;0",
+			expected: time.Date(2020, time.December, 31, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "JanuaryFetchedInJanuary",
+			now:      time.Date(2021, time.January, 4, 2, 0, 0, 0, time.UTC),
+			str:      "01/04;10:00:00;44.2;44.65;44.05;44.65;1709.545;0",
+			expected: time.Date(2021, time.January, 4, 10, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := &priceScanner{now: func() time.Time { return tC.now }}
+			p, err := s.parsePrice(tC.str)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff := cmp.Diff(tC.expected, p.Time)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
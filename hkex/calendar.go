@@ -0,0 +1,286 @@
+package hkex
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Phase of trading day.
+type Phase int
+
+const (
+	// Closed when market is not trading
+	Closed Phase = iota
+	// PreOpening auction session
+	PreOpening
+	// Morning continuous trading session
+	Morning
+	// LunchBreak between morning and afternoon sessions
+	LunchBreak
+	// Afternoon continuous trading session
+	Afternoon
+	// ClosingAuction session
+	ClosingAuction
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PreOpening:
+		return "PreOpening"
+	case Morning:
+		return "Morning"
+	case LunchBreak:
+		return "LunchBreak"
+	case Afternoon:
+		return "Afternoon"
+	case ClosingAuction:
+		return "ClosingAuction"
+	default:
+		return "Closed"
+	}
+}
+
+// Session of trading day in Hong Kong time.
+type Session struct {
+	Phase Phase
+	Start time.Time
+	End   time.Time
+}
+
+type clock struct {
+	hour   int
+	minute int
+}
+
+type sessionTime struct {
+	phase Phase
+	start clock
+	end   clock
+}
+
+var fullDaySessions = []sessionTime{
+	{phase: PreOpening, start: clock{9, 0}, end: clock{9, 30}},
+	{phase: Morning, start: clock{9, 30}, end: clock{12, 0}},
+	{phase: LunchBreak, start: clock{12, 0}, end: clock{13, 0}},
+	{phase: Afternoon, start: clock{13, 0}, end: clock{16, 0}},
+	{phase: ClosingAuction, start: clock{16, 0}, end: clock{16, 10}},
+}
+
+var halfDaySessions = []sessionTime{
+	{phase: PreOpening, start: clock{9, 0}, end: clock{9, 30}},
+	{phase: Morning, start: clock{9, 30}, end: clock{12, 0}},
+	{phase: ClosingAuction, start: clock{12, 0}, end: clock{12, 10}},
+}
+
+type dayKind int
+
+const (
+	holiday dayKind = iota + 1
+	halfDay
+)
+
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) date {
+	y, m, d := t.In(aastocks.HongKong).Date()
+	return date{y, m, d}
+}
+
+func (d date) time() time.Time {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, aastocks.HongKong)
+}
+
+// MarketCalendar of HKEX trading sessions and holidays.
+// It is safe for concurrent use.
+type MarketCalendar struct {
+	mu    sync.RWMutex
+	days  map[date]dayKind
+	years map[int]bool
+}
+
+// NewMarketCalendar creates calendar with the embedded holiday table.
+func NewMarketCalendar() *MarketCalendar {
+	c := &MarketCalendar{
+		days:  make(map[date]dayKind),
+		years: make(map[int]bool),
+	}
+	err := c.LoadHolidays(strings.NewReader(holidayTable))
+	if err != nil {
+		panic(fmt.Sprintf("hkex: embedded holiday table cannot be loaded: %v", err))
+	}
+	return c
+}
+
+// LoadHolidays updates calendar with holiday table, with date and kind per line (i.e. "2024-12-24 half-day").
+// Kind is either "holiday" or "half-day", while blank lines and lines starting with "#" are ignored.
+func (c *MarketCalendar) LoadHolidays(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	line := 0
+	for s.Scan() {
+		line++
+		t := strings.TrimSpace(s.Text())
+		if t == "" || strings.HasPrefix(t, "#") {
+			continue
+		}
+		fields := strings.Fields(t)
+		if len(fields) != 2 {
+			return fmt.Errorf("Holiday format is incorrect at line %v: %v", line, t)
+		}
+		d, err := time.ParseInLocation("2006-01-02", fields[0], aastocks.HongKong)
+		if err != nil {
+			return fmt.Errorf("Holiday date failed to be parsed at line %v: %v", line, err)
+		}
+		switch fields[1] {
+		case "holiday":
+			c.AddHoliday(d)
+		case "half-day":
+			c.AddHalfDay(d)
+		default:
+			return fmt.Errorf("Holiday kind is unknown at line %v: %v", line, fields[1])
+		}
+	}
+	return s.Err()
+}
+
+// AddHoliday marks the date as holiday, such as market closure for typhoon signal.
+func (c *MarketCalendar) AddHoliday(t time.Time) {
+	c.add(t, holiday)
+}
+
+// AddHalfDay marks the date as half-day, which only morning session is traded.
+func (c *MarketCalendar) AddHalfDay(t time.Time) {
+	c.add(t, halfDay)
+}
+
+func (c *MarketCalendar) add(t time.Time, kind dayKind) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	d := dateOf(t)
+	c.days[d] = kind
+	c.years[d.year] = true
+}
+
+// Covers checks if holidays of the year of the time are in the calendar.
+func (c *MarketCalendar) Covers(t time.Time) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.years[dateOf(t).year]
+}
+
+// IsTradingDay checks if the date of the time is weekday and not holiday.
+func (c *MarketCalendar) IsTradingDay(t time.Time) bool {
+	d := dateOf(t)
+	switch d.time().Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.days[d] != holiday
+}
+
+// IsHalfDay checks if the date of the time is half-day.
+func (c *MarketCalendar) IsHalfDay(t time.Time) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.days[dateOf(t)] == halfDay
+}
+
+// Sessions of the date of the time, empty if it is not trading day.
+func (c *MarketCalendar) Sessions(t time.Time) []Session {
+	if !c.IsTradingDay(t) {
+		return []Session{}
+	}
+	times := fullDaySessions
+	if c.IsHalfDay(t) {
+		times = halfDaySessions
+	}
+	d := dateOf(t)
+	sessions := make([]Session, len(times))
+	for i, s := range times {
+		sessions[i] = Session{
+			Phase: s.phase,
+			Start: time.Date(d.year, d.month, d.day, s.start.hour, s.start.minute, 0, 0, aastocks.HongKong),
+			End:   time.Date(d.year, d.month, d.day, s.end.hour, s.end.minute, 0, 0, aastocks.HongKong),
+		}
+	}
+	return sessions
+}
+
+// Phase of market at the time.
+func (c *MarketCalendar) Phase(t time.Time) Phase {
+	for _, s := range c.Sessions(t) {
+		if !t.Before(s.Start) && t.Before(s.End) {
+			return s.Phase
+		}
+	}
+	return Closed
+}
+
+// IsOpen checks if market is trading at the time, which is in any session except lunch break.
+func (c *MarketCalendar) IsOpen(t time.Time) bool {
+	p := c.Phase(t)
+	return p != Closed && p != LunchBreak
+}
+
+// NextOpen returns the time itself if market is open, otherwise the start of the next session.
+// It can be used as aastocks.Schedule for serving prices only when market is open.
+func (c *MarketCalendar) NextOpen(t time.Time) time.Time {
+	if c.IsOpen(t) {
+		return t
+	}
+	d := dateOf(t).time()
+	// Trading day should be found within a few weeks even for the longest holidays
+	for i := 0; i < 31; i++ {
+		for _, s := range c.Sessions(d.AddDate(0, 0, i)) {
+			if s.Phase != LunchBreak && s.Start.After(t) {
+				return s.Start
+			}
+		}
+	}
+	return t
+}
+
+// MissingDays returns trading days without prices, between the first and last of daily historical prices.
+// Only dates of years covered by the calendar are checked, as holidays of other years are unknown.
+func (c *MarketCalendar) MissingDays(prices []aastocks.HistoricalPrice) []time.Time {
+	missing := make([]time.Time, 0)
+	if len(prices) == 0 {
+		return missing
+	}
+	// Date of historical prices are in Hong Kong already, despite parsed in UTC
+	local := func(t time.Time) date {
+		y, m, d := t.Date()
+		return date{y, m, d}
+	}
+	found := make(map[date]bool, len(prices))
+	for _, p := range prices {
+		found[local(p.Time)] = true
+	}
+	sorted := make([]time.Time, len(prices))
+	for i, p := range prices {
+		sorted[i] = local(p.Time).time()
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Before(sorted[j])
+	})
+	first, last := sorted[0], sorted[len(sorted)-1]
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		if !c.Covers(d) || !c.IsTradingDay(d) || found[dateOf(d)] {
+			continue
+		}
+		missing = append(missing, d)
+	}
+	return missing
+}
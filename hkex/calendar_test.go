@@ -0,0 +1,111 @@
+package hkex
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+func hkt(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, aastocks.HongKong)
+}
+
+func TestMarketCalendarPhase(t *testing.T) {
+	c := NewMarketCalendar()
+	testCases := []struct {
+		desc  string
+		time  time.Time
+		phase Phase
+		open  bool
+	}{
+		{desc: "BeforePreOpening", time: hkt(2024, time.March, 5, 8, 59), phase: Closed},
+		{desc: "PreOpening", time: hkt(2024, time.March, 5, 9, 0), phase: PreOpening, open: true},
+		{desc: "Morning", time: hkt(2024, time.March, 5, 10, 15), phase: Morning, open: true},
+		{desc: "LunchBreak", time: hkt(2024, time.March, 5, 12, 30), phase: LunchBreak},
+		{desc: "Afternoon", time: hkt(2024, time.March, 5, 13, 0), phase: Afternoon, open: true},
+		{desc: "ClosingAuction", time: hkt(2024, time.March, 5, 16, 5), phase: ClosingAuction, open: true},
+		{desc: "AfterClose", time: hkt(2024, time.March, 5, 16, 10), phase: Closed},
+		{desc: "Weekend", time: hkt(2024, time.March, 9, 10, 0), phase: Closed},
+		{desc: "Holiday", time: hkt(2024, time.December, 25, 10, 0), phase: Closed},
+		{desc: "HalfDayClosingAuction", time: hkt(2024, time.December, 24, 12, 5), phase: ClosingAuction, open: true},
+		{desc: "HalfDayAfternoon", time: hkt(2024, time.December, 24, 14, 0), phase: Closed},
+		{desc: "UTC", time: time.Date(2024, time.March, 5, 2, 0, 0, 0, time.UTC), phase: Morning, open: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			diff := cmp.Diff(tC.phase, c.Phase(tC.time))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.open, c.IsOpen(tC.time))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMarketCalendarNextOpen(t *testing.T) {
+	c := NewMarketCalendar()
+	testCases := []struct {
+		desc     string
+		time     time.Time
+		nextOpen time.Time
+	}{
+		{desc: "Open", time: hkt(2024, time.March, 5, 10, 15), nextOpen: hkt(2024, time.March, 5, 10, 15)},
+		{desc: "BeforePreOpening", time: hkt(2024, time.March, 5, 7, 0), nextOpen: hkt(2024, time.March, 5, 9, 0)},
+		{desc: "LunchBreak", time: hkt(2024, time.March, 5, 12, 30), nextOpen: hkt(2024, time.March, 5, 13, 0)},
+		{desc: "AfterCloseOnFriday", time: hkt(2024, time.March, 8, 17, 0), nextOpen: hkt(2024, time.March, 11, 9, 0)},
+		{desc: "LunarNewYear", time: hkt(2025, time.January, 28, 13, 0), nextOpen: hkt(2025, time.February, 3, 9, 0)},
+		{desc: "Easter", time: hkt(2024, time.March, 28, 16, 30), nextOpen: hkt(2024, time.April, 2, 9, 0)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			diff := cmp.Diff(tC.nextOpen, c.NextOpen(tC.time))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestMarketCalendarLoadHolidays(t *testing.T) {
+	c := NewMarketCalendar()
+	// Typhoon signal No. 8
+	err := c.LoadHolidays(strings.NewReader("# Typhoon\n2024-03-05 holiday\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.IsTradingDay(hkt(2024, time.March, 5, 0, 0)) {
+		t.Fatalf("date loaded as holiday should not be trading day")
+	}
+
+	err = c.LoadHolidays(strings.NewReader("2024-03-06 closed\n"))
+	diff := cmp.Diff("Holiday kind is unknown at line 1: closed", err.Error())
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestMarketCalendarMissingDays(t *testing.T) {
+	c := NewMarketCalendar()
+	day := func(month time.Month, day int) aastocks.HistoricalPrice {
+		return aastocks.HistoricalPrice{Time: time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)}
+	}
+	prices := []aastocks.HistoricalPrice{
+		day(time.March, 27),
+		day(time.March, 28),
+		// Good Friday and Easter Monday are holidays
+		day(time.April, 2),
+		// 3rd April is missing
+		day(time.April, 5),
+	}
+	expected := []time.Time{hkt(2024, time.April, 3, 0, 0)}
+	diff := cmp.Diff(expected, c.MissingDays(prices))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,164 @@
+package hkex
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Rates of statutory fees charged on trades, which are effective from the time.
+type Rates struct {
+	Effective time.Time
+	// StampDuty is charged on both buyer and seller, rounded up to the nearest dollar.
+	StampDuty float64
+	// TradingFee is charged by HKEX.
+	TradingFee float64
+	// TransactionLevy is charged by SFC.
+	TransactionLevy float64
+	// AFRCLevy is charged by Accounting and Financial Reporting Council.
+	AFRCLevy float64
+	// SettlementFee is charged by CCASS, within the minimum and maximum.
+	SettlementFee    float64
+	SettlementMinFee float64
+	SettlementMaxFee float64
+}
+
+// rateSchedule is rates of HKEX by effective dates in ascending order.
+var rateSchedule = []Rates{
+	{
+		Effective:        time.Date(2014, time.January, 1, 0, 0, 0, 0, aastocks.HongKong),
+		StampDuty:        0.001,
+		TradingFee:       0.00005,
+		TransactionLevy:  0.00003,
+		SettlementFee:    0.00002,
+		SettlementMinFee: 2,
+		SettlementMaxFee: 100,
+	},
+	{
+		Effective:        time.Date(2014, time.December, 1, 0, 0, 0, 0, aastocks.HongKong),
+		StampDuty:        0.001,
+		TradingFee:       0.00005,
+		TransactionLevy:  0.000027,
+		SettlementFee:    0.00002,
+		SettlementMinFee: 2,
+		SettlementMaxFee: 100,
+	},
+	{
+		Effective:        time.Date(2021, time.August, 1, 0, 0, 0, 0, aastocks.HongKong),
+		StampDuty:        0.0013,
+		TradingFee:       0.00005,
+		TransactionLevy:  0.000027,
+		SettlementFee:    0.00002,
+		SettlementMinFee: 2,
+		SettlementMaxFee: 100,
+	},
+	{
+		Effective:        time.Date(2022, time.January, 1, 0, 0, 0, 0, aastocks.HongKong),
+		StampDuty:        0.0013,
+		TradingFee:       0.00005,
+		TransactionLevy:  0.000027,
+		AFRCLevy:         0.0000015,
+		SettlementFee:    0.00002,
+		SettlementMinFee: 2,
+		SettlementMaxFee: 100,
+	},
+	{
+		Effective:        time.Date(2023, time.January, 1, 0, 0, 0, 0, aastocks.HongKong),
+		StampDuty:        0.0013,
+		TradingFee:       0.0000565,
+		TransactionLevy:  0.000027,
+		AFRCLevy:         0.0000015,
+		SettlementFee:    0.00002,
+		SettlementMinFee: 2,
+		SettlementMaxFee: 100,
+	},
+	{
+		Effective:        time.Date(2023, time.November, 17, 0, 0, 0, 0, aastocks.HongKong),
+		StampDuty:        0.001,
+		TradingFee:       0.0000565,
+		TransactionLevy:  0.000027,
+		AFRCLevy:         0.0000015,
+		SettlementFee:    0.00002,
+		SettlementMinFee: 2,
+		SettlementMaxFee: 100,
+	},
+}
+
+// RateSchedule returns copy of rates of HKEX by effective dates in ascending order.
+// Rates not in the schedule can be used by setting them to CostModel.
+func RateSchedule() []Rates {
+	return append([]Rates(nil), rateSchedule...)
+}
+
+// RatesAt returns rates effective at the time.
+// The earliest rates are returned if the time is before all of them.
+func RatesAt(t time.Time) Rates {
+	i := sort.Search(len(rateSchedule), func(i int) bool {
+		return rateSchedule[i].Effective.After(t)
+	})
+	if i == 0 {
+		return rateSchedule[0]
+	}
+	return rateSchedule[i-1]
+}
+
+// Commission schedule of broker.
+type Commission struct {
+	Rate    float64
+	Minimum float64
+	// Fixed fee charged per trade on top of the commission (i.e. platform fee).
+	Fixed float64
+}
+
+// Cost of trade.
+type Cost struct {
+	Commission      float64
+	StampDuty       float64
+	TradingFee      float64
+	TransactionLevy float64
+	AFRCLevy        float64
+	SettlementFee   float64
+}
+
+// Total of the cost.
+func (c Cost) Total() float64 {
+	return round(c.Commission + c.StampDuty + c.TradingFee + c.TransactionLevy + c.AFRCLevy + c.SettlementFee)
+}
+
+// CostModel computes cost of trades with rates and broker commission.
+type CostModel struct {
+	Rates      Rates
+	Commission Commission
+}
+
+// NewCostModel creates cost model with rates effective at the time, and broker commission.
+func NewCostModel(t time.Time, commission Commission) *CostModel {
+	return &CostModel{
+		Rates:      RatesAt(t),
+		Commission: commission,
+	}
+}
+
+// TradeCost of trading quantity of shares at the price.
+// Statutory fees are charged on buyer and seller at the same rates, so that side does not change the cost.
+// Side is kept to match cost of backtest (i.e. backtest.Config.Cost), and for rates charged on one side only in future.
+// Stamp duty is rounded up to the nearest dollar, while other fees are rounded to cents.
+func (m *CostModel) TradeCost(side Side, price float64, quantity int) Cost {
+	value := price * float64(quantity)
+	r := m.Rates
+	return Cost{
+		Commission:      round(math.Max(value*m.Commission.Rate, m.Commission.Minimum) + m.Commission.Fixed),
+		StampDuty:       math.Ceil(round(value * r.StampDuty)),
+		TradingFee:      round(value * r.TradingFee),
+		TransactionLevy: round(value * r.TransactionLevy),
+		AFRCLevy:        round(value * r.AFRCLevy),
+		SettlementFee:   round(math.Min(math.Max(value*r.SettlementFee, r.SettlementMinFee), r.SettlementMaxFee)),
+	}
+}
+
+// round to cents.
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
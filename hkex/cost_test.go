@@ -0,0 +1,135 @@
+package hkex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+func TestRatesAt(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		time      time.Time
+		stampDuty float64
+		afrcLevy  float64
+	}{
+		{
+			desc:      "BeforeSchedule",
+			time:      time.Date(2010, time.January, 1, 0, 0, 0, 0, aastocks.HongKong),
+			stampDuty: 0.001,
+		},
+		{
+			desc:      "StampDutyRaised",
+			time:      time.Date(2021, time.August, 1, 0, 0, 0, 0, aastocks.HongKong),
+			stampDuty: 0.0013,
+		},
+		{
+			desc:      "AFRCLevy",
+			time:      time.Date(2022, time.June, 1, 0, 0, 0, 0, aastocks.HongKong),
+			stampDuty: 0.0013,
+			afrcLevy:  0.0000015,
+		},
+		{
+			desc:      "StampDutyReduced",
+			time:      time.Date(2023, time.November, 17, 9, 30, 0, 0, aastocks.HongKong),
+			stampDuty: 0.001,
+			afrcLevy:  0.0000015,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			r := RatesAt(tC.time)
+			diff := cmp.Diff([]float64{tC.stampDuty, tC.afrcLevy}, []float64{r.StampDuty, r.AFRCLevy})
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestRateSchedule(t *testing.T) {
+	schedule := RateSchedule()
+	schedule[0].StampDuty = 0
+	diff := cmp.Diff(0.001, RatesAt(time.Date(2014, time.January, 1, 0, 0, 0, 0, aastocks.HongKong)).StampDuty)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestTradeCost(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		time       time.Time
+		commission Commission
+		side       Side
+		price      float64
+		quantity   int
+		cost       Cost
+		total      float64
+	}{
+		{
+			desc:       "MinimumCommission",
+			time:       time.Date(2020, time.August, 25, 0, 0, 0, 0, aastocks.HongKong),
+			commission: Commission{Rate: 0.0025, Minimum: 100},
+			side:       Buy,
+			price:      44.65,
+			quantity:   500,
+			cost: Cost{
+				Commission:      100,
+				StampDuty:       23,
+				TradingFee:      1.12,
+				TransactionLevy: 0.6,
+				SettlementFee:   2,
+			},
+			total: 126.72,
+		},
+		{
+			desc:       "TransactionLevyBeforeReduced",
+			time:       time.Date(2014, time.June, 3, 0, 0, 0, 0, aastocks.HongKong),
+			commission: Commission{Rate: 0.0025, Minimum: 100},
+			side:       Sell,
+			price:      44.65,
+			quantity:   500,
+			cost: Cost{
+				Commission:      100,
+				StampDuty:       23,
+				TradingFee:      1.12,
+				TransactionLevy: 0.67,
+				SettlementFee:   2,
+			},
+			total: 126.79,
+		},
+		{
+			desc:       "MaximumSettlementFee",
+			time:       time.Date(2024, time.January, 2, 0, 0, 0, 0, aastocks.HongKong),
+			commission: Commission{Rate: 0.0005, Minimum: 50, Fixed: 15},
+			side:       Sell,
+			price:      100,
+			quantity:   100000,
+			cost: Cost{
+				Commission:      5015,
+				StampDuty:       10000,
+				TradingFee:      565,
+				TransactionLevy: 270,
+				AFRCLevy:        15,
+				SettlementFee:   100,
+			},
+			total: 15965,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			c := NewCostModel(tC.time, tC.commission).TradeCost(tC.side, tC.price, tC.quantity)
+			diff := cmp.Diff(tC.cost, c)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.total, c.Total())
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
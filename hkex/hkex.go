@@ -0,0 +1,32 @@
+// Package hkex models trading rules of Hong Kong Exchanges (HKEX),
+// including tick size spread table, board lots and trading costs.
+//
+//	price := hkex.RoundToTick(44.63)
+//	if !hkex.ValidLotQuantity(1000, quote.Lots) {
+//		logger.Fatal("quantity is not multiple of board lots")
+//	}
+//	model := hkex.NewCostModel(time.Now(), hkex.Commission{Rate: 0.0025, Minimum: 100})
+//	cost := model.TradeCost(hkex.Buy, price, 1000)
+package hkex
+
+// Side of trade.
+type Side int
+
+const (
+	// Buy side
+	Buy Side = iota
+	// Sell side
+	Sell
+)
+
+func (s Side) String() string {
+	if s == Sell {
+		return "Sell"
+	}
+	return "Buy"
+}
+
+// ValidLotQuantity checks if quantity is positive multiple of board lot size (i.e. Quote.Lots).
+func ValidLotQuantity(quantity int, lots int) bool {
+	return quantity > 0 && lots > 0 && quantity%lots == 0
+}
@@ -0,0 +1,135 @@
+package hkex
+
+// holidayTable of HKEX with date and kind per line, where kind is either "holiday" or "half-day".
+// Market is closed on holidays, and only morning session is traded on half-days
+// (i.e. Christmas Eve, New Year's Eve and Lunar New Year's Eve).
+// Years in the table are taken as covered by the calendar.
+const holidayTable = `
+# 2020
+2020-01-01 holiday
+2020-01-24 half-day
+2020-01-27 holiday
+2020-01-28 holiday
+2020-04-10 holiday
+2020-04-13 holiday
+2020-04-30 holiday
+2020-05-01 holiday
+2020-06-25 holiday
+2020-07-01 holiday
+2020-10-01 holiday
+2020-10-02 holiday
+2020-10-26 holiday
+2020-12-24 half-day
+2020-12-25 holiday
+2020-12-31 half-day
+
+# 2021
+2021-01-01 holiday
+2021-02-11 half-day
+2021-02-12 holiday
+2021-02-15 holiday
+2021-04-02 holiday
+2021-04-05 holiday
+2021-04-06 holiday
+2021-05-19 holiday
+2021-06-14 holiday
+2021-07-01 holiday
+2021-09-22 holiday
+2021-10-01 holiday
+2021-10-14 holiday
+2021-12-24 half-day
+2021-12-27 holiday
+2021-12-31 half-day
+
+# 2022
+2022-01-31 half-day
+2022-02-01 holiday
+2022-02-02 holiday
+2022-02-03 holiday
+2022-04-05 holiday
+2022-04-15 holiday
+2022-04-18 holiday
+2022-05-02 holiday
+2022-05-09 holiday
+2022-06-03 holiday
+2022-07-01 holiday
+2022-09-12 holiday
+2022-10-04 holiday
+2022-12-26 holiday
+2022-12-27 holiday
+
+# 2023
+2023-01-02 holiday
+2023-01-23 holiday
+2023-01-24 holiday
+2023-01-25 holiday
+2023-04-05 holiday
+2023-04-07 holiday
+2023-04-10 holiday
+2023-05-01 holiday
+2023-05-26 holiday
+2023-06-22 holiday
+2023-10-02 holiday
+2023-10-23 holiday
+2023-12-25 holiday
+2023-12-26 holiday
+
+# 2024
+2024-01-01 holiday
+2024-02-09 half-day
+2024-02-12 holiday
+2024-02-13 holiday
+2024-03-29 holiday
+2024-04-01 holiday
+2024-04-04 holiday
+2024-05-01 holiday
+2024-05-15 holiday
+2024-06-10 holiday
+2024-07-01 holiday
+2024-09-18 holiday
+2024-10-01 holiday
+2024-10-11 holiday
+2024-12-24 half-day
+2024-12-25 holiday
+2024-12-26 holiday
+2024-12-31 half-day
+
+# 2025
+2025-01-01 holiday
+2025-01-28 half-day
+2025-01-29 holiday
+2025-01-30 holiday
+2025-01-31 holiday
+2025-04-04 holiday
+2025-04-18 holiday
+2025-04-21 holiday
+2025-05-01 holiday
+2025-05-05 holiday
+2025-07-01 holiday
+2025-10-01 holiday
+2025-10-07 holiday
+2025-10-29 holiday
+2025-12-24 half-day
+2025-12-25 holiday
+2025-12-26 holiday
+2025-12-31 half-day
+
+# 2026
+2026-01-01 holiday
+2026-02-16 half-day
+2026-02-17 holiday
+2026-02-18 holiday
+2026-02-19 holiday
+2026-04-03 holiday
+2026-04-06 holiday
+2026-04-07 holiday
+2026-05-01 holiday
+2026-05-25 holiday
+2026-06-19 holiday
+2026-07-01 holiday
+2026-10-01 holiday
+2026-10-19 holiday
+2026-12-24 half-day
+2026-12-25 holiday
+2026-12-31 half-day
+`
@@ -0,0 +1,49 @@
+package hkex
+
+import (
+	"math"
+)
+
+type spread struct {
+	upTo float64
+	tick float64
+}
+
+// spreadTable of HKEX for equities, tick applies to prices above the previous upTo and up to upTo.
+var spreadTable = []spread{
+	{upTo: 0.25, tick: 0.001},
+	{upTo: 0.50, tick: 0.005},
+	{upTo: 10, tick: 0.01},
+	{upTo: 20, tick: 0.02},
+	{upTo: 100, tick: 0.05},
+	{upTo: 200, tick: 0.1},
+	{upTo: 500, tick: 0.2},
+	{upTo: 1000, tick: 0.5},
+	{upTo: 2000, tick: 1},
+	{upTo: 5000, tick: 2},
+	{upTo: 9995, tick: 5},
+}
+
+// TickSize of the price from HKEX spread table.
+// Tick size of the highest band is used for prices above 9995.
+func TickSize(price float64) float64 {
+	for _, s := range spreadTable {
+		if price <= s.upTo {
+			return s.tick
+		}
+	}
+	return spreadTable[len(spreadTable)-1].tick
+}
+
+// RoundToTick rounds price to the nearest valid price of its tick size.
+func RoundToTick(price float64) float64 {
+	tick := TickSize(price)
+	p := math.Round(price/tick) * tick
+	// Smallest tick is 0.001, rounding off floating point error of multiplication
+	return math.Round(p*1000) / 1000
+}
+
+// ValidPrice checks if price is on tick of HKEX spread table.
+func ValidPrice(price float64) bool {
+	return price > 0 && RoundToTick(price) == price
+}
@@ -0,0 +1,67 @@
+package hkex
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRoundToTick(t *testing.T) {
+	testCases := []struct {
+		price   float64
+		tick    float64
+		rounded float64
+	}{
+		{price: 0.2004, tick: 0.001, rounded: 0.2},
+		{price: 0.25, tick: 0.001, rounded: 0.25},
+		{price: 0.253, tick: 0.005, rounded: 0.255},
+		{price: 7.615, tick: 0.01, rounded: 7.62},
+		{price: 10, tick: 0.01, rounded: 10},
+		{price: 10.015, tick: 0.02, rounded: 10.02},
+		{price: 44.63, tick: 0.05, rounded: 44.65},
+		{price: 150.04, tick: 0.1, rounded: 150},
+		{price: 333.3, tick: 0.2, rounded: 333.4},
+		{price: 612.2, tick: 0.5, rounded: 612},
+		{price: 1500.6, tick: 1, rounded: 1501},
+		{price: 2345, tick: 2, rounded: 2346},
+		{price: 6001, tick: 5, rounded: 6000},
+	}
+	for _, tC := range testCases {
+		t.Run(fmt.Sprint(tC.price), func(t *testing.T) {
+			diff := cmp.Diff(tC.tick, TickSize(tC.price))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.rounded, RoundToTick(tC.price))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			if !ValidPrice(tC.rounded) {
+				t.Fatalf("rounded price should be valid: %v", tC.rounded)
+			}
+		})
+	}
+}
+
+func TestValidLotQuantity(t *testing.T) {
+	testCases := []struct {
+		quantity int
+		lots     int
+		valid    bool
+	}{
+		{quantity: 1000, lots: 500, valid: true},
+		{quantity: 500, lots: 500, valid: true},
+		{quantity: 300, lots: 500, valid: false},
+		{quantity: 0, lots: 500, valid: false},
+		{quantity: 500, lots: 0, valid: false},
+	}
+	for _, tC := range testCases {
+		t.Run(fmt.Sprintf("%v/%v", tC.quantity, tC.lots), func(t *testing.T) {
+			diff := cmp.Diff(tC.valid, ValidLotQuantity(tC.quantity, tC.lots))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
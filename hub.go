@@ -0,0 +1,393 @@
+package aastocks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// HubConfig of price hub.
+type HubConfig struct {
+	// Interval between polls of each symbol.
+	Interval time.Duration
+	// Workers polling symbols concurrently, 4 workers are used if it is zero.
+	Workers int
+	// Limiter shared by workers to limit requests to AAStocks, requests are not limited if it is nil.
+	Limiter *RateLimiter
+	// Buffer of events for each subscription, 16 is used if it is zero.
+	// The oldest events are dropped when the buffer is full, so slow subscriber will not stall polling.
+	Buffer int
+}
+
+// PriceHub polls prices of symbols subscribed, on shared worker pool.
+// Each symbol is polled once regardless of the number of its subscribers,
+// and symbols are polled in round-robin, so that no symbol is starved.
+type PriceHub struct {
+	config HubConfig
+	quote  *Quote
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	wake   chan struct{}
+	jobs   chan string
+
+	mu      sync.Mutex
+	symbols map[string]*hubSymbol
+	order   []string
+	cursor  int
+	subs    map[*Subscription]struct{}
+	ended   bool
+}
+
+type hubSymbol struct {
+	quote    *Quote
+	refs     int
+	previous *Quote
+	latest   *PriceEvent
+	next     time.Time
+	inFlight bool
+	subs     map[*Subscription]struct{}
+}
+
+// NewPriceHub creates price hub, which polls until context is done or it is closed.
+// Options are applied to quotes polled by the hub (i.e. WithClient).
+func NewPriceHub(ctx context.Context, config HubConfig, opts ...Option) *PriceHub {
+	if config.Workers <= 0 {
+		config.Workers = 4
+	}
+	if config.Buffer <= 0 {
+		config.Buffer = 16
+	}
+	q := &Quote{
+		client: defaultClient(),
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+
+	hubCtx, cancel := context.WithCancel(ctx)
+	h := &PriceHub{
+		config:  config,
+		quote:   q,
+		ctx:     hubCtx,
+		cancel:  cancel,
+		wake:    make(chan struct{}, 1),
+		jobs:    make(chan string),
+		symbols: make(map[string]*hubSymbol),
+		order:   make([]string, 0),
+		subs:    make(map[*Subscription]struct{}),
+	}
+
+	h.wg.Add(config.Workers + 1)
+	go h.schedule()
+	for i := 0; i < config.Workers; i++ {
+		go h.work()
+	}
+	go func() {
+		<-hubCtx.Done()
+		h.wg.Wait()
+		h.end()
+	}()
+	return h
+}
+
+// Close the hub and all of its subscriptions, and waits until its goroutines exit.
+func (h *PriceHub) Close() {
+	h.cancel()
+	h.wg.Wait()
+	h.end()
+}
+
+func (h *PriceHub) end() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.ended {
+		return
+	}
+	h.ended = true
+	for s := range h.subs {
+		s.close()
+	}
+}
+
+// Subscribe to prices of symbols, latest events of symbols already polled are sent immediately.
+func (h *PriceHub) Subscribe(symbols ...string) *Subscription {
+	s := &Subscription{
+		hub:     h,
+		events:  make(chan PriceEvent, h.config.Buffer),
+		symbols: make(map[string]bool),
+	}
+	h.mu.Lock()
+	if h.ended {
+		s.close()
+	} else {
+		h.subs[s] = struct{}{}
+	}
+	h.mu.Unlock()
+	s.Subscribe(symbols...)
+	return s
+}
+
+// Symbols polled by the hub currently.
+func (h *PriceHub) Symbols() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	symbols := make([]string, len(h.order))
+	copy(symbols, h.order)
+	return symbols
+}
+
+// Latest event of the symbol polled by the hub, events with error are not kept.
+func (h *PriceHub) Latest(symbol string) (PriceEvent, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.symbols[symbol]
+	if !ok || s.latest == nil {
+		return PriceEvent{}, false
+	}
+	return *s.latest, true
+}
+
+func (h *PriceHub) add(sub *Subscription, symbol string) {
+	s, ok := h.symbols[symbol]
+	if !ok {
+		s = &hubSymbol{
+			quote: &Quote{
+				Symbol: symbol,
+				client: h.quote.client,
+			},
+			subs: make(map[*Subscription]struct{}),
+		}
+		h.symbols[symbol] = s
+		h.order = append(h.order, symbol)
+		h.wakeup()
+	}
+	s.refs++
+	s.subs[sub] = struct{}{}
+	if s.latest != nil {
+		sub.send(*s.latest)
+	}
+}
+
+func (h *PriceHub) remove(sub *Subscription, symbol string) {
+	s, ok := h.symbols[symbol]
+	if !ok {
+		return
+	}
+	delete(s.subs, sub)
+	s.refs--
+	if s.refs > 0 {
+		return
+	}
+	delete(h.symbols, symbol)
+	for i, o := range h.order {
+		if o == symbol {
+			h.order = append(h.order[:i], h.order[i+1:]...)
+			if h.cursor > i {
+				h.cursor--
+			}
+			break
+		}
+	}
+}
+
+func (h *PriceHub) wakeup() {
+	select {
+	case h.wake <- struct{}{}:
+	default:
+	}
+}
+
+// nextDue returns symbol due to be polled in round-robin, otherwise duration until the next symbol is due.
+// Negative duration is returned if there is no symbol to be polled.
+func (h *PriceHub) nextDue(now time.Time) (string, time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	wait := time.Duration(-1)
+	n := len(h.order)
+	for i := 0; i < n; i++ {
+		idx := (h.cursor + i) % n
+		symbol := h.order[idx]
+		s := h.symbols[symbol]
+		if s.inFlight {
+			continue
+		}
+		if !s.next.After(now) {
+			s.inFlight = true
+			h.cursor = (idx + 1) % n
+			return symbol, 0
+		}
+		d := s.next.Sub(now)
+		if wait < 0 || d < wait {
+			wait = d
+		}
+	}
+	return "", wait
+}
+
+func (h *PriceHub) schedule() {
+	defer h.wg.Done()
+	defer close(h.jobs)
+	for {
+		symbol, wait := h.nextDue(time.Now())
+		if symbol == "" {
+			var timeout <-chan time.Time
+			if wait >= 0 {
+				timeout = time.After(wait)
+			}
+			select {
+			case <-h.ctx.Done():
+				return
+			case <-h.wake:
+			case <-timeout:
+			}
+			continue
+		}
+		if h.config.Limiter != nil {
+			err := h.config.Limiter.Wait(h.ctx)
+			if err != nil {
+				return
+			}
+		}
+		select {
+		case <-h.ctx.Done():
+			return
+		case h.jobs <- symbol:
+		}
+	}
+}
+
+func (h *PriceHub) work() {
+	defer h.wg.Done()
+	for symbol := range h.jobs {
+		h.mu.Lock()
+		s, ok := h.symbols[symbol]
+		h.mu.Unlock()
+		if !ok {
+			// Unsubscribed before polling
+			continue
+		}
+		latest := s.quote.clone()
+		err := latest.details(h.ctx)
+		if h.ctx.Err() != nil {
+			// Hub is ended while polling
+			return
+		}
+		h.publish(symbol, latest, err)
+		h.wakeup()
+	}
+}
+
+func (h *PriceHub) publish(symbol string, latest *Quote, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.symbols[symbol]
+	if !ok {
+		// Unsubscribed while polling
+		return
+	}
+	s.inFlight = false
+	s.next = time.Now().Add(h.config.Interval)
+
+	event := PriceEvent{Price: PriceResult{Symbol: symbol}, Err: err}
+	if err == nil {
+		snapshot := *latest
+		event = PriceEvent{
+			Price: PriceResult{
+				Price:  latest.Price,
+				Symbol: latest.Symbol,
+				Time:   latest.UpdateTime,
+			},
+			Quote:   &snapshot,
+			Changes: DiffQuotes(s.previous, latest),
+		}
+		s.previous = latest
+		s.latest = &event
+	}
+	for sub := range s.subs {
+		sub.send(event)
+	}
+}
+
+// Subscription to prices of symbols from price hub.
+// Its channel is closed when it is closed, or the hub is ended.
+type Subscription struct {
+	hub     *PriceHub
+	events  chan PriceEvent
+	symbols map[string]bool
+	closed  bool
+}
+
+// C returns channel of price events for symbols subscribed.
+func (s *Subscription) C() <-chan PriceEvent {
+	return s.events
+}
+
+// Subscribe to more symbols.
+func (s *Subscription) Subscribe(symbols ...string) {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	if s.closed {
+		return
+	}
+	for _, symbol := range symbols {
+		if s.symbols[symbol] {
+			continue
+		}
+		s.symbols[symbol] = true
+		s.hub.add(s, symbol)
+	}
+}
+
+// Unsubscribe from symbols, symbols are no longer polled once they have no subscribers.
+func (s *Subscription) Unsubscribe(symbols ...string) {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	for _, symbol := range symbols {
+		if !s.symbols[symbol] {
+			continue
+		}
+		delete(s.symbols, symbol)
+		s.hub.remove(s, symbol)
+	}
+}
+
+// Close the subscription, and unsubscribe from all of its symbols.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	for symbol := range s.symbols {
+		s.hub.remove(s, symbol)
+	}
+	s.symbols = make(map[string]bool)
+	delete(s.hub.subs, s)
+	s.close()
+}
+
+// close channel of the subscription, hub lock should be held.
+func (s *Subscription) close() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.events)
+}
+
+// send event without blocking, the oldest event is dropped if buffer is full.
+// Hub lock should be held.
+func (s *Subscription) send(event PriceEvent) {
+	if s.closed {
+		return
+	}
+	for {
+		select {
+		case s.events <- event:
+			return
+		default:
+		}
+		select {
+		case <-s.events:
+		default:
+		}
+	}
+}
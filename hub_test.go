@@ -0,0 +1,171 @@
+package aastocks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// countRequests counts requests served by the handler.
+type countRequests struct {
+	mu    sync.Mutex
+	count int
+}
+
+func (c *countRequests) serve(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock()
+		c.count++
+		c.mu.Unlock()
+		handler(w, r)
+	}
+}
+
+func (c *countRequests) get() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.count
+}
+
+func receive(t *testing.T, s *Subscription) PriceEvent {
+	select {
+	case e, ok := <-s.C():
+		if !ok {
+			t.Fatalf("Subscription is closed unexpectedly")
+		}
+		return e
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Timeout is triggered, expect event to be received")
+	}
+	return PriceEvent{}
+}
+
+func TestPriceHubSharedPolling(t *testing.T) {
+	counter := &countRequests{}
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": counter.serve(serveFile("testdata/detail_quote.html")),
+	})
+
+	hub := NewPriceHub(context.Background(), HubConfig{Interval: time.Hour}, WithClient(mock.client))
+	defer hub.Close()
+
+	s1 := hub.Subscribe("00006")
+	e := receive(t, s1)
+	diff := cmp.Diff(PriceResult{
+		Symbol: "00006",
+		Price:  44.65,
+		Time:   time.Date(2020, time.August, 25, 21, 18, 38, 0, time.UTC),
+	}, e.Price)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// Latest event is replayed to new subscriber without polling again
+	s2 := hub.Subscribe("00006")
+	e = receive(t, s2)
+	diff = cmp.Diff(44.65, e.Price.Price)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff(1, counter.get())
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	s1.Unsubscribe("00006")
+	diff = cmp.Diff([]string{"00006"}, hub.Symbols())
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	s2.Close()
+	diff = cmp.Diff([]string{}, hub.Symbols())
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	if _, ok := <-s2.C(); ok {
+		t.Fatalf("Subscription channel should be closed")
+	}
+}
+
+func TestPriceHubRoundRobin(t *testing.T) {
+	counters := map[string]*countRequests{
+		"00006": {},
+		"09923": {},
+		"3033":  {},
+	}
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": counters["00006"].serve(serveFile("testdata/detail_quote.html")),
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=09923": counters["09923"].serve(serveFile("testdata/detail_quote_new.html")),
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=3033":  counters["3033"].serve(serveFile("testdata/detail_quote_3033.html")),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	hub := NewPriceHub(ctx, HubConfig{
+		Interval: 0,
+		Workers:  1,
+		Limiter:  NewRateLimiter(10*time.Millisecond, 1),
+		Buffer:   100,
+	}, WithClient(mock.client))
+
+	s := hub.Subscribe("00006", "09923", "3033")
+	received := make(map[string]int)
+	for i := 0; i < 9; i++ {
+		e := receive(t, s)
+		if e.Err != nil {
+			t.Fatal(e.Err)
+		}
+		received[e.Price.Symbol]++
+	}
+	diff := cmp.Diff(map[string]int{"00006": 3, "09923": 3, "3033": 3}, received)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// Subscription is closed once hub is ended by context
+	cancel()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-s.C():
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("Timeout is triggered, expect subscription to be closed")
+		}
+	}
+}
+
+func TestPriceHubError(t *testing.T) {
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=151511": serveFile("testdata/detail_quote_not_found.html"),
+	})
+
+	hub := NewPriceHub(context.Background(), HubConfig{Interval: time.Hour}, WithClient(mock.client))
+	defer hub.Close()
+
+	s := hub.Subscribe("151511")
+	e := receive(t, s)
+	diff := cmp.Diff(PriceEvent{
+		Price: PriceResult{Symbol: "151511"},
+		Err:   fmt.Errorf("%w: 151511", ErrSymbolNotFound),
+	}, e, equateErrorMessage)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// Events with error are not kept as latest
+	_, ok := hub.Latest("151511")
+	diff = cmp.Diff(false, ok)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,52 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// ATR is average true range with Wilder's smoothing.
+type ATR struct {
+	prevClose float64
+	started   bool
+	average   *EMA
+}
+
+// NewATR creates average true range over period.
+func NewATR(period int) *ATR {
+	return &ATR{average: newWilder(period)}
+}
+
+// Update with price bar, and returns the latest average true range.
+// True range of the first bar is its high minus low.
+func (a *ATR) Update(p aastocks.HistoricalPrice) float64 {
+	tr := p.High - p.Low
+	if a.started {
+		tr = math.Max(tr, math.Abs(p.High-a.prevClose))
+		tr = math.Max(tr, math.Abs(p.Low-a.prevClose))
+	}
+	a.prevClose = p.Close
+	a.started = true
+	return a.average.Update(tr)
+}
+
+// Ready when the period of bars are updated.
+func (a *ATR) Ready() bool {
+	return a.average.Ready()
+}
+
+// Value of the latest average true range.
+func (a *ATR) Value() float64 {
+	return a.average.Value()
+}
+
+// ATRSeries computes average true range of historical prices.
+func ATRSeries(prices []aastocks.HistoricalPrice, period int) []float64 {
+	a := NewATR(period)
+	result := make([]float64, len(prices))
+	for i, p := range prices {
+		result[i] = a.Update(p)
+	}
+	return result
+}
@@ -0,0 +1,15 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestATRSeries(t *testing.T) {
+	expected := []float64{nan, nan, 1.6666666666666667, 1.4777777777777779, 1.2851851851851854, 1.2567901234567906, 1.1711934156378605, 1.014128943758574}
+	diff := cmp.Diff(expected, ATRSeries(testPrices(), 3), equateFloat)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
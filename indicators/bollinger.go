@@ -0,0 +1,75 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// BollingerValue is value of Bollinger Bands.
+type BollingerValue struct {
+	Upper  float64
+	Middle float64
+	Lower  float64
+}
+
+// Bollinger is Bollinger Bands with simple moving average and population standard deviation.
+type Bollinger struct {
+	window *ring
+	k      float64
+}
+
+// NewBollinger creates Bollinger Bands over period, with bands k standard deviations away from the average.
+// AAStocks displays Bollinger Bands with period of 20 and k of 2.
+func NewBollinger(period int, k float64) *Bollinger {
+	checkPeriod(period)
+	return &Bollinger{
+		window: newRing(period),
+		k:      k,
+	}
+}
+
+// Update with close price, and returns the latest bands.
+func (b *Bollinger) Update(v float64) BollingerValue {
+	b.window.push(v)
+	return b.Value()
+}
+
+// Ready when the period of prices are updated.
+func (b *Bollinger) Ready() bool {
+	return b.window.full()
+}
+
+// Value of the latest bands.
+func (b *Bollinger) Value() BollingerValue {
+	if !b.Ready() {
+		nan := math.NaN()
+		return BollingerValue{Upper: nan, Middle: nan, Lower: nan}
+	}
+	n := float64(len(b.window.values))
+	var sum float64
+	for _, v := range b.window.values {
+		sum += v
+	}
+	mean := sum / n
+	var variance float64
+	for _, v := range b.window.values {
+		variance += (v - mean) * (v - mean)
+	}
+	sd := math.Sqrt(variance / n)
+	return BollingerValue{
+		Upper:  mean + b.k*sd,
+		Middle: mean,
+		Lower:  mean - b.k*sd,
+	}
+}
+
+// BollingerSeries computes Bollinger Bands of close prices.
+func BollingerSeries(prices []aastocks.HistoricalPrice, period int, k float64) []BollingerValue {
+	b := NewBollinger(period, k)
+	result := make([]BollingerValue, len(prices))
+	for i, p := range prices {
+		result[i] = b.Update(p.Close)
+	}
+	return result
+}
@@ -0,0 +1,24 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBollingerSeries(t *testing.T) {
+	expected := []BollingerValue{
+		{Upper: nan, Middle: nan, Lower: nan},
+		{Upper: nan, Middle: nan, Lower: nan},
+		{Upper: 11.816496580927726, Middle: 11, Lower: 10.183503419072274},
+		{Upper: 11.970825226947268, Middle: 10.9, Lower: 9.829174773052733},
+		{Upper: 11.346535935145704, Middle: 10.666666666666666, Lower: 9.986797398187628},
+		{Upper: 12.132882800593796, Middle: 10.9, Lower: 9.667117199406205},
+		{Upper: 12.725209813132185, Middle: 11.566666666666668, Lower: 10.408123520201151},
+		{Upper: 12.332049379893856, Middle: 11.9, Lower: 11.467950620106144},
+	}
+	diff := cmp.Diff(expected, BollingerSeries(testPrices(), 3, 2), equateFloat)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,85 @@
+// Package indicators computes technical indicators over historical prices of AAStocks.
+//
+// Each indicator is provided in streaming form, which is updated incrementally one bar at a time,
+// and batch form, which computes the whole series from historical prices.
+// Values are NaN until enough bars are updated for the indicator to be ready.
+//
+// Parameters follow the conventions displayed by AAStocks,
+// so that results can be validated against values on its detail quote page.
+//
+//	prices, err := quote.HistoricalPrices(aastocks.Daily)
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	rsi := indicators.RSISeries(prices, indicators.RSI14)
+package indicators
+
+import (
+	"math"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Periods of RSI displayed by AAStocks.
+const (
+	RSI10 = 10
+	RSI14 = 14
+	RSI20 = 20
+)
+
+// Closes returns close prices of historical prices.
+func Closes(prices []aastocks.HistoricalPrice) []float64 {
+	closes := make([]float64, len(prices))
+	for i, p := range prices {
+		closes[i] = p.Close
+	}
+	return closes
+}
+
+func checkPeriod(period int) {
+	if period <= 0 {
+		panic("indicators: non-positive period")
+	}
+}
+
+// ring is fixed size window of latest values.
+type ring struct {
+	values []float64
+	idx    int
+	count  int
+}
+
+func newRing(size int) *ring {
+	return &ring{values: make([]float64, size)}
+}
+
+// push value into the window, and returns value evicted from the window if it is full.
+func (r *ring) push(v float64) (float64, bool) {
+	evicted, full := r.values[r.idx], r.full()
+	r.values[r.idx] = v
+	r.idx = (r.idx + 1) % len(r.values)
+	if !full {
+		r.count++
+	}
+	return evicted, full
+}
+
+func (r *ring) full() bool {
+	return r.count == len(r.values)
+}
+
+func (r *ring) max() float64 {
+	m := math.Inf(-1)
+	for _, v := range r.values[:r.count] {
+		m = math.Max(m, v)
+	}
+	return m
+}
+
+func (r *ring) min() float64 {
+	m := math.Inf(1)
+	for _, v := range r.values[:r.count] {
+		m = math.Min(m, v)
+	}
+	return m
+}
@@ -0,0 +1,151 @@
+package indicators
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/horacehylee/aastocks"
+)
+
+var nan = math.NaN()
+
+var equateFloat = cmp.Options{
+	cmpopts.EquateApprox(0, 1e-9),
+	cmpopts.EquateNaNs(),
+}
+
+func testPrices() []aastocks.HistoricalPrice {
+	bars := [][5]float64{
+		{10, 11, 9, 10.5, 100},
+		{10.5, 12, 10, 11.5, 120},
+		{11.5, 11.8, 10.8, 11, 90},
+		{11, 11.2, 10.1, 10.2, 150},
+		{10.2, 10.9, 10, 10.8, 80},
+		{10.8, 11.9, 10.7, 11.7, 130},
+		{11.7, 12.5, 11.5, 12.2, 160},
+		{12.2, 12.3, 11.6, 11.8, 110},
+	}
+	prices := make([]aastocks.HistoricalPrice, len(bars))
+	for i, b := range bars {
+		prices[i] = aastocks.HistoricalPrice{
+			Time:   time.Date(2020, time.August, 3+i, 0, 0, 0, 0, time.UTC),
+			Open:   b[0],
+			High:   b[1],
+			Low:    b[2],
+			Close:  b[3],
+			Volume: b[4],
+		}
+	}
+	return prices
+}
+
+func TestCloses(t *testing.T) {
+	expected := []float64{10.5, 11.5, 11, 10.2, 10.8, 11.7, 12.2, 11.8}
+	diff := cmp.Diff(expected, Closes(testPrices()))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestNonPositivePeriod(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("panic is expected for non-positive period")
+		}
+	}()
+	NewSMA(0)
+}
+
+// mockTransport serves files of urls.
+type mockTransport map[string]string
+
+func (m mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, ok := m[req.URL.String()]
+	if !ok {
+		return nil, fmt.Errorf("Handler not found for %s", req.URL)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	recorder.Write(b)
+	return recorder.Result(), nil
+}
+
+// TestDetailQuote checks indicators against values displayed on detail quote page of testdata,
+// with daily prices until the day of the page.
+// macdSeries of MACD line with the params, as series of period.
+func macdSeries(params MACDParams) func(prices []aastocks.HistoricalPrice, period int) []float64 {
+	return func(prices []aastocks.HistoricalPrice, period int) []float64 {
+		values := MACDSeries(prices, params)
+		result := make([]float64, len(values))
+		for i, v := range values {
+			result[i] = v.MACD
+		}
+		return result
+	}
+}
+
+func TestDetailQuote(t *testing.T) {
+	q, err := aastocks.Get("00006", aastocks.WithClient(&http.Client{
+		Transport: mockTransport{
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006":                                                             "../testdata/detail_quote.html",
+			"http://chartdata1.internet.aastocks.com/servlet/iDataServlet/getdaily?id=00006.HK&type=24&market=1&level=1&period=56&encoding=utf8": "../testdata/historical_price_00006_daily.html",
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := q.HistoricalPrices(aastocks.Daily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Time.Before(all[j].Time)
+	})
+	prices := make([]aastocks.HistoricalPrice, 0, len(all))
+	for _, p := range all {
+		if p.Time.Before(q.UpdateTime) {
+			prices = append(prices, p)
+		}
+	}
+
+	testCases := []struct {
+		desc      string
+		series    func(prices []aastocks.HistoricalPrice, period int) []float64
+		period    int
+		expected  float64
+		tolerance float64
+	}{
+		// SMA are displayed with 3 decimals
+		{desc: "SMA10", series: SMASeries, period: 10, expected: 44.170, tolerance: 0.0005},
+		{desc: "SMA50", series: SMASeries, period: 50, expected: 43.363, tolerance: 0.0005},
+		{desc: "SMA100", series: SMASeries, period: 100, expected: 45.736, tolerance: 0.0005},
+		{desc: "SMA250", series: SMASeries, period: 250, expected: 51.096, tolerance: 0.0005},
+		// RSI depends on the first bar smoothed, which is earlier than testdata on AAStocks
+		{desc: "RSI10", series: RSISeries, period: RSI10, expected: 63.663, tolerance: 0.02},
+		{desc: "RSI14", series: RSISeries, period: RSI14, expected: 60.561, tolerance: 0.02},
+		{desc: "RSI20", series: RSISeries, period: RSI20, expected: 56.323, tolerance: 0.02},
+		// MACD are displayed with 4 decimals, but also depends on the first bar smoothed
+		{desc: "MACD8x17", series: macdSeries(MACD8x17), expected: 0.2990, tolerance: 0.0005},
+		{desc: "MACD12x25", series: macdSeries(MACD12x25), expected: 0.2720, tolerance: 0.0005},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			values := tC.series(prices, tC.period)
+			diff := cmp.Diff(tC.expected, values[len(values)-1], cmpopts.EquateApprox(0, tC.tolerance))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
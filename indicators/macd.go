@@ -0,0 +1,84 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// MACDParams are periods of MACD.
+type MACDParams struct {
+	Fast   int
+	Slow   int
+	Signal int
+}
+
+// Parameters of MACD displayed by AAStocks.
+var (
+	MACD8x17  = MACDParams{Fast: 8, Slow: 17, Signal: 9}
+	MACD12x25 = MACDParams{Fast: 12, Slow: 25, Signal: 9}
+)
+
+// MACDValue is value of MACD.
+type MACDValue struct {
+	MACD      float64
+	Signal    float64
+	Histogram float64
+}
+
+// MACD is moving average convergence divergence.
+type MACD struct {
+	fast   *EMA
+	slow   *EMA
+	signal *EMA
+	value  MACDValue
+}
+
+// NewMACD creates moving average convergence divergence with periods, such as MACD12x25.
+func NewMACD(params MACDParams) *MACD {
+	return &MACD{
+		fast:   NewEMA(params.Fast),
+		slow:   NewEMA(params.Slow),
+		signal: NewEMA(params.Signal),
+		value:  MACDValue{MACD: math.NaN(), Signal: math.NaN(), Histogram: math.NaN()},
+	}
+}
+
+// Update with close price, and returns the latest value.
+// MACD is available once slow period of prices are updated,
+// while signal and histogram are available once signal period of MACD are computed further.
+func (m *MACD) Update(v float64) MACDValue {
+	fast := m.fast.Update(v)
+	slow := m.slow.Update(v)
+	if math.IsNaN(fast) || math.IsNaN(slow) {
+		return m.value
+	}
+	macd := fast - slow
+	signal := m.signal.Update(macd)
+	m.value = MACDValue{
+		MACD:      macd,
+		Signal:    signal,
+		Histogram: macd - signal,
+	}
+	return m.value
+}
+
+// Ready when signal is available.
+func (m *MACD) Ready() bool {
+	return m.signal.Ready()
+}
+
+// Value of the latest MACD.
+func (m *MACD) Value() MACDValue {
+	return m.value
+}
+
+// MACDSeries computes moving average convergence divergence of close prices.
+func MACDSeries(prices []aastocks.HistoricalPrice, params MACDParams) []MACDValue {
+	m := NewMACD(params)
+	result := make([]MACDValue, len(prices))
+	for i, p := range prices {
+		result[i] = m.Update(p.Close)
+	}
+	return result
+}
@@ -0,0 +1,24 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMACDSeries(t *testing.T) {
+	expected := []MACDValue{
+		{MACD: nan, Signal: nan, Histogram: nan},
+		{MACD: nan, Signal: nan, Histogram: nan},
+		{MACD: nan, Signal: nan, Histogram: nan},
+		{MACD: nan, Signal: nan, Histogram: nan},
+		{MACD: -0.1, Signal: nan, Histogram: nan},
+		{MACD: 0.1, Signal: 0, Histogram: 0.1},
+		{MACD: 0.23333333333333073, Signal: 0.15555555555555323, Histogram: 0.0777777777777775},
+		{MACD: 0.17222222222222072, Signal: 0.1666666666666649, Histogram: 0.0055555555555558},
+	}
+	diff := cmp.Diff(expected, MACDSeries(testPrices(), MACDParams{Fast: 3, Slow: 5, Signal: 2}), equateFloat)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,116 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// SMA is simple moving average.
+type SMA struct {
+	window *ring
+	sum    float64
+}
+
+// NewSMA creates simple moving average over period.
+func NewSMA(period int) *SMA {
+	checkPeriod(period)
+	return &SMA{window: newRing(period)}
+}
+
+// Update with value, and returns the latest average.
+func (s *SMA) Update(v float64) float64 {
+	evicted, full := s.window.push(v)
+	if full {
+		s.sum -= evicted
+	}
+	s.sum += v
+	return s.Value()
+}
+
+// Ready when the period of values are updated.
+func (s *SMA) Ready() bool {
+	return s.window.full()
+}
+
+// Value of the latest average.
+func (s *SMA) Value() float64 {
+	if !s.Ready() {
+		return math.NaN()
+	}
+	return s.sum / float64(len(s.window.values))
+}
+
+// EMA is exponential moving average.
+// It is seeded with simple moving average of the first period of values.
+type EMA struct {
+	period int
+	alpha  float64
+	count  int
+	value  float64
+}
+
+// NewEMA creates exponential moving average over period, with smoothing factor of 2/(period+1).
+func NewEMA(period int) *EMA {
+	checkPeriod(period)
+	return &EMA{
+		period: period,
+		alpha:  2 / float64(period+1),
+	}
+}
+
+// newWilder creates Wilder's moving average over period, with smoothing factor of 1/period.
+func newWilder(period int) *EMA {
+	checkPeriod(period)
+	return &EMA{
+		period: period,
+		alpha:  1 / float64(period),
+	}
+}
+
+// Update with value, and returns the latest average.
+func (e *EMA) Update(v float64) float64 {
+	e.count++
+	switch {
+	case e.count < e.period:
+		e.value += v
+	case e.count == e.period:
+		e.value = (e.value + v) / float64(e.period)
+	default:
+		e.value = e.alpha*v + (1-e.alpha)*e.value
+	}
+	return e.Value()
+}
+
+// Ready when the period of values are updated.
+func (e *EMA) Ready() bool {
+	return e.count >= e.period
+}
+
+// Value of the latest average.
+func (e *EMA) Value() float64 {
+	if !e.Ready() {
+		return math.NaN()
+	}
+	return e.value
+}
+
+// SMASeries computes simple moving average of close prices.
+func SMASeries(prices []aastocks.HistoricalPrice, period int) []float64 {
+	s := NewSMA(period)
+	result := make([]float64, len(prices))
+	for i, p := range prices {
+		result[i] = s.Update(p.Close)
+	}
+	return result
+}
+
+// EMASeries computes exponential moving average of close prices.
+func EMASeries(prices []aastocks.HistoricalPrice, period int) []float64 {
+	e := NewEMA(period)
+	result := make([]float64, len(prices))
+	for i, p := range prices {
+		result[i] = e.Update(p.Close)
+	}
+	return result
+}
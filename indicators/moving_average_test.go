@@ -0,0 +1,53 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMovingAverage(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		series   func() []float64
+		expected []float64
+	}{
+		{
+			desc:     "SMA",
+			series:   func() []float64 { return SMASeries(testPrices(), 3) },
+			expected: []float64{nan, nan, 11, 10.9, 10.666666666666666, 10.9, 11.566666666666668, 11.9},
+		},
+		{
+			desc:     "EMA",
+			series:   func() []float64 { return EMASeries(testPrices(), 3) },
+			expected: []float64{nan, nan, 11, 10.6, 10.7, 11.2, 11.7, 11.75},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			diff := cmp.Diff(tC.expected, tC.series(), equateFloat)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestStreamingMatchesSeries(t *testing.T) {
+	prices := testPrices()
+	sma := NewSMA(3)
+	ema := NewEMA(3)
+	for _, p := range prices {
+		sma.Update(p.Close)
+		ema.Update(p.Close)
+	}
+	if !sma.Ready() || !ema.Ready() {
+		t.Fatalf("moving averages should be ready")
+	}
+	smaSeries := SMASeries(prices, 3)
+	emaSeries := EMASeries(prices, 3)
+	diff := cmp.Diff([]float64{smaSeries[len(prices)-1], emaSeries[len(prices)-1]}, []float64{sma.Value(), ema.Value()}, equateFloat)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
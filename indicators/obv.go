@@ -0,0 +1,48 @@
+package indicators
+
+import (
+	"github.com/horacehylee/aastocks"
+)
+
+// OBV is on-balance volume.
+type OBV struct {
+	prevClose float64
+	started   bool
+	value     float64
+}
+
+// NewOBV creates on-balance volume.
+func NewOBV() *OBV {
+	return &OBV{}
+}
+
+// Update with price bar, and returns the latest on-balance volume.
+// On-balance volume starts from zero at the first bar.
+func (o *OBV) Update(p aastocks.HistoricalPrice) float64 {
+	if o.started {
+		switch {
+		case p.Close > o.prevClose:
+			o.value += p.Volume
+		case p.Close < o.prevClose:
+			o.value -= p.Volume
+		}
+	}
+	o.prevClose = p.Close
+	o.started = true
+	return o.value
+}
+
+// Value of the latest on-balance volume.
+func (o *OBV) Value() float64 {
+	return o.value
+}
+
+// OBVSeries computes on-balance volume of historical prices.
+func OBVSeries(prices []aastocks.HistoricalPrice) []float64 {
+	o := NewOBV()
+	result := make([]float64, len(prices))
+	for i, p := range prices {
+		result[i] = o.Update(p)
+	}
+	return result
+}
@@ -0,0 +1,15 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOBVSeries(t *testing.T) {
+	expected := []float64{0, 120, 30, -120, -40, 90, 250, 140}
+	diff := cmp.Diff(expected, OBVSeries(testPrices()), equateFloat)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
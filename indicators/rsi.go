@@ -0,0 +1,67 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// RSI is relative strength index with Wilder's smoothing.
+type RSI struct {
+	prev    float64
+	started bool
+	gain    *EMA
+	loss    *EMA
+}
+
+// NewRSI creates relative strength index over period, such as RSI14.
+func NewRSI(period int) *RSI {
+	return &RSI{
+		gain: newWilder(period),
+		loss: newWilder(period),
+	}
+}
+
+// Update with close price, and returns the latest index.
+func (r *RSI) Update(v float64) float64 {
+	if !r.started {
+		r.prev = v
+		r.started = true
+		return math.NaN()
+	}
+	change := v - r.prev
+	r.prev = v
+	r.gain.Update(math.Max(change, 0))
+	r.loss.Update(math.Max(-change, 0))
+	return r.Value()
+}
+
+// Ready when the period of price changes are updated.
+func (r *RSI) Ready() bool {
+	return r.gain.Ready()
+}
+
+// Value of the latest index, ranged from 0 to 100.
+func (r *RSI) Value() float64 {
+	if !r.Ready() {
+		return math.NaN()
+	}
+	gain, loss := r.gain.Value(), r.loss.Value()
+	if loss == 0 {
+		if gain == 0 {
+			return 50
+		}
+		return 100
+	}
+	return 100 - 100/(1+gain/loss)
+}
+
+// RSISeries computes relative strength index of close prices.
+func RSISeries(prices []aastocks.HistoricalPrice, period int) []float64 {
+	r := NewRSI(period)
+	result := make([]float64, len(prices))
+	for i, p := range prices {
+		result[i] = r.Update(p.Close)
+	}
+	return result
+}
@@ -0,0 +1,44 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+func TestRSISeries(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		prices   []aastocks.HistoricalPrice
+		expected []float64
+	}{
+		{
+			desc:     "WithChanges",
+			prices:   testPrices(),
+			expected: []float64{nan, nan, nan, 43.478260869565204, 59.375000000000014, 75.11961722488036, 81.19349005424954, 62.797202797202836},
+		},
+		{
+			desc: "WithoutChanges",
+			prices: []aastocks.HistoricalPrice{
+				{Close: 10}, {Close: 10}, {Close: 10}, {Close: 10},
+			},
+			expected: []float64{nan, nan, nan, 50},
+		},
+		{
+			desc: "WithGainsOnly",
+			prices: []aastocks.HistoricalPrice{
+				{Close: 10}, {Close: 11}, {Close: 12}, {Close: 13},
+			},
+			expected: []float64{nan, nan, nan, 100},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			diff := cmp.Diff(tC.expected, RSISeries(tC.prices, 3), equateFloat)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// StochasticValue is value of stochastic oscillator.
+type StochasticValue struct {
+	K float64
+	D float64
+}
+
+// Stochastic is stochastic oscillator.
+type Stochastic struct {
+	highs *ring
+	lows  *ring
+	d     *SMA
+	value StochasticValue
+}
+
+// NewStochastic creates stochastic oscillator with %K over kPeriod, and %D as simple moving average of %K over dPeriod.
+// AAStocks displays stochastic oscillator with kPeriod of 14 and dPeriod of 3.
+func NewStochastic(kPeriod int, dPeriod int) *Stochastic {
+	checkPeriod(kPeriod)
+	return &Stochastic{
+		highs: newRing(kPeriod),
+		lows:  newRing(kPeriod),
+		d:     NewSMA(dPeriod),
+		value: StochasticValue{K: math.NaN(), D: math.NaN()},
+	}
+}
+
+// Update with price bar, and returns the latest value.
+// %K is 50 if highest high and lowest low of the period are the same.
+func (s *Stochastic) Update(p aastocks.HistoricalPrice) StochasticValue {
+	s.highs.push(p.High)
+	s.lows.push(p.Low)
+	if !s.highs.full() {
+		return s.value
+	}
+	high, low := s.highs.max(), s.lows.min()
+	k := float64(50)
+	if high != low {
+		k = 100 * (p.Close - low) / (high - low)
+	}
+	s.value = StochasticValue{
+		K: k,
+		D: s.d.Update(k),
+	}
+	return s.value
+}
+
+// Ready when %D is available.
+func (s *Stochastic) Ready() bool {
+	return s.d.Ready()
+}
+
+// Value of the latest stochastic oscillator.
+func (s *Stochastic) Value() StochasticValue {
+	return s.value
+}
+
+// StochasticSeries computes stochastic oscillator of historical prices.
+func StochasticSeries(prices []aastocks.HistoricalPrice, kPeriod int, dPeriod int) []StochasticValue {
+	s := NewStochastic(kPeriod, dPeriod)
+	result := make([]StochasticValue, len(prices))
+	for i, p := range prices {
+		result[i] = s.Update(p)
+	}
+	return result
+}
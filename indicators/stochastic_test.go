@@ -0,0 +1,24 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStochasticSeries(t *testing.T) {
+	expected := []StochasticValue{
+		{K: nan, D: nan},
+		{K: nan, D: nan},
+		{K: 66.66666666666667, D: nan},
+		{K: 9.999999999999964, D: 38.333333333333314},
+		{K: 44.444444444444464, D: 27.222222222222214},
+		{K: 89.47368421052627, D: 66.95906432748536},
+		{K: 87.99999999999997, D: 88.73684210526312},
+		{K: 61.111111111111164, D: 74.55555555555557},
+	}
+	diff := cmp.Diff(expected, StochasticSeries(testPrices(), 3, 2), equateFloat)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
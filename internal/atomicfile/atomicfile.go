@@ -0,0 +1,44 @@
+// Package atomicfile writes files atomically, so that partially written files will not be read.
+package atomicfile
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Write file of the path with write function, which writes to temporary file in the same directory
+// and replaces the file by renaming it. Directory of the path is created if it does not exist.
+// Temporary file is removed if write function fails.
+func Write(path string, write func(w io.Writer) error) error {
+	dir := filepath.Dir(path)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(dir, filepath.Base(path)+"_*.tmp")
+	if err != nil {
+		return err
+	}
+	err = write(f)
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// WriteFile writes data to file of the path atomically.
+func WriteFile(path string, data []byte) error {
+	return Write(path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
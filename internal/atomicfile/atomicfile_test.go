@@ -0,0 +1,93 @@
+package atomicfile
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "atomicfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+// files under the directory, recursively.
+func files(t *testing.T, dir string) []string {
+	names := make([]string, 0)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		names = append(names, rel)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return names
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := testDir(t)
+	path := filepath.Join(dir, "a", "b.json")
+	err := WriteFile(path, []byte("first"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = WriteFile(path, []byte("second"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff("second", string(b))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff([]string{filepath.Join("a", "b.json")}, files(t, dir))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	dir := testDir(t)
+	path := filepath.Join(dir, "b.json")
+	err := WriteFile(path, []byte("first"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Write(path, func(w io.Writer) error {
+		w.Write([]byte("partial"))
+		return fmt.Errorf("testing error")
+	})
+	diff := cmp.Diff("testing error", fmt.Sprint(err))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	// File is not replaced and temporary file is removed
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff = cmp.Diff("first", string(b))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff([]string{"b.json"}, files(t, dir))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
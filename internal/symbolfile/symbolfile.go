@@ -0,0 +1,33 @@
+// Package symbolfile reads universe of symbols from files, one per line, which are given to commands.
+package symbolfile
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// Parse symbols, one per line, ignoring blank lines and lines starting with "#".
+func Parse(r io.Reader) ([]string, error) {
+	symbols := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		symbols = append(symbols, line)
+	}
+	return symbols, scanner.Err()
+}
+
+// Read symbols from file of the name.
+func Read(name string) ([]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
@@ -0,0 +1,43 @@
+package symbolfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		content  string
+		expected []string
+	}{
+		{
+			desc:     "Symbols",
+			content:  "00005\n00006\n",
+			expected: []string{"00005", "00006"},
+		},
+		{
+			desc:     "CommentsAndBlankLines",
+			content:  "# HSI constituents\n\n 00005 \n#00011\n00006",
+			expected: []string{"00005", "00006"},
+		},
+		{
+			desc:     "Empty",
+			expected: []string{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			symbols, err := Parse(strings.NewReader(tC.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff := cmp.Diff(tC.expected, symbols)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
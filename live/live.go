@@ -0,0 +1,215 @@
+// Package live serves live prices of price hub to browser clients over Server-Sent Events and WebSocket.
+//
+// Symbols are subscribed with query of the request, and each symbol is polled once by the hub
+// regardless of the number of clients connected.
+//
+//	hub := aastocks.NewPriceHub(ctx, aastocks.HubConfig{Interval: 5 * time.Second})
+//	http.Handle("/v1/live", live.NewHandler(hub, live.Config{}))
+//
+// Browser clients can then subscribe with EventSource, or WebSocket if it is preferred.
+//
+//	const source = new EventSource("/v1/live?symbols=00005,00006");
+//	source.addEventListener("price", (e) => console.log(JSON.parse(e.data)));
+//
+// Price events carry ID of the latest prices sent, so reconnection of EventSource with Last-Event-ID
+// only replays the latest prices which are newer than those received.
+// Events are buffered for each connection by the hub, and the oldest events are dropped
+// once the buffer is full, so slow client will not stall others.
+package live
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"golang.org/x/net/websocket"
+)
+
+// Event types sent to clients.
+const (
+	TypePrice     = "price"
+	TypeError     = "error"
+	TypeHeartbeat = "heartbeat"
+)
+
+// Price event sent to clients.
+type Price struct {
+	Type      string    `json:"type"`
+	ID        string    `json:"id"`
+	Symbol    string    `json:"symbol"`
+	Price     float64   `json:"price"`
+	PrevClose float64   `json:"prev_close"`
+	Changes   []string  `json:"changes,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// Error event sent to clients, symbol is empty if it is not error of polling the symbol.
+type Error struct {
+	Type    string `json:"type"`
+	Symbol  string `json:"symbol,omitempty"`
+	Message string `json:"message"`
+}
+
+// Heartbeat event sent to clients periodically, so that idle connections are kept alive.
+type Heartbeat struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+}
+
+// Config of handler.
+type Config struct {
+	// Heartbeat interval, 15 seconds is used if it is zero.
+	Heartbeat time.Duration
+	// MaxSymbols subscribed by each connection, 20 is used if it is zero.
+	MaxSymbols int
+	// Retry delay suggested to EventSource for reconnection, browser default is used if it is zero.
+	Retry time.Duration
+	// WriteTimeout of each WebSocket message, connection is closed once it is exceeded.
+	// 10 seconds is used if it is zero.
+	WriteTimeout time.Duration
+}
+
+// Handler serves live prices from price hub.
+// WebSocket is served if the request is to upgrade, otherwise Server-Sent Events are served.
+type Handler struct {
+	hub       *aastocks.PriceHub
+	config    Config
+	websocket websocket.Server
+}
+
+// NewHandler creates handler serving live prices from the hub, which is not closed by the handler.
+func NewHandler(hub *aastocks.PriceHub, config Config) *Handler {
+	if config.Heartbeat <= 0 {
+		config.Heartbeat = 15 * time.Second
+	}
+	if config.MaxSymbols <= 0 {
+		config.MaxSymbols = 20
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = 10 * time.Second
+	}
+	h := &Handler{
+		hub:    hub,
+		config: config,
+	}
+	h.websocket = websocket.Server{
+		// Origin is not checked, same as Server-Sent Events
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler:   h.serveWebSocket,
+	}
+	return h
+}
+
+// ServeHTTP serves live prices of symbols in query (i.e. "?symbols=00005,00006").
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method is not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		h.websocket.ServeHTTP(w, r)
+		return
+	}
+	h.serveSSE(w, r)
+}
+
+// parseSymbols parses comma separated symbols, duplicates are removed.
+func parseSymbols(s string) []string {
+	symbols := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, symbol := range strings.Split(s, ",") {
+		symbol = strings.TrimSpace(symbol)
+		if symbol == "" || seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+func (h *Handler) checkSymbols(n int) error {
+	if n > h.config.MaxSymbols {
+		return fmt.Errorf("Symbols are more than %v", h.config.MaxSymbols)
+	}
+	return nil
+}
+
+// cursor of the latest price times sent to connection by symbol.
+// It is used as ID of events, so that reconnection with Last-Event-ID only replays newer prices.
+type cursor map[string]time.Time
+
+// parseCursor parses cursor formatted as "00005:1598390318000,00006:1598390318000"
+// with times in Unix milliseconds, malformed entries are ignored.
+func parseCursor(s string) cursor {
+	c := make(cursor)
+	for _, entry := range strings.Split(s, ",") {
+		i := strings.LastIndex(entry, ":")
+		if i <= 0 {
+			continue
+		}
+		ms, err := strconv.ParseInt(entry[i+1:], 10, 64)
+		if err != nil {
+			continue
+		}
+		c[entry[:i]] = time.Unix(0, ms*int64(time.Millisecond)).UTC()
+	}
+	return c
+}
+
+func (c cursor) String() string {
+	symbols := make([]string, 0, len(c))
+	for symbol := range c {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	entries := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		entries[i] = fmt.Sprintf("%v:%v", symbol, c[symbol].UnixNano()/int64(time.Millisecond))
+	}
+	return strings.Join(entries, ",")
+}
+
+// advance cursor of the symbol to time of the price.
+// False is returned if the price is not newer than the one sent, so it should not be sent again.
+// Prices without time are always sent.
+func (c cursor) advance(symbol string, t time.Time) bool {
+	if t.IsZero() {
+		return true
+	}
+	if last, ok := c[symbol]; ok && !t.After(last) {
+		return false
+	}
+	c[symbol] = t
+	return true
+}
+
+// event converts event of the hub to event sent to clients, nil is returned if it should not be sent.
+func (c cursor) event(e aastocks.PriceEvent) interface{} {
+	symbol := e.Price.Symbol
+	if e.Err != nil {
+		return Error{Type: TypeError, Symbol: symbol, Message: e.Err.Error()}
+	}
+	if !c.advance(symbol, e.Price.Time) {
+		return nil
+	}
+	p := Price{
+		Type:   TypePrice,
+		ID:     c.String(),
+		Symbol: symbol,
+		Price:  e.Price.Price,
+		Time:   e.Price.Time,
+	}
+	if e.Quote != nil {
+		p.PrevClose = e.Quote.PrevClose
+	}
+	for _, change := range e.Changes {
+		p.Changes = append(p.Changes, change.Field)
+	}
+	return p
+}
@@ -0,0 +1,155 @@
+package live
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+// mockTransport serves files of urls.
+type mockTransport map[string]string
+
+func (m mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, ok := m[req.URL.String()]
+	if !ok {
+		return nil, fmt.Errorf("Handler not found for %s", req.URL)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	recorder.Write(b)
+	return recorder.Result(), nil
+}
+
+func testHub(t *testing.T) *aastocks.PriceHub {
+	hub := aastocks.NewPriceHub(context.Background(), aastocks.HubConfig{Interval: time.Hour}, aastocks.WithClient(&http.Client{
+		Transport: mockTransport{
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": "../testdata/detail_quote.html",
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=09923": "../testdata/detail_quote_new.html",
+		},
+	}))
+	t.Cleanup(hub.Close)
+	return hub
+}
+
+var (
+	price00006 = Price{
+		Type:      TypePrice,
+		ID:        "00006:1598390318000",
+		Symbol:    "00006",
+		Price:     44.65,
+		PrevClose: 44.2,
+		Time:      time.Date(2020, time.August, 25, 21, 18, 38, 0, time.UTC),
+	}
+	price09923 = Price{
+		Type:      TypePrice,
+		ID:        "09923:1598410243000",
+		Symbol:    "09923",
+		Price:     59.6,
+		PrevClose: 61.2,
+		Time:      time.Date(2020, time.August, 26, 2, 50, 43, 0, time.UTC),
+	}
+)
+
+func TestCursor(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		id       string
+		expected cursor
+	}{
+		{
+			desc: "Symbols",
+			id:   "00005:1598390318000,00006:1598410243000",
+			expected: cursor{
+				"00005": time.Date(2020, time.August, 25, 21, 18, 38, 0, time.UTC),
+				"00006": time.Date(2020, time.August, 26, 2, 50, 43, 0, time.UTC),
+			},
+		},
+		{
+			desc:     "Empty",
+			id:       "",
+			expected: cursor{},
+		},
+		{
+			desc: "Malformed",
+			id:   "00005,:1598390318000,00006:abc,00007:1598390318000",
+			expected: cursor{
+				"00007": time.Date(2020, time.August, 25, 21, 18, 38, 0, time.UTC),
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			c := parseCursor(tC.id)
+			diff := cmp.Diff(tC.expected, c)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestCursorEvent(t *testing.T) {
+	event := aastocks.PriceEvent{
+		Price: aastocks.PriceResult{
+			Symbol: "00006",
+			Price:  44.65,
+			Time:   time.Date(2020, time.August, 25, 21, 18, 38, 0, time.UTC),
+		},
+		Quote: &aastocks.Quote{Symbol: "00006", Price: 44.65, PrevClose: 44.2},
+		Changes: []aastocks.FieldChange{
+			{Field: "price", Previous: 44.6, New: 44.65},
+		},
+	}
+
+	c := parseCursor("00005:1598390318000")
+	expected := price00006
+	expected.ID = "00005:1598390318000,00006:1598390318000"
+	expected.Changes = []string{"price"}
+	diff := cmp.Diff(expected, c.event(event))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// Price is not sent again, unless it is newer
+	diff = cmp.Diff(nil, c.event(event))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	event.Price.Time = event.Price.Time.Add(time.Second)
+	e, ok := c.event(event).(Price)
+	if !ok {
+		t.Fatalf("Price event is expected")
+	}
+	diff = cmp.Diff("00005:1598390318000,00006:1598390319000", e.ID)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff(Error{Type: TypeError, Symbol: "00006", Message: "Request failed"}, c.event(aastocks.PriceEvent{
+		Price: aastocks.PriceResult{Symbol: "00006"},
+		Err:   fmt.Errorf("Request failed"),
+	}))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestHandlerMethodNotAllowed(t *testing.T) {
+	h := NewHandler(testHub(t), Config{})
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/?symbols=00006", nil))
+	diff := cmp.Diff(http.StatusMethodNotAllowed, recorder.Code)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,91 @@
+package live
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// serveSSE serves events until the client is disconnected or the hub is ended.
+// Writes are not timed out, events are dropped by the hub instead while the client is slow.
+func (h *Handler) serveSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	symbols := parseSymbols(r.URL.Query().Get("symbols"))
+	if len(symbols) == 0 {
+		http.Error(w, "Symbols are not provided", http.StatusBadRequest)
+		return
+	}
+	if err := h.checkSymbols(len(symbols)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c := make(cursor)
+	last := parseCursor(r.Header.Get("Last-Event-ID"))
+	for _, symbol := range symbols {
+		if t, ok := last[symbol]; ok {
+			c[symbol] = t
+		}
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// Disables buffering of reverse proxy (i.e. nginx)
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if h.config.Retry > 0 {
+		fmt.Fprintf(w, "retry: %d\n\n", h.config.Retry/time.Millisecond)
+	}
+	flusher.Flush()
+
+	sub := h.hub.Subscribe(symbols...)
+	defer sub.Close()
+	heartbeat := time.NewTicker(h.config.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		var event interface{}
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-sub.C():
+			if !ok {
+				return
+			}
+			event = c.event(e)
+			if event == nil {
+				continue
+			}
+		case t := <-heartbeat.C:
+			event = Heartbeat{Type: TypeHeartbeat, Time: t.UTC()}
+		}
+		if err := writeSSE(w, event); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeSSE writes event with its type, and ID if it is price event.
+func writeSSE(w io.Writer, event interface{}) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	switch e := event.(type) {
+	case Price:
+		_, err = fmt.Fprintf(w, "event: %v\nid: %v\ndata: %s\n\n", e.Type, e.ID, data)
+	case Error:
+		_, err = fmt.Fprintf(w, "event: %v\ndata: %s\n\n", e.Type, data)
+	case Heartbeat:
+		_, err = fmt.Fprintf(w, "event: %v\ndata: %s\n\n", e.Type, data)
+	}
+	return err
+}
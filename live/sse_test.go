@@ -0,0 +1,167 @@
+package live
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type sseEvent struct {
+	event string
+	id    string
+	data  string
+}
+
+// readSSE reads next event from the stream, comments and retry are skipped.
+func readSSE(t *testing.T, r *bufio.Reader) sseEvent {
+	e := sseEvent{}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Event failed to be read: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			if e.event != "" {
+				return e
+			}
+			continue
+		}
+		field := strings.SplitN(line, ": ", 2)
+		switch field[0] {
+		case "event":
+			e.event = field[1]
+		case "id":
+			e.id = field[1]
+		case "data":
+			e.data = field[1]
+		}
+	}
+}
+
+func connectSSE(t *testing.T, url string, lastEventID string) *bufio.Reader {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	diff := cmp.Diff("text/event-stream", resp.Header.Get("Content-Type"))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	return bufio.NewReader(resp.Body)
+}
+
+func TestServeSSE(t *testing.T) {
+	server := httptest.NewServer(NewHandler(testHub(t), Config{
+		Heartbeat: 50 * time.Millisecond,
+		Retry:     3 * time.Second,
+	}))
+	t.Cleanup(server.Close)
+
+	r := connectSSE(t, server.URL+"?symbols=00006,09923", "")
+	received := make(map[string]Price)
+	var id string
+	for len(received) < 2 {
+		e := readSSE(t, r)
+		if e.event != TypePrice {
+			continue
+		}
+		var p Price
+		err := json.Unmarshal([]byte(e.data), &p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		diff := cmp.Diff(p.ID, e.id)
+		if diff != "" {
+			t.Fatalf(diff)
+		}
+		p.ID = ""
+		received[p.Symbol] = p
+		id = e.id
+	}
+	expected00006, expected09923 := price00006, price09923
+	expected00006.ID, expected09923.ID = "", ""
+	diff := cmp.Diff(map[string]Price{"00006": expected00006, "09923": expected09923}, received)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff("00006:1598390318000,09923:1598410243000", id)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// Heartbeat is sent while prices are not changed
+	e := readSSE(t, r)
+	diff = cmp.Diff(TypeHeartbeat, e.event)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// Latest prices received are not replayed on reconnection
+	r = connectSSE(t, server.URL+"?symbols=00006,09923", id)
+	e = readSSE(t, r)
+	diff = cmp.Diff(TypeHeartbeat, e.event)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// Latest prices newer than those received are replayed on reconnection
+	r = connectSSE(t, server.URL+"?symbols=00006,09923", "00006:1598390318000,09923:1598410242000")
+	e = readSSE(t, r)
+	diff = cmp.Diff(sseEvent{
+		event: TypePrice,
+		id:    "00006:1598390318000,09923:1598410243000",
+		data:  `{"type":"price","id":"00006:1598390318000,09923:1598410243000","symbol":"09923","price":59.6,"prev_close":61.2,"time":"2020-08-26T02:50:43Z"}`,
+	}, e, cmp.AllowUnexported(sseEvent{}))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestServeSSEBadRequest(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		query string
+		body  string
+	}{
+		{
+			desc:  "NoSymbols",
+			query: "?symbols=,",
+			body:  "Symbols are not provided\n",
+		},
+		{
+			desc:  "TooManySymbols",
+			query: "?symbols=00001,00002,00003",
+			body:  "Symbols are more than 2\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			h := NewHandler(testHub(t), Config{MaxSymbols: 2})
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/"+tC.query, nil))
+			diff := cmp.Diff(http.StatusBadRequest, recorder.Code)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.body, recorder.Body.String())
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package live
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// Actions of messages from WebSocket clients.
+const (
+	ActionSubscribe   = "subscribe"
+	ActionUnsubscribe = "unsubscribe"
+)
+
+// Message from WebSocket clients to change symbols subscribed.
+//
+//	{"action": "subscribe", "symbols": ["00005"]}
+type Message struct {
+	Action  string   `json:"action"`
+	Symbols []string `json:"symbols"`
+}
+
+// serveWebSocket serves events until the client is disconnected, the hub is ended,
+// or write is timed out. Symbols in query are subscribed initially, which can be empty.
+func (h *Handler) serveWebSocket(conn *websocket.Conn) {
+	defer conn.Close()
+	symbols := parseSymbols(conn.Request().URL.Query().Get("symbols"))
+	if err := h.checkSymbols(len(symbols)); err != nil {
+		h.send(conn, Error{Type: TypeError, Message: err.Error()})
+		return
+	}
+
+	messages := make(chan Message)
+	done := make(chan struct{})
+	defer close(done)
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			var data []byte
+			err := websocket.Message.Receive(conn, &data)
+			if err != nil {
+				return
+			}
+			var m Message
+			err = json.Unmarshal(data, &m)
+			if err != nil {
+				m = Message{}
+			}
+			m.Symbols = parseSymbols(strings.Join(m.Symbols, ","))
+			select {
+			case messages <- m:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	c := make(cursor)
+	subscribed := make(map[string]bool)
+	for _, symbol := range symbols {
+		subscribed[symbol] = true
+	}
+	sub := h.hub.Subscribe(symbols...)
+	defer sub.Close()
+	heartbeat := time.NewTicker(h.config.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		var event interface{}
+		select {
+		case <-closed:
+			return
+		case m := <-messages:
+			err := h.apply(m, subscribed)
+			if err != nil {
+				event = Error{Type: TypeError, Message: err.Error()}
+				break
+			}
+			switch m.Action {
+			case ActionSubscribe:
+				sub.Subscribe(m.Symbols...)
+			case ActionUnsubscribe:
+				sub.Unsubscribe(m.Symbols...)
+				for _, symbol := range m.Symbols {
+					delete(c, symbol)
+				}
+			}
+			continue
+		case e, ok := <-sub.C():
+			if !ok {
+				return
+			}
+			if !subscribed[e.Price.Symbol] {
+				// Buffered before it is unsubscribed
+				continue
+			}
+			event = c.event(e)
+			if event == nil {
+				continue
+			}
+		case t := <-heartbeat.C:
+			event = Heartbeat{Type: TypeHeartbeat, Time: t.UTC()}
+		}
+		if err := h.send(conn, event); err != nil {
+			return
+		}
+	}
+}
+
+// apply message to symbols subscribed, error is returned if the message is invalid.
+func (h *Handler) apply(m Message, subscribed map[string]bool) error {
+	switch m.Action {
+	case ActionSubscribe:
+		n := len(subscribed)
+		for _, symbol := range m.Symbols {
+			if !subscribed[symbol] {
+				n++
+			}
+		}
+		if err := h.checkSymbols(n); err != nil {
+			return err
+		}
+		for _, symbol := range m.Symbols {
+			subscribed[symbol] = true
+		}
+	case ActionUnsubscribe:
+		for _, symbol := range m.Symbols {
+			delete(subscribed, symbol)
+		}
+	default:
+		return fmt.Errorf("Message is invalid")
+	}
+	return nil
+}
+
+func (h *Handler) send(conn *websocket.Conn, event interface{}) error {
+	err := conn.SetWriteDeadline(time.Now().Add(h.config.WriteTimeout))
+	if err != nil {
+		return err
+	}
+	return websocket.JSON.Send(conn, event)
+}
@@ -0,0 +1,148 @@
+package live
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/net/websocket"
+)
+
+func dialWebSocket(t *testing.T, url string) *websocket.Conn {
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(url, "http"), "", "http://localhost/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readWebSocket reads next event which is not heartbeat.
+func readWebSocket(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	for {
+		err := conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var data []byte
+		err = websocket.Message.Receive(conn, &data)
+		if err != nil {
+			t.Fatalf("Event failed to be read: %v", err)
+		}
+		var e map[string]interface{}
+		err = json.Unmarshal(data, &e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e["type"] != TypeHeartbeat {
+			return e
+		}
+	}
+}
+
+func TestServeWebSocket(t *testing.T) {
+	server := httptest.NewServer(NewHandler(testHub(t), Config{
+		Heartbeat:  50 * time.Millisecond,
+		MaxSymbols: 2,
+	}))
+	t.Cleanup(server.Close)
+
+	conn := dialWebSocket(t, server.URL+"?symbols=00006")
+	testCases := []struct {
+		desc     string
+		message  string
+		expected map[string]interface{}
+	}{
+		{
+			desc: "Initial",
+			expected: map[string]interface{}{
+				"type":       "price",
+				"id":         "00006:1598390318000",
+				"symbol":     "00006",
+				"price":      44.65,
+				"prev_close": 44.2,
+				"time":       "2020-08-25T21:18:38Z",
+			},
+		},
+		{
+			desc:    "Subscribe",
+			message: `{"action":"subscribe","symbols":["09923"," 00006"]}`,
+			expected: map[string]interface{}{
+				"type":       "price",
+				"id":         "00006:1598390318000,09923:1598410243000",
+				"symbol":     "09923",
+				"price":      59.6,
+				"prev_close": 61.2,
+				"time":       "2020-08-26T02:50:43Z",
+			},
+		},
+		{
+			desc:    "TooManySymbols",
+			message: `{"action":"subscribe","symbols":["00005"]}`,
+			expected: map[string]interface{}{
+				"type":    "error",
+				"message": "Symbols are more than 2",
+			},
+		},
+		{
+			desc:    "InvalidMessage",
+			message: `{"action":"`,
+			expected: map[string]interface{}{
+				"type":    "error",
+				"message": "Message is invalid",
+			},
+		},
+		{
+			desc:    "Unsubscribe",
+			message: `{"action":"unsubscribe","symbols":["00006"]}`,
+		},
+		{
+			desc:    "Resubscribe",
+			message: `{"action":"subscribe","symbols":["00006"]}`,
+			expected: map[string]interface{}{
+				"type":       "price",
+				"id":         "00006:1598390318000,09923:1598410243000",
+				"symbol":     "00006",
+				"price":      44.65,
+				"prev_close": 44.2,
+				"time":       "2020-08-25T21:18:38Z",
+			},
+		},
+	}
+	for _, tC := range testCases {
+		if tC.message != "" {
+			err := websocket.Message.Send(conn, tC.message)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if tC.expected == nil {
+			continue
+		}
+		diff := cmp.Diff(tC.expected, readWebSocket(t, conn))
+		if diff != "" {
+			t.Fatalf("%v: %v", tC.desc, diff)
+		}
+	}
+}
+
+func TestServeWebSocketTooManySymbols(t *testing.T) {
+	server := httptest.NewServer(NewHandler(testHub(t), Config{MaxSymbols: 1}))
+	t.Cleanup(server.Close)
+
+	conn := dialWebSocket(t, server.URL+"?symbols=00006,09923")
+	diff := cmp.Diff(map[string]interface{}{
+		"type":    "error",
+		"message": "Symbols are more than 1",
+	}, readWebSocket(t, conn))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	var data []byte
+	if err := websocket.Message.Receive(conn, &data); err == nil {
+		t.Fatalf("Connection should be closed")
+	}
+}
@@ -0,0 +1,120 @@
+package aastocks
+
+import (
+	"math"
+	"time"
+)
+
+// Pacer decides delay before the next poll of prices.
+// Pacer is stateful, and should not be shared by multiple serving of prices.
+type Pacer interface {
+	// Next returns delay before the next poll, with the latest quote or error of fetching it.
+	Next(latest *Quote, err error) time.Duration
+}
+
+// WithPacer to adapt delay between polls, instead of fixed delay.
+func WithPacer(pacer Pacer) ServeOption {
+	return func(c *serveConfig) {
+		c.pacer = pacer
+	}
+}
+
+// WithRateLimiter to wait for rate limiter before each poll, which can be shared globally.
+func WithRateLimiter(limiter *RateLimiter) ServeOption {
+	return func(c *serveConfig) {
+		c.limiter = limiter
+	}
+}
+
+// AdaptiveInterval is pacer shortening interval when price changes frequently or approaches levels,
+// and lengthening it when price is stable. Interval is backed off exponentially on consecutive errors.
+// Interval is always within the minimum and maximum.
+type AdaptiveInterval struct {
+	// Min of interval, 1 second is used if it is not positive, so that AAStocks is not polled without delay.
+	Min time.Duration
+	// Max of interval, 8 times of the minimum is used if it is not positive,
+	// so that price is still polled in time after long stable period.
+	Max time.Duration
+	// Levels of prices, interval is reset to the minimum when price approaches any of them.
+	Levels []float64
+	// Proximity to levels as fraction of price, 0.01 is used if it is zero.
+	Proximity float64
+	// Factor to shorten or lengthen interval, 2 is used if it is zero.
+	Factor float64
+
+	current time.Duration
+	price   float64
+	started bool
+	errors  int
+}
+
+// Next returns delay before the next poll.
+func (a *AdaptiveInterval) Next(latest *Quote, err error) time.Duration {
+	factor := a.Factor
+	if factor == 0 {
+		factor = 2
+	}
+	if a.current == 0 {
+		a.current = a.min()
+	}
+
+	if err != nil {
+		a.errors++
+		backoff := float64(a.current) * math.Pow(2, float64(a.errors))
+		// prevent overflow of duration on many consecutive errors
+		if backoff > float64(1<<62) {
+			backoff = float64(1 << 62)
+		}
+		return a.bound(time.Duration(backoff))
+	}
+	a.errors = 0
+
+	switch {
+	case a.approaching(latest.Price):
+		a.current = a.min()
+	case a.started && latest.Price != a.price:
+		a.current = a.bound(time.Duration(float64(a.current) / factor))
+	case a.started:
+		a.current = a.bound(time.Duration(float64(a.current) * factor))
+	}
+	a.price = latest.Price
+	a.started = true
+	return a.current
+}
+
+func (a *AdaptiveInterval) approaching(price float64) bool {
+	proximity := a.Proximity
+	if proximity == 0 {
+		proximity = 0.01
+	}
+	for _, l := range a.Levels {
+		if math.Abs(price-l) <= price*proximity {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *AdaptiveInterval) min() time.Duration {
+	if a.Min <= 0 {
+		return time.Second
+	}
+	return a.Min
+}
+
+func (a *AdaptiveInterval) max() time.Duration {
+	if a.Max <= 0 {
+		return 8 * a.min()
+	}
+	return a.Max
+}
+
+func (a *AdaptiveInterval) bound(d time.Duration) time.Duration {
+	if d < a.min() {
+		return a.min()
+	}
+	if d > a.max() {
+		return a.max()
+	}
+	return d
+}
@@ -0,0 +1,160 @@
+package aastocks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAdaptiveInterval(t *testing.T) {
+	a := &AdaptiveInterval{
+		Min:    time.Second,
+		Max:    8 * time.Second,
+		Levels: []float64{50},
+	}
+	steps := []struct {
+		desc  string
+		price float64
+		err   error
+		delay time.Duration
+	}{
+		{desc: "First", price: 44.65, delay: time.Second},
+		{desc: "Stable", price: 44.65, delay: 2 * time.Second},
+		{desc: "Stable", price: 44.65, delay: 4 * time.Second},
+		{desc: "Stable", price: 44.65, delay: 8 * time.Second},
+		{desc: "StableAtMax", price: 44.65, delay: 8 * time.Second},
+		{desc: "Changed", price: 44.4, delay: 4 * time.Second},
+		{desc: "Error", err: fmt.Errorf("testing error"), delay: 8 * time.Second},
+		{desc: "ErrorAtMax", err: fmt.Errorf("testing error"), delay: 8 * time.Second},
+		{desc: "Recovered", price: 44.4, delay: 8 * time.Second},
+		{desc: "ApproachingLevel", price: 49.6, delay: time.Second},
+	}
+	for i, s := range steps {
+		var q *Quote
+		if s.err == nil {
+			q = &Quote{Price: s.price}
+		}
+		diff := cmp.Diff(s.delay, a.Next(q, s.err))
+		if diff != "" {
+			t.Fatalf("step %v (%s): %v", i, s.desc, diff)
+		}
+	}
+}
+
+func TestAdaptiveIntervalDefaults(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		pacer  *AdaptiveInterval
+		steps  []error
+		delays []time.Duration
+	}{
+		{
+			desc:   "ZeroValue",
+			pacer:  &AdaptiveInterval{},
+			steps:  []error{nil, nil, nil, fmt.Errorf("testing error")},
+			delays: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		{
+			desc:   "MaxUnset",
+			pacer:  &AdaptiveInterval{Min: 500 * time.Millisecond},
+			steps:  []error{nil, nil, fmt.Errorf("testing error"), fmt.Errorf("testing error")},
+			delays: []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			desc:   "MaxUnsetStable",
+			pacer:  &AdaptiveInterval{},
+			steps:  []error{nil, nil, nil, nil, nil, nil, fmt.Errorf("testing error"), fmt.Errorf("testing error")},
+			delays: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second, 8 * time.Second, 8 * time.Second, 8 * time.Second},
+		},
+		{
+			desc:   "MinUnset",
+			pacer:  &AdaptiveInterval{Max: 3 * time.Second},
+			steps:  []error{nil, nil, nil, fmt.Errorf("testing error")},
+			delays: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			delays := make([]time.Duration, 0, len(tC.steps))
+			for _, err := range tC.steps {
+				var q *Quote
+				if err == nil {
+					q = &Quote{Price: 44.65}
+				}
+				delays = append(delays, tC.pacer.Next(q, err))
+			}
+			diff := cmp.Diff(tC.delays, delays)
+			if diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestAdaptiveIntervalBackoffOverflow(t *testing.T) {
+	a := &AdaptiveInterval{}
+	var delay time.Duration
+	for i := 0; i < 100; i++ {
+		delay = a.Next(nil, fmt.Errorf("testing error"))
+	}
+	if delay <= 0 {
+		t.Fatalf("expected positive delay after consecutive errors, but got %v", delay)
+	}
+}
+
+// recordPacer records prices and errors it is called with.
+type recordPacer struct {
+	calls []interface{}
+}
+
+func (p *recordPacer) Next(latest *Quote, err error) time.Duration {
+	if err != nil {
+		p.calls = append(p.calls, err.Error())
+	} else {
+		p.calls = append(p.calls, latest.Price)
+	}
+	if len(p.calls) >= 3 {
+		return time.Hour
+	}
+	return 10 * time.Millisecond
+}
+
+func TestStreamPricesWithPacer(t *testing.T) {
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": serveAll(
+			serveFile("testdata/detail_quote.html"), // First called with getting quote
+
+			serveFile("testdata/detail_quote.html"),
+			serveError(fmt.Errorf("testing error")),
+			serveFile("testdata/detail_quote_00006_2.html"),
+		),
+	})
+	quote, err := Get("00006", WithClient(mock.client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pacer := &recordPacer{}
+	stream := quote.StreamPrices(context.Background(), time.Hour,
+		WithPacer(pacer),
+		WithRateLimiter(NewRateLimiter(time.Millisecond, 1)),
+	)
+	for i := 0; i < 3; i++ {
+		select {
+		case <-stream.C():
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timeout is triggered, expect delay to be decided by pacer")
+		}
+	}
+	stream.Close()
+
+	diff := cmp.Diff([]interface{}{44.65, "Failed to fetch quote details: 500 Internal Server Error", 44.4}, pacer.calls)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,118 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Method of cost basis.
+type Method int
+
+const (
+	// FIFO matches quantity sold with the earliest quantity bought.
+	FIFO Method = iota
+	// AverageCost matches quantity sold with average cost of quantity held.
+	AverageCost
+)
+
+// Position of symbol.
+type Position struct {
+	Symbol   string
+	Quantity int
+	// Cost basis of quantity held, including fees of buys.
+	Cost float64
+	// Realised profit and loss of quantity sold, net of fees.
+	Realised float64
+
+	// Fields below are filled when the position is valued.
+	Industry   string
+	Price      float64
+	Value      float64
+	Unrealised float64
+	// Dividends credited in HKD.
+	Dividends float64
+}
+
+// AverageCost of quantity held.
+func (p Position) AverageCost() float64 {
+	if p.Quantity == 0 {
+		return 0
+	}
+	return p.Cost / float64(p.Quantity)
+}
+
+// lot of quantity bought, with its total cost.
+type lot struct {
+	quantity int
+	cost     float64
+}
+
+// positions replays trades in order of dates, and returns positions by symbols in ascending order.
+func positions(trades []Trade, method Method) ([]Position, error) {
+	lots := make(map[string][]lot)
+	result := make(map[string]*Position)
+	for _, t := range sorted(trades) {
+		p, ok := result[t.Symbol]
+		if !ok {
+			p = &Position{Symbol: t.Symbol}
+			result[t.Symbol] = p
+		}
+		amount := float64(t.Quantity) * t.Price
+		switch t.Side {
+		case Buy:
+			l := lot{quantity: t.Quantity, cost: amount + t.Fees}
+			held := lots[t.Symbol]
+			if method == AverageCost && len(held) > 0 {
+				held[0].quantity += l.quantity
+				held[0].cost += l.cost
+			} else {
+				lots[t.Symbol] = append(held, l)
+			}
+			p.Quantity += t.Quantity
+			p.Cost += l.cost
+		case Sell:
+			if t.Quantity > p.Quantity {
+				return nil, fmt.Errorf("Quantity sold is more than held for %v on %v: %v > %v", t.Symbol, t.Date.Format("2006-01-02"), t.Quantity, p.Quantity)
+			}
+			var cost float64
+			lots[t.Symbol], cost = consume(lots[t.Symbol], t.Quantity)
+			p.Quantity -= t.Quantity
+			p.Cost -= cost
+			if p.Quantity == 0 {
+				// Avoid residual of rounding errors
+				p.Cost = 0
+			}
+			p.Realised += amount - t.Fees - cost
+		}
+	}
+	symbols := make([]string, 0, len(result))
+	for s := range result {
+		symbols = append(symbols, s)
+	}
+	sort.Strings(symbols)
+	positions := make([]Position, len(symbols))
+	for i, s := range symbols {
+		positions[i] = *result[s]
+	}
+	return positions, nil
+}
+
+// consume quantity from the earliest lots, and returns lots remained with cost of quantity consumed.
+func consume(lots []lot, quantity int) ([]lot, float64) {
+	cost := 0.0
+	for quantity > 0 && len(lots) > 0 {
+		l := &lots[0]
+		if l.quantity <= quantity {
+			cost += l.cost
+			quantity -= l.quantity
+			lots = lots[1:]
+			continue
+		}
+		part := l.cost * float64(quantity) / float64(l.quantity)
+		l.cost -= part
+		l.quantity -= quantity
+		cost += part
+		quantity = 0
+	}
+	return lots, cost
+}
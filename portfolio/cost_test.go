@@ -0,0 +1,88 @@
+package portfolio
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2020, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func testTrades() []Trade {
+	return []Trade{
+		{Date: date(time.March, 2), Symbol: "00006", Side: Buy, Quantity: 1000, Price: 40, Fees: 10},
+		// Recorded out of order
+		{Date: date(time.June, 1), Symbol: "00006", Side: Sell, Quantity: 1000, Price: 48, Fees: 12},
+		{Date: date(time.April, 1), Symbol: "00006", Side: Buy, Quantity: 500, Price: 50, Fees: 5},
+		{Date: date(time.April, 1), Symbol: "00005", Side: Buy, Quantity: 400, Price: 30, Fees: 4},
+	}
+}
+
+func TestPositions(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		trades    []Trade
+		method    Method
+		positions []Position
+		err       error
+	}{
+		{
+			desc:   "FIFO",
+			trades: testTrades(),
+			method: FIFO,
+			positions: []Position{
+				{Symbol: "00005", Quantity: 400, Cost: 12004},
+				{Symbol: "00006", Quantity: 500, Cost: 25005, Realised: 47988 - 40010},
+			},
+		},
+		{
+			desc:   "AverageCost",
+			trades: testTrades(),
+			method: AverageCost,
+			positions: []Position{
+				{Symbol: "00005", Quantity: 400, Cost: 12004},
+				{Symbol: "00006", Quantity: 500, Cost: 65015.0 / 3, Realised: 47988 - 65015.0*2/3},
+			},
+		},
+		{
+			desc: "PartialLots",
+			trades: []Trade{
+				{Date: date(time.March, 2), Symbol: "00006", Side: Buy, Quantity: 1000, Price: 40},
+				{Date: date(time.March, 3), Symbol: "00006", Side: Buy, Quantity: 1000, Price: 44},
+				{Date: date(time.March, 4), Symbol: "00006", Side: Sell, Quantity: 1500, Price: 45},
+				{Date: date(time.March, 5), Symbol: "00006", Side: Sell, Quantity: 500, Price: 46},
+			},
+			method: FIFO,
+			positions: []Position{
+				{Symbol: "00006", Quantity: 0, Cost: 0, Realised: (67500 - 62000) + (23000 - 22000)},
+			},
+		},
+		{
+			desc: "Oversold",
+			trades: []Trade{
+				{Date: date(time.March, 2), Symbol: "00006", Side: Buy, Quantity: 500, Price: 40},
+				{Date: date(time.March, 1), Symbol: "00006", Side: Sell, Quantity: 500, Price: 40},
+			},
+			method: FIFO,
+			err:    fmt.Errorf("Quantity sold is more than held for 00006 on 2020-03-01: 500 > 0"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			positions, err := positions(tC.trades, tC.method)
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.positions, positions, cmpopts.EquateApprox(0, 1e-9))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
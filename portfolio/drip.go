@@ -0,0 +1,269 @@
+package portfolio
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// ReinvestAt is the date which dividends are reinvested at.
+type ReinvestAt int
+
+const (
+	// AtExDate reinvests at close price of ex-date
+	AtExDate ReinvestAt = iota
+	// AtPayableDate reinvests at close price of payable date, or ex-date if payable date is not announced
+	AtPayableDate
+)
+
+// DripConfig of dividend reinvestment simulation.
+type DripConfig struct {
+	// Quantity held at the start.
+	Quantity int
+	// Lots is board lot size (i.e. Quote.Lots), shares are only bought in multiple of it.
+	Lots int
+	At   ReinvestAt
+	// Start and End of simulation, the range of historical prices is used if they are zero.
+	Start time.Time
+	End   time.Time
+	// Fees of buying shares when reinvesting, no fees are charged if it is nil.
+	Fees func(price float64, quantity int) float64
+}
+
+// DripEvent is dividend paid in the simulation.
+type DripEvent struct {
+	Dividend aastocks.Dividend
+	// Date of the dividend paid or reinvested.
+	Date time.Time
+	// Quantity entitled to the dividend, held before its ex-date.
+	Quantity int
+	Amount   float64
+	// Price of shares bought, it is zero when no shares are bought.
+	Price  float64
+	Bought int
+	Fees   float64
+	// Cash after the event.
+	Cash float64
+}
+
+// DripOutcome of simulation.
+type DripOutcome struct {
+	Events   []DripEvent
+	Quantity int
+	// Cash is residual of reinvestment, or dividends paid out in cash.
+	Cash float64
+	// Income of dividends.
+	Income float64
+	// Value of quantity at the last close price, with cash.
+	Value float64
+}
+
+// Simulation of dividend reinvestment versus cash payout.
+type Simulation struct {
+	Drip DripOutcome
+	Cash DripOutcome
+}
+
+// SimulateDrip simulates holding with dividends reinvested (DRIP) and paid out in cash.
+// Prices are historical daily prices in ascending order of time.
+// Only cash dividends in HKD with ex-dates within the simulation are paid.
+func SimulateDrip(dividends []aastocks.Dividend, prices []aastocks.HistoricalPrice, config DripConfig) (*Simulation, error) {
+	if config.Quantity <= 0 {
+		return nil, fmt.Errorf("Quantity must be positive")
+	}
+	if config.Lots <= 0 {
+		return nil, fmt.Errorf("Lots must be positive")
+	}
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("Historical prices cannot be empty")
+	}
+	if config.Start.IsZero() {
+		config.Start = prices[0].Time
+	}
+	if config.End.IsZero() {
+		config.End = prices[len(prices)-1].Time
+	}
+	payouts := make([]aastocks.Dividend, 0)
+	for _, d := range dividends {
+		currency, _, ok := d.Cash()
+		if !ok || currency != "HKD" || d.ExDate.Before(config.Start) || d.ExDate.After(config.End) {
+			continue
+		}
+		payouts = append(payouts, d)
+	}
+	sort.SliceStable(payouts, func(i, j int) bool {
+		return payouts[i].ExDate.Before(payouts[j].ExDate)
+	})
+	return &Simulation{
+		Drip: simulate(payouts, prices, config, true),
+		Cash: simulate(payouts, prices, config, false),
+	}, nil
+}
+
+type purchase struct {
+	date     time.Time
+	quantity int
+}
+
+func simulate(payouts []aastocks.Dividend, prices []aastocks.HistoricalPrice, config DripConfig, reinvest bool) DripOutcome {
+	o := DripOutcome{
+		Events:   make([]DripEvent, 0),
+		Quantity: config.Quantity,
+	}
+	// Shares bought are entitled to dividends with ex-dates after they are bought
+	purchases := make([]purchase, 0)
+	entitled := func(exDate time.Time) int {
+		q := config.Quantity
+		for _, p := range purchases {
+			if p.date.Before(exDate) {
+				q += p.quantity
+			}
+		}
+		return q
+	}
+
+	events := make([]DripEvent, len(payouts))
+	perShare := make([]float64, len(payouts))
+	for i, d := range payouts {
+		date := d.ExDate
+		if config.At == AtPayableDate && !d.PayableDate.IsZero() {
+			date = d.PayableDate
+		}
+		events[i] = DripEvent{Dividend: d, Date: date}
+		_, perShare[i], _ = d.Cash()
+	}
+	// Dividends are paid in order of the dates, which may differ from ex-dates when paid at payable dates
+	order := make([]int, len(events))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return events[order[i]].Date.Before(events[order[j]].Date)
+	})
+	for _, i := range order {
+		e := events[i]
+		e.Quantity = entitled(e.Dividend.ExDate)
+		e.Amount = perShare[i] * float64(e.Quantity)
+		o.Income += e.Amount
+		o.Cash += e.Amount
+		if reinvest {
+			price, ok := closeOn(prices, e.Date)
+			if ok {
+				bought, fees := affordable(o.Cash, price, config)
+				if bought > 0 {
+					e.Price = price
+					e.Bought = bought
+					e.Fees = fees
+					o.Cash -= price*float64(bought) + fees
+					o.Quantity += bought
+					purchases = append(purchases, purchase{date: e.Date, quantity: bought})
+				}
+			}
+		}
+		e.Cash = o.Cash
+		o.Events = append(o.Events, e)
+	}
+	last := prices[0].Close
+	if p := lastOnOrBefore(prices, config.End); p != nil {
+		last = p.Close
+	}
+	o.Value = last*float64(o.Quantity) + o.Cash
+	return o
+}
+
+// closeOn returns close price of the first bar on or after the date.
+func closeOn(prices []aastocks.HistoricalPrice, date time.Time) (float64, bool) {
+	i := sort.Search(len(prices), func(i int) bool {
+		return !prices[i].Time.Before(date)
+	})
+	if i >= len(prices) {
+		return 0, false
+	}
+	return prices[i].Close, true
+}
+
+// lastOnOrBefore returns the last bar on or before the date, nil if there is none.
+func lastOnOrBefore(prices []aastocks.HistoricalPrice, date time.Time) *aastocks.HistoricalPrice {
+	i := sort.Search(len(prices), func(i int) bool {
+		return prices[i].Time.After(date)
+	})
+	if i == 0 {
+		return nil
+	}
+	return &prices[i-1]
+}
+
+// affordable returns the largest quantity in multiple of lots which cash can buy with fees.
+func affordable(cash float64, price float64, config DripConfig) (int, float64) {
+	if price <= 0 {
+		return 0, 0
+	}
+	lots := int(math.Floor(cash / (price * float64(config.Lots))))
+	for ; lots > 0; lots-- {
+		quantity := lots * config.Lots
+		fees := 0.0
+		if config.Fees != nil {
+			fees = config.Fees(price, quantity)
+		}
+		if price*float64(quantity)+fees <= cash {
+			return quantity, fees
+		}
+	}
+	return 0, 0
+}
+
+// Forecast of dividend income.
+type Forecast struct {
+	Event string
+	// ExDate expected, which is one year after the ex-date of the dividend it is based on.
+	ExDate   time.Time
+	PerShare float64
+	Income   float64
+}
+
+// ForecastIncome forecasts income of next 12 months from the time, assuming cash dividends in HKD
+// with ex-dates in the last 12 months recur one year later with the same amounts.
+// Forecasts are in ascending order of ex-dates, with total income returned.
+func ForecastIncome(dividends []aastocks.Dividend, quantity int, t time.Time) ([]Forecast, float64) {
+	from := t.AddDate(-1, 0, 0)
+	forecasts := make([]Forecast, 0)
+	total := 0.0
+	for _, d := range dividends {
+		currency, amount, ok := d.Cash()
+		if !ok || currency != "HKD" || !d.ExDate.After(from) || d.ExDate.After(t) {
+			continue
+		}
+		f := Forecast{
+			Event:    d.Event,
+			ExDate:   d.ExDate.AddDate(1, 0, 0),
+			PerShare: amount,
+			Income:   amount * float64(quantity),
+		}
+		forecasts = append(forecasts, f)
+		total += f.Income
+	}
+	sort.SliceStable(forecasts, func(i, j int) bool {
+		return forecasts[i].ExDate.Before(forecasts[j].ExDate)
+	})
+	return forecasts, total
+}
+
+// FetchDrip fetches dividends and daily historical prices of the quote from AAStocks, and simulates
+// dividend reinvestment. Lots of the quote are used if it is not configured.
+func FetchDrip(q *aastocks.Quote, config DripConfig) (*Simulation, error) {
+	if config.Lots == 0 {
+		config.Lots = q.Lots
+	}
+	dividends, err := q.Dividends()
+	if err != nil {
+		return nil, err
+	}
+	prices, err := q.HistoricalPrices(aastocks.Daily)
+	if err != nil {
+		return nil, err
+	}
+	return SimulateDrip(dividends, prices, config)
+}
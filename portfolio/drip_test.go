@@ -0,0 +1,127 @@
+package portfolio
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/horacehylee/aastocks"
+)
+
+func testDividends() []aastocks.Dividend {
+	return []aastocks.Dividend{
+		{Event: "Interim", Particular: "D:HKD 0.7700", ExDate: date(time.September, 3), PayableDate: date(time.September, 15)},
+		{Event: "Final", Particular: "D:HKD 2.0300", ExDate: date(time.May, 18), PayableDate: date(time.May, 28)},
+		{Event: "Special", Particular: "D:HKD 0.5000", ExDate: date(time.March, 20), PayableDate: date(time.March, 30)},
+		{Event: "Special", Particular: "D:RMB 0.5000", ExDate: date(time.March, 20), PayableDate: date(time.March, 30)},
+		{Event: "Special", Particular: "Preferential Offer", ExDate: date(time.March, 10)},
+		{Event: "Final", Particular: "D:HKD 2.0200", ExDate: time.Date(2019, time.May, 17, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func testDailyPrices() []aastocks.HistoricalPrice {
+	closes := []struct {
+		date  time.Time
+		close float64
+	}{
+		{date(time.January, 2), 40},
+		{date(time.March, 20), 40},
+		{date(time.March, 30), 50},
+		{date(time.May, 18), 42},
+		{date(time.May, 28), 44},
+		{date(time.September, 3), 44},
+		{date(time.September, 15), 44},
+		{date(time.December, 31), 45},
+	}
+	prices := make([]aastocks.HistoricalPrice, len(closes))
+	for i, c := range closes {
+		prices[i] = aastocks.HistoricalPrice{Time: c.date, Close: c.close}
+	}
+	return prices
+}
+
+func TestSimulateDrip(t *testing.T) {
+	type outcome struct {
+		Bought   []int
+		Quantity int
+		Cash     float64
+		Income   float64
+		Value    float64
+	}
+	summarize := func(o DripOutcome) outcome {
+		bought := make([]int, len(o.Events))
+		for i, e := range o.Events {
+			bought[i] = e.Bought
+		}
+		return outcome{Bought: bought, Quantity: o.Quantity, Cash: o.Cash, Income: o.Income, Value: o.Value}
+	}
+	testCases := []struct {
+		desc   string
+		config DripConfig
+		drip   outcome
+		cash   outcome
+		err    error
+	}{
+		{
+			desc:   "AtExDate",
+			config: DripConfig{Quantity: 10000, Lots: 500, At: AtExDate},
+			drip:   outcome{Bought: []int{0, 500, 0}, Quantity: 10500, Cash: 12385, Income: 33385, Value: 484885},
+			cash:   outcome{Bought: []int{0, 0, 0}, Quantity: 10000, Cash: 33000, Income: 33000, Value: 483000},
+		},
+		{
+			desc: "AtPayableDateWithFees",
+			config: DripConfig{Quantity: 10000, Lots: 500, At: AtPayableDate, Fees: func(price float64, quantity int) float64 {
+				return 100
+			}},
+			drip: outcome{Bought: []int{0, 500, 0}, Quantity: 10500, Cash: 3200 + 8085, Income: 33385, Value: 45*10500 + 11285},
+			cash: outcome{Bought: []int{0, 0, 0}, Quantity: 10000, Cash: 33000, Income: 33000, Value: 483000},
+		},
+		{
+			desc:   "WithinPeriod",
+			config: DripConfig{Quantity: 10000, Lots: 500, Start: date(time.April, 1), End: date(time.June, 30)},
+			// Cash is insufficient for a lot at ex-date
+			drip: outcome{Bought: []int{0}, Quantity: 10000, Cash: 20300, Income: 20300, Value: 44*10000 + 20300},
+			cash: outcome{Bought: []int{0}, Quantity: 10000, Cash: 20300, Income: 20300, Value: 44*10000 + 20300},
+		},
+		{
+			desc:   "InvalidLots",
+			config: DripConfig{Quantity: 10000},
+			err:    fmt.Errorf("Lots must be positive"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s, err := SimulateDrip(testDividends(), testDailyPrices(), tC.config)
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			if err != nil {
+				return
+			}
+			diff = cmp.Diff([]outcome{tC.drip, tC.cash}, []outcome{summarize(s.Drip), summarize(s.Cash)}, cmpopts.EquateApprox(0, 1e-9))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestForecastIncome(t *testing.T) {
+	forecasts, total := ForecastIncome(testDividends(), 10000, date(time.December, 31))
+	expected := []Forecast{
+		{Event: "Special", ExDate: time.Date(2021, time.March, 20, 0, 0, 0, 0, time.UTC), PerShare: 0.5, Income: 5000},
+		{Event: "Final", ExDate: time.Date(2021, time.May, 18, 0, 0, 0, 0, time.UTC), PerShare: 2.03, Income: 20300},
+		{Event: "Interim", ExDate: time.Date(2021, time.September, 3, 0, 0, 0, 0, time.UTC), PerShare: 0.77, Income: 7700},
+	}
+	diff := cmp.Diff(expected, forecasts, cmpopts.EquateApprox(0, 1e-9))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff(33000.0, total, cmpopts.EquateApprox(0, 1e-9))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,131 @@
+package portfolio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/horacehylee/aastocks/internal/atomicfile"
+)
+
+// Side of trade.
+type Side string
+
+const (
+	// Buy side
+	Buy Side = "buy"
+	// Sell side
+	Sell Side = "sell"
+)
+
+// Trade of symbol recorded in ledger.
+type Trade struct {
+	Date     time.Time `json:"date"`
+	Symbol   string    `json:"symbol"`
+	Side     Side      `json:"side"`
+	Quantity int       `json:"quantity"`
+	Price    float64   `json:"price"`
+	// Fees of the trade (i.e. commission and stamp duty, see hkex.CostModel),
+	// which are added to cost of buys and deducted from proceeds of sells.
+	Fees float64 `json:"fees"`
+}
+
+// Ledger of trades.
+type Ledger struct {
+	Trades []Trade `json:"trades"`
+}
+
+// Add trade to ledger.
+// Trade is rejected if it sells more than quantity held on its date.
+func (l *Ledger) Add(t Trade) error {
+	if t.Symbol == "" {
+		return fmt.Errorf("Symbol of trade cannot be empty")
+	}
+	if t.Side != Buy && t.Side != Sell {
+		return fmt.Errorf("Side of trade is unknown: %v", t.Side)
+	}
+	if t.Quantity <= 0 || t.Price < 0 || t.Fees < 0 {
+		return fmt.Errorf("Quantity of trade must be positive, and price and fees cannot be negative")
+	}
+	trades := append(l.Trades[:len(l.Trades):len(l.Trades)], t)
+	_, err := positions(trades, FIFO)
+	if err != nil {
+		return err
+	}
+	l.Trades = trades
+	return nil
+}
+
+// Symbols traded in ledger, in ascending order.
+func (l *Ledger) Symbols() []string {
+	seen := make(map[string]bool)
+	symbols := make([]string, 0)
+	for _, t := range l.Trades {
+		if !seen[t.Symbol] {
+			seen[t.Symbol] = true
+			symbols = append(symbols, t.Symbol)
+		}
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// QuantityAt returns quantity of symbol held before the date, i.e. entitled to dividends of the ex-date.
+func (l *Ledger) QuantityAt(symbol string, date time.Time) int {
+	quantity := 0
+	for _, t := range l.Trades {
+		if t.Symbol != symbol || !t.Date.Before(date) {
+			continue
+		}
+		if t.Side == Buy {
+			quantity += t.Quantity
+		} else {
+			quantity -= t.Quantity
+		}
+	}
+	return quantity
+}
+
+// sorted returns trades in ascending order of dates, trades on the same date are kept in the order recorded.
+func sorted(trades []Trade) []Trade {
+	s := make([]Trade, len(trades))
+	copy(s, trades)
+	sort.SliceStable(s, func(i, j int) bool {
+		return s[i].Date.Before(s[j].Date)
+	})
+	return s
+}
+
+// LoadLedger loads ledger from JSON file, empty ledger is returned if the file does not exist.
+func LoadLedger(name string) (*Ledger, error) {
+	b, err := ioutil.ReadFile(name)
+	if os.IsNotExist(err) {
+		return &Ledger{Trades: make([]Trade, 0)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	l := &Ledger{}
+	err = json.Unmarshal(b, l)
+	if err != nil {
+		return nil, fmt.Errorf("Ledger failed to be decoded: %v", err)
+	}
+	_, err = positions(l.Trades, FIFO)
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Save ledger to JSON file.
+// File is replaced atomically, so partially written ledger will not be loaded.
+func (l *Ledger) Save(name string) error {
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(name, b)
+}
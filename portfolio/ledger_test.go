@@ -0,0 +1,109 @@
+package portfolio
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLedgerAdd(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		trade Trade
+		err   error
+	}{
+		{
+			desc:  "Buy",
+			trade: Trade{Date: date(time.July, 1), Symbol: "00006", Side: Buy, Quantity: 500, Price: 44},
+		},
+		{
+			desc:  "Sell",
+			trade: Trade{Date: date(time.July, 1), Symbol: "00006", Side: Sell, Quantity: 500, Price: 44},
+		},
+		{
+			desc:  "Oversold",
+			trade: Trade{Date: date(time.July, 1), Symbol: "00005", Side: Sell, Quantity: 500, Price: 30},
+			err:   fmt.Errorf("Quantity sold is more than held for 00005 on 2020-07-01: 500 > 400"),
+		},
+		{
+			desc:  "UnknownSide",
+			trade: Trade{Date: date(time.July, 1), Symbol: "00005", Side: "short", Quantity: 500, Price: 30},
+			err:   fmt.Errorf("Side of trade is unknown: short"),
+		},
+		{
+			desc:  "ZeroQuantity",
+			trade: Trade{Date: date(time.July, 1), Symbol: "00005", Side: Buy, Price: 30},
+			err:   fmt.Errorf("Quantity of trade must be positive, and price and fees cannot be negative"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			l := &Ledger{Trades: testTrades()}
+			err := l.Add(tC.trade)
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			expected := len(testTrades()) + 1
+			if err != nil {
+				expected = len(testTrades())
+			}
+			if len(l.Trades) != expected {
+				t.Fatalf("expected %v trades, but got %v", expected, len(l.Trades))
+			}
+		})
+	}
+}
+
+func TestLedgerQuantityAt(t *testing.T) {
+	l := &Ledger{Trades: testTrades()}
+	quantities := []int{
+		l.QuantityAt("00006", date(time.March, 2)),
+		l.QuantityAt("00006", date(time.March, 3)),
+		l.QuantityAt("00006", date(time.April, 2)),
+		l.QuantityAt("00006", date(time.June, 2)),
+	}
+	diff := cmp.Diff([]int{0, 1000, 1500, 500}, quantities)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff([]string{"00005", "00006"}, l.Symbols())
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestLedgerFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "portfolio")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "ledger.json")
+
+	l, err := LoadLedger(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Trades) != 0 {
+		t.Fatalf("ledger should be empty when file does not exist")
+	}
+	l.Trades = testTrades()
+	err = l.Save(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadLedger(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff(l, loaded)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
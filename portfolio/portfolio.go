@@ -0,0 +1,190 @@
+// Package portfolio tracks holdings of trades recorded in ledger,
+// with cost basis, profit and loss, dividend income and allocation by industry.
+//
+//	ledger, err := portfolio.LoadLedger("ledger.json")
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	err = ledger.Add(portfolio.Trade{
+//		Date:     time.Now(),
+//		Symbol:   "00006",
+//		Side:     portfolio.Buy,
+//		Quantity: 500,
+//		Price:    44.65,
+//		Fees:     50.12,
+//	})
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	err = ledger.Save("ledger.json")
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	v, err := portfolio.Fetch(ledger, portfolio.FIFO, time.Now())
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	logger.Printf("value: %v, unrealised: %v\n", v.Value, v.Unrealised)
+//
+// Dividend reinvestment (DRIP) can be simulated against cash payout for holding,
+// and income of next 12 months can be forecasted from dividends of the last 12 months.
+//
+//	s, err := portfolio.FetchDrip(quote, portfolio.DripConfig{Quantity: 10000, At: portfolio.AtPayableDate})
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	logger.Printf("drip: %v, cash: %v\n", s.Drip.Value, s.Cash.Value)
+package portfolio
+
+import (
+	"sort"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Income of dividend credited to holdings on its ex-date.
+type Income struct {
+	Symbol   string
+	Dividend aastocks.Dividend
+	// Quantity held before the ex-date.
+	Quantity int
+	Currency string
+	Amount   float64
+}
+
+// Allocation of market value in industry.
+type Allocation struct {
+	Industry string
+	Value    float64
+	// Weight of value as fraction of total market value.
+	Weight float64
+}
+
+// Unclassified is the industry of quotes without industry (i.e. ETFs).
+const Unclassified = "Unclassified"
+
+// Valuation of portfolio.
+type Valuation struct {
+	Time      time.Time
+	Positions []Position
+	// Income of cash dividends, in ascending order of ex-dates.
+	Income     []Income
+	Allocation []Allocation
+	// Totals of positions, dividends in currencies other than HKD are excluded.
+	Cost       float64
+	Value      float64
+	Realised   float64
+	Unrealised float64
+	Dividends  float64
+}
+
+// Value ledger with cost basis method at the time, by marking positions to market with prices of quotes
+// and crediting cash dividends with ex-dates up to the time.
+// Quotes and dividends are keyed by symbols, positions without quotes are valued at their cost.
+func Value(ledger *Ledger, method Method, quotes map[string]*aastocks.Quote, dividends map[string][]aastocks.Dividend, t time.Time) (*Valuation, error) {
+	trades := make([]Trade, 0, len(ledger.Trades))
+	for _, trade := range ledger.Trades {
+		if !trade.Date.After(t) {
+			trades = append(trades, trade)
+		}
+	}
+	held := &Ledger{Trades: trades}
+	positions, err := positions(trades, method)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Valuation{
+		Time:       t,
+		Positions:  positions,
+		Income:     make([]Income, 0),
+		Allocation: make([]Allocation, 0),
+	}
+	industries := make(map[string]float64)
+	for i := range v.Positions {
+		p := &v.Positions[i]
+		p.Price = p.AverageCost()
+		p.Industry = Unclassified
+		if q, ok := quotes[p.Symbol]; ok {
+			p.Price = q.Price
+			if q.Industry != "" {
+				p.Industry = q.Industry
+			}
+		}
+		p.Value = p.Price * float64(p.Quantity)
+		p.Unrealised = p.Value - p.Cost
+
+		for _, d := range dividends[p.Symbol] {
+			if d.ExDate.IsZero() || d.ExDate.After(t) {
+				continue
+			}
+			currency, amount, ok := d.Cash()
+			if !ok {
+				continue
+			}
+			quantity := held.QuantityAt(p.Symbol, d.ExDate)
+			if quantity <= 0 {
+				continue
+			}
+			income := Income{
+				Symbol:   p.Symbol,
+				Dividend: d,
+				Quantity: quantity,
+				Currency: currency,
+				Amount:   amount * float64(quantity),
+			}
+			v.Income = append(v.Income, income)
+			if currency == "HKD" {
+				p.Dividends += income.Amount
+			}
+		}
+
+		v.Cost += p.Cost
+		v.Value += p.Value
+		v.Realised += p.Realised
+		v.Unrealised += p.Unrealised
+		v.Dividends += p.Dividends
+		if p.Quantity > 0 {
+			industries[p.Industry] += p.Value
+		}
+	}
+	sort.SliceStable(v.Income, func(i, j int) bool {
+		return v.Income[i].Dividend.ExDate.Before(v.Income[j].Dividend.ExDate)
+	})
+
+	for industry, value := range industries {
+		a := Allocation{Industry: industry, Value: value}
+		if v.Value != 0 {
+			a.Weight = value / v.Value
+		}
+		v.Allocation = append(v.Allocation, a)
+	}
+	sort.Slice(v.Allocation, func(i, j int) bool {
+		if v.Allocation[i].Value != v.Allocation[j].Value {
+			return v.Allocation[i].Value > v.Allocation[j].Value
+		}
+		return v.Allocation[i].Industry < v.Allocation[j].Industry
+	})
+	return v, nil
+}
+
+// Fetch quotes and dividends of symbols in ledger from AAStocks, and value the ledger at the time.
+// Options are applied to quotes fetched (i.e. WithClient).
+func Fetch(ledger *Ledger, method Method, t time.Time, opts ...aastocks.Option) (*Valuation, error) {
+	quotes := make(map[string]*aastocks.Quote)
+	dividends := make(map[string][]aastocks.Dividend)
+	for _, symbol := range ledger.Symbols() {
+		q, err := aastocks.Get(symbol, opts...)
+		if err != nil {
+			return nil, err
+		}
+		d, err := q.Dividends()
+		if err != nil {
+			return nil, err
+		}
+		quotes[symbol] = q
+		dividends[symbol] = d
+	}
+	return Value(ledger, method, quotes, dividends, t)
+}
@@ -0,0 +1,113 @@
+package portfolio
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/horacehylee/aastocks"
+)
+
+// mockTransport serves files of urls.
+type mockTransport map[string]string
+
+func (m mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, ok := m[req.URL.String()]
+	if !ok {
+		return nil, fmt.Errorf("Handler not found for %s", req.URL)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	recorder.Write(b)
+	return recorder.Result(), nil
+}
+
+func TestValue(t *testing.T) {
+	ledger := &Ledger{Trades: testTrades()}
+	quotes := map[string]*aastocks.Quote{
+		"00006": {Symbol: "00006", Industry: "Electricity Supply", Price: 44},
+	}
+	special := aastocks.Dividend{Event: "Special", Particular: "D:HKD 0.5000", ExDate: date(time.March, 20)}
+	final := aastocks.Dividend{Event: "Final", Particular: "D:HKD 2.0300", ExDate: date(time.May, 18)}
+	dividends := map[string][]aastocks.Dividend{
+		"00006": {
+			{Event: "Interim", Particular: "D:HKD 0.7700", ExDate: date(time.September, 3)},
+			final,
+			{Event: "Special", Particular: "Preferential Offer", ExDate: date(time.March, 10)},
+			special,
+			{Event: "Interim", Particular: "D:HKD 0.6500", ExDate: time.Date(2013, time.August, 23, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	v, err := Value(ledger, FIFO, quotes, dividends, date(time.July, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Valuation{
+		Time: date(time.July, 1),
+		Positions: []Position{
+			// Valued at cost without quote
+			{Symbol: "00005", Quantity: 400, Cost: 12004, Industry: Unclassified, Price: 30.01, Value: 12004},
+			{Symbol: "00006", Quantity: 500, Cost: 25005, Realised: 7978, Industry: "Electricity Supply", Price: 44, Value: 22000, Unrealised: -3005, Dividends: 500 + 3045},
+		},
+		Income: []Income{
+			{Symbol: "00006", Dividend: special, Quantity: 1000, Currency: "HKD", Amount: 500},
+			{Symbol: "00006", Dividend: final, Quantity: 1500, Currency: "HKD", Amount: 3045},
+		},
+		Allocation: []Allocation{
+			{Industry: "Electricity Supply", Value: 22000, Weight: 22000.0 / 34004},
+			{Industry: Unclassified, Value: 12004, Weight: 12004.0 / 34004},
+		},
+		Cost:       37009,
+		Value:      34004,
+		Realised:   7978,
+		Unrealised: -3005,
+		Dividends:  3545,
+	}
+	diff := cmp.Diff(expected, v, cmpopts.EquateApprox(0, 1e-9))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestValueBeforeTrades(t *testing.T) {
+	ledger := &Ledger{Trades: testTrades()}
+	v, err := Value(ledger, AverageCost, nil, nil, date(time.April, 1).Add(-time.Nanosecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff([]interface{}{1, 40010.0}, []interface{}{len(v.Positions), v.Cost})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	client := &http.Client{
+		Transport: mockTransport{
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": "../testdata/detail_quote.html",
+			"http://www.aastocks.com/en/stocks/analysis/dividend.aspx?symbol=00006":  "../testdata/dividend.html",
+		},
+	}
+	ledger := &Ledger{
+		Trades: []Trade{
+			{Date: date(time.August, 3), Symbol: "00006", Side: Buy, Quantity: 1000, Price: 42},
+		},
+	}
+	v, err := Fetch(ledger, FIFO, date(time.September, 30), aastocks.WithClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff([]interface{}{44650.0, 2650.0, 770.0}, []interface{}{v.Value, v.Unrealised, v.Dividends}, cmpopts.EquateApprox(0, 1e-9))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
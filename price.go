@@ -7,9 +7,71 @@ import (
 
 // PriceResult is the result of serving real time prices.
 type PriceResult struct {
-	Price  float64
-	Symbol string
-	Time   time.Time
+	Price  float64   `json:"price"`
+	Symbol string    `json:"symbol"`
+	Time   time.Time `json:"time"`
+}
+
+// Schedule of market for serving prices.
+type Schedule interface {
+	// NextOpen returns the time itself if market is open, otherwise the time market is opened next.
+	NextOpen(t time.Time) time.Time
+}
+
+// ServeOption for serving real time prices.
+type ServeOption func(c *serveConfig)
+
+type serveConfig struct {
+	schedule    Schedule
+	bufferSize  int
+	dropPolicy  DropPolicy
+	onlyChanges bool
+	pacer       Pacer
+	limiter     *RateLimiter
+	now         func() time.Time
+}
+
+func newServeConfig(opts []ServeOption) *serveConfig {
+	c := &serveConfig{
+		now: time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithSchedule to fetch prices only when market is open,
+// it sleeps until the next session is opened when market is closed.
+func WithSchedule(schedule Schedule) ServeOption {
+	return func(c *serveConfig) {
+		c.schedule = schedule
+	}
+}
+
+// untilOpen returns duration until market is opened, zero if it is open now.
+func (c *serveConfig) untilOpen() time.Duration {
+	if c.schedule == nil {
+		return 0
+	}
+	now := c.now()
+	return c.schedule.NextOpen(now).Sub(now)
+}
+
+// nextDelay returns delay before the next poll, which is fixed delay if there is no pacer.
+func (c *serveConfig) nextDelay(delay time.Duration, latest *Quote, err error) time.Duration {
+	if c.pacer == nil {
+		return delay
+	}
+	return c.pacer.Next(latest, err)
+}
+
+// wait for rate limiter if there is any.
+func (c *serveConfig) wait(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
+	}
+	return c.limiter.Wait(ctx)
 }
 
 func (q *Quote) clone() *Quote {
@@ -21,9 +83,11 @@ func (q *Quote) clone() *Quote {
 
 // ServePrices continuously fetching latest price from AAStocks.
 // It will start goroutine to fetch real time prices.
-func (q *Quote) ServePrices(ctx context.Context, delay time.Duration) (<-chan PriceResult, <-chan error) {
+// Channels are never closed, StreamPrices should be used for explicit lifecycle of the goroutine.
+func (q *Quote) ServePrices(ctx context.Context, delay time.Duration, opts ...ServeOption) (<-chan PriceResult, <-chan error) {
 	prices := make(chan PriceResult)
 	errors := make(chan error)
+	config := newServeConfig(opts)
 
 	go func() {
 		var priceChan chan<- PriceResult
@@ -44,7 +108,18 @@ func (q *Quote) ServePrices(ctx context.Context, delay time.Duration) (<-chan Pr
 				priceChan = nil
 				price = PriceResult{}
 			case <-timeout:
-				err = qq.details()
+				wait := config.untilOpen()
+				if wait > 0 {
+					timeout = time.After(wait)
+					continue
+				}
+				if config.wait(ctx) != nil {
+					return
+				}
+				err = qq.details(ctx)
+				if ctx.Err() != nil {
+					return
+				}
 				if err != nil {
 					errChan = errors
 				} else {
@@ -55,7 +130,7 @@ func (q *Quote) ServePrices(ctx context.Context, delay time.Duration) (<-chan Pr
 					}
 					priceChan = prices
 				}
-				timeout = time.After(delay)
+				timeout = time.After(config.nextDelay(delay, qq, err))
 			}
 		}
 	}()
@@ -15,6 +15,7 @@ func TestServePrices(t *testing.T) {
 		desc     string
 		symbol   string
 		requests map[string]http.HandlerFunc
+		opts     []ServeOption
 		err      error
 		prices   []PriceResult
 	}{
@@ -42,6 +43,33 @@ func TestServePrices(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:   "WithSchedule",
+			symbol: "00006",
+			requests: map[string]http.HandlerFunc{
+				"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": serveAll(
+					serveFile("testdata/detail_quote.html"), // First called with getting quote
+
+					serveFile("testdata/detail_quote.html"),
+					serveFile("testdata/detail_quote_00006_2.html"),
+				),
+			},
+			opts: []ServeOption{
+				WithSchedule(&closedSchedule{closed: 2}),
+			},
+			prices: []PriceResult{
+				{
+					Symbol: "00006",
+					Price:  44.65,
+					Time:   time.Date(2020, time.August, 25, 21, 18, 38, 0, time.UTC),
+				},
+				{
+					Symbol: "00006",
+					Price:  44.4,
+					Time:   time.Date(2020, time.August, 29, 00, 55, 31, 0, time.UTC),
+				},
+			},
+		},
 		{
 			desc:   "WithErrorChannel",
 			symbol: "00006",
@@ -53,7 +81,7 @@ func TestServePrices(t *testing.T) {
 					serveError(fmt.Errorf("testing error")),
 				),
 			},
-			err: fmt.Errorf("Failed to fetch quote details"),
+			err: &StatusError{StatusCode: 500, Err: fmt.Errorf("Failed to fetch quote details: 500 Internal Server Error")},
 			prices: []PriceResult{
 				{
 					Symbol: "00006",
@@ -79,7 +107,7 @@ func TestServePrices(t *testing.T) {
 				ctx, cancel := context.WithCancel(ctx)
 				defer cancel()
 
-				pricesChan, errChan := quote.ServePrices(ctx, 100*time.Millisecond)
+				pricesChan, errChan := quote.ServePrices(ctx, 100*time.Millisecond, tC.opts...)
 
 				prices := make([]PriceResult, 0)
 				timeout := time.After(2 * time.Second)
@@ -110,3 +138,16 @@ func TestServePrices(t *testing.T) {
 		})
 	}
 }
+
+// closedSchedule is closed for number of times checked, before it is opened.
+type closedSchedule struct {
+	closed int
+}
+
+func (s *closedSchedule) NextOpen(t time.Time) time.Time {
+	if s.closed > 0 {
+		s.closed--
+		return t.Add(50 * time.Millisecond)
+	}
+	return t
+}
@@ -0,0 +1,104 @@
+package aastocks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits rate of requests to AAStocks with token bucket.
+// It is safe for concurrent use, and can be shared to limit requests globally.
+type RateLimiter struct {
+	interval time.Duration
+	burst    int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+	stats  RateLimiterStats
+}
+
+// RateLimiterStats of requests waited for rate limiter, which are accumulated since it is created.
+type RateLimiterStats struct {
+	// Waits is number of requests waited for token
+	Waits int64
+	// Waited is total duration waited by requests
+	Waited time.Duration
+}
+
+// NewRateLimiter creates rate limiter allowing one request per interval, with burst of requests at most.
+func NewRateLimiter(interval time.Duration, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		interval: interval,
+		burst:    burst,
+		tokens:   float64(burst),
+		now:      time.Now,
+	}
+}
+
+// reserve takes a token if it is available, otherwise returns duration until it is available.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.last.IsZero() && l.interval > 0 {
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+		if l.tokens > float64(l.burst) {
+			l.tokens = float64(l.burst)
+		}
+	}
+	l.last = now
+	if l.tokens >= 1 || l.interval <= 0 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) * float64(l.interval))
+}
+
+// Allow checks if request can be made now, a token is taken if it is allowed.
+func (l *RateLimiter) Allow() bool {
+	return l.reserve() == 0
+}
+
+// Wait until request can be made, or context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	var start time.Time
+	for {
+		wait := l.reserve()
+		if wait == 0 {
+			if !start.IsZero() {
+				l.waited(start)
+			}
+			return nil
+		}
+		if start.IsZero() {
+			start = l.now()
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			l.waited(start)
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (l *RateLimiter) waited(start time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stats.Waits++
+	l.stats.Waited += l.now().Sub(start)
+}
+
+// Stats of requests waited for the rate limiter.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
@@ -0,0 +1,74 @@
+package aastocks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2020, time.August, 25, 0, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(time.Second, 2)
+	l.now = func() time.Time {
+		return now
+	}
+
+	allowed := []bool{l.Allow(), l.Allow(), l.Allow()}
+	diff := cmp.Diff([]bool{true, true, false}, allowed)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	diff = cmp.Diff(500*time.Millisecond, l.reserve())
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	diff = cmp.Diff(true, l.Allow())
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(time.Hour, 1)
+	err := l.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = l.Wait(ctx)
+	diff := cmp.Diff(context.DeadlineExceeded, err, equateErrorMessage)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	// Request waited is counted even if context is done
+	diff = cmp.Diff(int64(1), l.Stats().Waits)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestRateLimiterStats(t *testing.T) {
+	l := NewRateLimiter(20*time.Millisecond, 1)
+	for i := 0; i < 3; i++ {
+		err := l.Wait(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	stats := l.Stats()
+	diff := cmp.Diff(int64(2), stats.Waits)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	if stats.Waited < 30*time.Millisecond {
+		t.Fatalf("Waited should be at least 30ms, but got %v", stats.Waited)
+	}
+}
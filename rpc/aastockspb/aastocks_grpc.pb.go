@@ -0,0 +1,243 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package aastockspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// MarketDataClient is the client API for MarketData service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MarketDataClient interface {
+	// GetQuote returns quote of the symbol, NOT_FOUND is returned if the symbol cannot be found.
+	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*Quote, error)
+	// GetDividends returns dividends of the symbol, from the latest announced.
+	GetDividends(ctx context.Context, in *GetDividendsRequest, opts ...grpc.CallOption) (*GetDividendsResponse, error)
+	// GetHistory returns historical prices of the symbol within the range.
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// StreamPrices streams live prices of the symbols, each symbol is polled once for all streams.
+	// Latest prices of symbols already polled are sent immediately.
+	StreamPrices(ctx context.Context, in *StreamPricesRequest, opts ...grpc.CallOption) (MarketData_StreamPricesClient, error)
+}
+
+type marketDataClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMarketDataClient(cc grpc.ClientConnInterface) MarketDataClient {
+	return &marketDataClient{cc}
+}
+
+func (c *marketDataClient) GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*Quote, error) {
+	out := new(Quote)
+	err := c.cc.Invoke(ctx, "/aastocks.v1.MarketData/GetQuote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketDataClient) GetDividends(ctx context.Context, in *GetDividendsRequest, opts ...grpc.CallOption) (*GetDividendsResponse, error) {
+	out := new(GetDividendsResponse)
+	err := c.cc.Invoke(ctx, "/aastocks.v1.MarketData/GetDividends", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketDataClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, "/aastocks.v1.MarketData/GetHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketDataClient) StreamPrices(ctx context.Context, in *StreamPricesRequest, opts ...grpc.CallOption) (MarketData_StreamPricesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_MarketData_serviceDesc.Streams[0], "/aastocks.v1.MarketData/StreamPrices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &marketDataStreamPricesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MarketData_StreamPricesClient interface {
+	Recv() (*PriceEvent, error)
+	grpc.ClientStream
+}
+
+type marketDataStreamPricesClient struct {
+	grpc.ClientStream
+}
+
+func (x *marketDataStreamPricesClient) Recv() (*PriceEvent, error) {
+	m := new(PriceEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MarketDataServer is the server API for MarketData service.
+// All implementations must embed UnimplementedMarketDataServer
+// for forward compatibility
+type MarketDataServer interface {
+	// GetQuote returns quote of the symbol, NOT_FOUND is returned if the symbol cannot be found.
+	GetQuote(context.Context, *GetQuoteRequest) (*Quote, error)
+	// GetDividends returns dividends of the symbol, from the latest announced.
+	GetDividends(context.Context, *GetDividendsRequest) (*GetDividendsResponse, error)
+	// GetHistory returns historical prices of the symbol within the range.
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// StreamPrices streams live prices of the symbols, each symbol is polled once for all streams.
+	// Latest prices of symbols already polled are sent immediately.
+	StreamPrices(*StreamPricesRequest, MarketData_StreamPricesServer) error
+	mustEmbedUnimplementedMarketDataServer()
+}
+
+// UnimplementedMarketDataServer must be embedded to have forward compatible implementations.
+type UnimplementedMarketDataServer struct {
+}
+
+func (UnimplementedMarketDataServer) GetQuote(context.Context, *GetQuoteRequest) (*Quote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuote not implemented")
+}
+func (UnimplementedMarketDataServer) GetDividends(context.Context, *GetDividendsRequest) (*GetDividendsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDividends not implemented")
+}
+func (UnimplementedMarketDataServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedMarketDataServer) StreamPrices(*StreamPricesRequest, MarketData_StreamPricesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPrices not implemented")
+}
+func (UnimplementedMarketDataServer) mustEmbedUnimplementedMarketDataServer() {}
+
+// UnsafeMarketDataServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MarketDataServer will
+// result in compilation errors.
+type UnsafeMarketDataServer interface {
+	mustEmbedUnimplementedMarketDataServer()
+}
+
+func RegisterMarketDataServer(s grpc.ServiceRegistrar, srv MarketDataServer) {
+	s.RegisterService(&_MarketData_serviceDesc, srv)
+}
+
+func _MarketData_GetQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketDataServer).GetQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aastocks.v1.MarketData/GetQuote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketDataServer).GetQuote(ctx, req.(*GetQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketData_GetDividends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDividendsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketDataServer).GetDividends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aastocks.v1.MarketData/GetDividends",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketDataServer).GetDividends(ctx, req.(*GetDividendsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketData_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketDataServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aastocks.v1.MarketData/GetHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketDataServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketData_StreamPrices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPricesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MarketDataServer).StreamPrices(m, &marketDataStreamPricesServer{stream})
+}
+
+type MarketData_StreamPricesServer interface {
+	Send(*PriceEvent) error
+	grpc.ServerStream
+}
+
+type marketDataStreamPricesServer struct {
+	grpc.ServerStream
+}
+
+func (x *marketDataStreamPricesServer) Send(m *PriceEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _MarketData_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "aastocks.v1.MarketData",
+	HandlerType: (*MarketDataServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetQuote",
+			Handler:    _MarketData_GetQuote_Handler,
+		},
+		{
+			MethodName: "GetDividends",
+			Handler:    _MarketData_GetDividends_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _MarketData_GetHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPrices",
+			Handler:       _MarketData_StreamPrices_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "aastocks.proto",
+}
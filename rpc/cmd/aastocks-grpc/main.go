@@ -0,0 +1,63 @@
+// Command aastocks-grpc serves market data of AAStocks over gRPC, with service defined in aastocks.proto.
+// Requests to AAStocks are cached and rate limited, and live prices are polled once per symbol.
+//
+// Usage:
+//
+//	aastocks-grpc -addr :9090 -ttl 30s -interval 200ms -burst 5 -live 5s
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/rpc"
+	"github.com/horacehylee/aastocks/rpc/aastockspb"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "address to listen on")
+	ttl := flag.Duration("ttl", 30*time.Second, "duration which responses of AAStocks are cached for")
+	interval := flag.Duration("interval", 200*time.Millisecond, "interval between requests to AAStocks")
+	burst := flag.Int("burst", 5, "burst of requests to AAStocks")
+	liveInterval := flag.Duration("live", 5*time.Second, "interval between polls of live prices")
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "", log.Flags())
+	limiter := aastocks.NewRateLimiter(*interval, *burst)
+	client := aastocks.NewCachedClient(*ttl, limiter)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hub := aastocks.NewPriceHub(ctx, aastocks.HubConfig{
+		Interval: *liveInterval,
+		Limiter:  limiter,
+	})
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	server := grpc.NewServer()
+	aastockspb.RegisterMarketDataServer(server, rpc.NewServer(hub, aastocks.WithClient(client)))
+
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt)
+		<-c
+		// Streams of live prices are ended with the hub, so that graceful stop is not blocked by them
+		cancel()
+		server.GracefulStop()
+	}()
+
+	logger.Printf("Listening on %v\n", *addr)
+	err = server.Serve(lis)
+	if err != nil {
+		logger.Fatal(err)
+	}
+}
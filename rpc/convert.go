@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/rpc/aastockspb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// timestamp converts wall clock of AAStocks to timestamp in Hong Kong, nil is returned for zero time.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(aastocks.HongKongTime(t))
+}
+
+// timeOf converts timestamp to wall clock of AAStocks, zero time is returned if it is not set.
+func timeOf(ts *timestamppb.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	err := ts.CheckValid()
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "Timestamp is invalid: %v", err)
+	}
+	return aastocks.WallClock(ts.AsTime()), nil
+}
+
+func newQuote(q *aastocks.Quote) *aastockspb.Quote {
+	return &aastockspb.Quote{
+		Symbol:        q.Symbol,
+		Name:          q.Name,
+		Industry:      q.Industry,
+		Price:         q.Price,
+		PrevClose:     q.PrevClose,
+		Price_52WLow:  q.Price52WLow,
+		Price_52WHigh: q.Price52WHigh,
+		Yield:         q.Yield,
+		PeRatio:       q.PeRatio,
+		PbRatio:       q.PbRatio,
+		Lots:          int32(q.Lots),
+		Eps:           q.Eps,
+		UpdateTime:    timestamp(q.UpdateTime),
+	}
+}
+
+func newDividend(d aastocks.Dividend) *aastockspb.Dividend {
+	return &aastockspb.Dividend{
+		AnnounceDate: timestamp(d.AnnounceDate),
+		YearEnded:    timestamp(d.YearEnded),
+		Event:        d.Event,
+		Particular:   d.Particular,
+		Type:         d.Type,
+		ExDate:       timestamp(d.ExDate),
+		PayableDate:  timestamp(d.PayableDate),
+	}
+}
+
+func newHistoricalPrice(p aastocks.HistoricalPrice) *aastockspb.HistoricalPrice {
+	return &aastockspb.HistoricalPrice{
+		Time:   timestamp(p.Time),
+		Open:   p.Open,
+		High:   p.High,
+		Low:    p.Low,
+		Close:  p.Close,
+		Volume: p.Volume,
+	}
+}
+
+func newPriceEvent(e aastocks.PriceEvent) *aastockspb.PriceEvent {
+	event := &aastockspb.PriceEvent{
+		Symbol: e.Price.Symbol,
+	}
+	if e.Err != nil {
+		event.Error = e.Err.Error()
+		return event
+	}
+	event.Price = e.Price.Price
+	event.Time = timestamp(e.Price.Time)
+	if e.Quote != nil {
+		event.Quote = newQuote(e.Quote)
+	}
+	for _, change := range e.Changes {
+		event.Changes = append(event.Changes, change.Field)
+	}
+	return event
+}
@@ -0,0 +1,24 @@
+module github.com/horacehylee/aastocks/rpc
+
+go 1.17
+
+require (
+	github.com/golang/protobuf v1.4.1
+	github.com/google/go-cmp v0.5.2
+	github.com/horacehylee/aastocks v0.1.0
+	google.golang.org/grpc v1.33.2
+	google.golang.org/protobuf v1.25.0
+)
+
+require (
+	github.com/PuerkitoBio/goquery v1.5.1 // indirect
+	github.com/andybalholm/cascadia v1.1.0 // indirect
+	golang.org/x/net v0.0.0-20200202094626-16171245cfb2 // indirect
+	golang.org/x/sys v0.0.0-20190215142949-d0b11bdaac8a // indirect
+	golang.org/x/text v0.3.0 // indirect
+	golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543 // indirect
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013 // indirect
+)
+
+// Root module in the same repository is used for development, tagged version is required otherwise
+replace github.com/horacehylee/aastocks => ../
@@ -0,0 +1,156 @@
+// Package rpc serves market data of AAStocks over gRPC, with service defined in aastocks.proto.
+// Clients of other languages can be generated from aastocks.proto.
+//
+//	hub := aastocks.NewPriceHub(ctx, aastocks.HubConfig{Interval: 5 * time.Second})
+//	s := grpc.NewServer()
+//	aastockspb.RegisterMarketDataServer(s, rpc.NewServer(hub))
+//	s.Serve(lis)
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative aastocks.proto
+//go:generate mv aastocks.pb.go aastocks_grpc.pb.go aastockspb/
+
+import (
+	"context"
+	"errors"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/rpc/aastockspb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server of market data service.
+type Server struct {
+	aastockspb.UnimplementedMarketDataServer
+	hub  *aastocks.PriceHub
+	opts []aastocks.Option
+}
+
+// NewServer creates server of market data service, with live prices streamed from the hub.
+// Options are applied to quotes fetched by the server (i.e. WithClient).
+func NewServer(hub *aastocks.PriceHub, opts ...aastocks.Option) *Server {
+	return &Server{hub: hub, opts: opts}
+}
+
+// statusOf converts error to gRPC status error.
+func statusOf(err error) error {
+	switch {
+	case errors.Is(err, aastocks.ErrSymbolNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+func (s *Server) get(symbol string) (*aastocks.Quote, error) {
+	if symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "Symbol is not provided")
+	}
+	q, err := aastocks.Get(symbol, s.opts...)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return q, nil
+}
+
+// GetQuote returns quote of the symbol.
+func (s *Server) GetQuote(ctx context.Context, req *aastockspb.GetQuoteRequest) (*aastockspb.Quote, error) {
+	q, err := s.get(req.GetSymbol())
+	if err != nil {
+		return nil, err
+	}
+	return newQuote(q), nil
+}
+
+// GetDividends returns dividends of the symbol.
+func (s *Server) GetDividends(ctx context.Context, req *aastockspb.GetDividendsRequest) (*aastockspb.GetDividendsResponse, error) {
+	q, err := s.get(req.GetSymbol())
+	if err != nil {
+		return nil, err
+	}
+	dividends, err := q.Dividends()
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	resp := &aastockspb.GetDividendsResponse{
+		Dividends: make([]*aastockspb.Dividend, len(dividends)),
+	}
+	for i, d := range dividends {
+		resp.Dividends[i] = newDividend(d)
+	}
+	return resp, nil
+}
+
+var frequencies = map[aastockspb.Frequency]aastocks.PriceFrequency{
+	aastockspb.Frequency_FREQUENCY_UNSPECIFIED: aastocks.Daily,
+	aastockspb.Frequency_FREQUENCY_HOURLY:      aastocks.Hourly,
+	aastockspb.Frequency_FREQUENCY_DAILY:       aastocks.Daily,
+	aastockspb.Frequency_FREQUENCY_WEEKLY:      aastocks.Weekly,
+	aastockspb.Frequency_FREQUENCY_MONTHLY:     aastocks.Monthly,
+}
+
+// GetHistory returns historical prices of the symbol within the range.
+func (s *Server) GetHistory(ctx context.Context, req *aastockspb.GetHistoryRequest) (*aastockspb.GetHistoryResponse, error) {
+	frequency, ok := frequencies[req.GetFrequency()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "Frequency is unknown: %v", req.GetFrequency())
+	}
+	from, err := timeOf(req.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	to, err := timeOf(req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+	q, err := s.get(req.GetSymbol())
+	if err != nil {
+		return nil, err
+	}
+	prices, err := q.HistoricalPrices(frequency)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	resp := &aastockspb.GetHistoryResponse{
+		Prices: make([]*aastockspb.HistoricalPrice, 0, len(prices)),
+	}
+	for _, p := range prices {
+		if (!from.IsZero() && p.Time.Before(from)) || (!to.IsZero() && !p.Time.Before(to)) {
+			continue
+		}
+		resp.Prices = append(resp.Prices, newHistoricalPrice(p))
+	}
+	return resp, nil
+}
+
+// StreamPrices streams live prices of the symbols from the hub, until the client cancels or the hub is ended.
+// Events are dropped by the hub while the client is slow, instead of stalling polling.
+func (s *Server) StreamPrices(req *aastockspb.StreamPricesRequest, stream aastockspb.MarketData_StreamPricesServer) error {
+	if len(req.GetSymbols()) == 0 {
+		return status.Error(codes.InvalidArgument, "Symbols are not provided")
+	}
+	for _, symbol := range req.GetSymbols() {
+		if symbol == "" {
+			return status.Error(codes.InvalidArgument, "Symbol is not provided")
+		}
+	}
+	sub := s.hub.Subscribe(req.GetSymbols()...)
+	defer sub.Close()
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-sub.C():
+			if !ok {
+				return status.Error(codes.Unavailable, "Price hub is ended")
+			}
+			err := stream.Send(newPriceEvent(e))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
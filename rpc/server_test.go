@@ -0,0 +1,249 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/rpc/aastockspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// mockTransport serves files of urls.
+type mockTransport map[string]string
+
+func (m mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, ok := m[req.URL.String()]
+	if !ok {
+		return nil, fmt.Errorf("Handler not found for %s", req.URL)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	recorder.Write(b)
+	return recorder.Result(), nil
+}
+
+// testClient starts in-process server, and returns client connected to it.
+func testClient(t *testing.T) aastockspb.MarketDataClient {
+	client := aastocks.WithClient(&http.Client{
+		Transport: mockTransport{
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006":                                                             "../testdata/detail_quote.html",
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=151511":                                                            "../testdata/detail_quote_not_found.html",
+			"http://www.aastocks.com/en/stocks/analysis/dividend.aspx?symbol=00006":                                                              "../testdata/dividend.html",
+			"http://chartdata1.internet.aastocks.com/servlet/iDataServlet/getdaily?id=00006.HK&type=24&market=1&level=1&period=56&encoding=utf8": "../testdata/historical_price_00006_daily.html",
+		},
+	})
+	hub := aastocks.NewPriceHub(context.Background(), aastocks.HubConfig{Interval: time.Hour}, client)
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	aastockspb.RegisterMarketDataServer(s, NewServer(hub, client))
+	go s.Serve(lis)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+		hub.Close()
+	})
+	return aastockspb.NewMarketDataClient(conn)
+}
+
+// date in Hong Kong, as times of AAStocks.
+func date(year int, month time.Month, day int) *timestamppb.Timestamp {
+	return timestamppb.New(time.Date(year, month, day, 0, 0, 0, 0, aastocks.HongKong))
+}
+
+var quote00006 = &aastockspb.Quote{
+	Symbol:        "00006",
+	Name:          "POWER ASSETS",
+	Industry:      "Electricity Supply",
+	Price:         44.65,
+	PrevClose:     44.2,
+	Price_52WLow:  41.6,
+	Price_52WHigh: 58.5,
+	Yield:         0.06271,
+	PeRatio:       13.368,
+	PbRatio:       1.115,
+	Lots:          500,
+	Eps:           3.34,
+	UpdateTime:    timestamppb.New(time.Date(2020, time.August, 25, 21, 18, 38, 0, aastocks.HongKong)),
+}
+
+func TestGetQuote(t *testing.T) {
+	client := testClient(t)
+	testCases := []struct {
+		desc     string
+		symbol   string
+		expected *aastockspb.Quote
+		code     codes.Code
+	}{
+		{
+			desc:     "Quote",
+			symbol:   "00006",
+			expected: quote00006,
+		},
+		{
+			desc:   "NotFound",
+			symbol: "151511",
+			code:   codes.NotFound,
+		},
+		{
+			desc: "NoSymbol",
+			code: codes.InvalidArgument,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			q, err := client.GetQuote(context.Background(), &aastockspb.GetQuoteRequest{Symbol: tC.symbol})
+			diff := cmp.Diff(tC.code, status.Code(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.expected, q, protocmp.Transform())
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestGetDividends(t *testing.T) {
+	client := testClient(t)
+	resp, err := client.GetDividends(context.Background(), &aastockspb.GetDividendsRequest{Symbol: "00006"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff(4, len(resp.GetDividends()))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff(&aastockspb.Dividend{
+		AnnounceDate: date(2013, time.September, 27),
+		Event:        "Special",
+		Particular:   "Preferential Offer: 1 HK Electric Investments and HK Electric Investments Limited Share Stapled unit offer price HKD 5.4500 for every 4 Shares held",
+		Type:         "-",
+		ExDate:       date(2014, time.January, 8),
+	}, resp.GetDividends()[2], protocmp.Transform())
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestGetHistory(t *testing.T) {
+	client := testClient(t)
+	testCases := []struct {
+		desc     string
+		req      *aastockspb.GetHistoryRequest
+		expected []*aastockspb.HistoricalPrice
+		code     codes.Code
+	}{
+		{
+			desc: "Range",
+			req: &aastockspb.GetHistoryRequest{
+				Symbol:    "00006",
+				Frequency: aastockspb.Frequency_FREQUENCY_DAILY,
+				From:      date(2020, time.August, 24),
+				To:        date(2020, time.August, 26),
+			},
+			expected: []*aastockspb.HistoricalPrice{
+				{Time: date(2020, time.August, 24), Open: 44.2, High: 44.7, Low: 44.15, Close: 44.2, Volume: 1426.486},
+				{Time: date(2020, time.August, 25), Open: 44.2, High: 44.65, Low: 44.05, Close: 44.65, Volume: 1709.545},
+			},
+		},
+		{
+			desc: "UnknownFrequency",
+			req: &aastockspb.GetHistoryRequest{
+				Symbol:    "00006",
+				Frequency: aastockspb.Frequency(10),
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			desc: "InvalidTimestamp",
+			req: &aastockspb.GetHistoryRequest{
+				Symbol: "00006",
+				From:   &timestamppb.Timestamp{Nanos: -1},
+			},
+			code: codes.InvalidArgument,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			resp, err := client.GetHistory(context.Background(), tC.req)
+			diff := cmp.Diff(tC.code, status.Code(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.expected, resp.GetPrices(), protocmp.Transform())
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestStreamPrices(t *testing.T) {
+	client := testClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.StreamPrices(ctx, &aastockspb.StreamPricesRequest{Symbols: []string{"00006", "151511"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := make(map[string]*aastockspb.PriceEvent)
+	for len(received) < 2 {
+		e, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		received[e.GetSymbol()] = e
+	}
+	diff := cmp.Diff(map[string]*aastockspb.PriceEvent{
+		"00006": {
+			Symbol: "00006",
+			Price:  44.65,
+			Time:   quote00006.UpdateTime,
+			Quote:  quote00006,
+		},
+		"151511": {
+			Symbol: "151511",
+			Error:  "Symbol cannot be found: 151511",
+		},
+	}, received, protocmp.Transform())
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	stream, err = client.StreamPrices(ctx, &aastockspb.StreamPricesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	diff = cmp.Diff(codes.InvalidArgument, status.Code(err))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
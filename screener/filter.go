@@ -0,0 +1,156 @@
+package screener
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/expr"
+)
+
+// Field of quote to be filtered and sorted by.
+type Field string
+
+const (
+	// Price is the latest price
+	Price Field = "price"
+	// Change is fraction of price changed from previous close
+	Change Field = "change"
+	// PeRatio is PE ratio, which is not available when it is not positive
+	PeRatio Field = "pe"
+	// PbRatio is PB ratio, which is not available when it is not positive
+	PbRatio Field = "pb"
+	// Yield is dividend yield as fraction
+	Yield Field = "yield"
+	// Eps is earnings per share
+	Eps Field = "eps"
+	// Lots is the number of shares per lot
+	Lots Field = "lots"
+	// Range52W is position of price within 52 week range, 0 at 52 week low and 1 at 52 week high
+	Range52W Field = "range_52w"
+)
+
+var fields = map[Field]func(q *aastocks.Quote) (float64, bool){
+	Price: func(q *aastocks.Quote) (float64, bool) {
+		return q.Price, true
+	},
+	Change: func(q *aastocks.Quote) (float64, bool) {
+		if q.PrevClose == 0 {
+			return 0, false
+		}
+		return q.Price/q.PrevClose - 1, true
+	},
+	PeRatio: func(q *aastocks.Quote) (float64, bool) {
+		return q.PeRatio, q.PeRatio > 0
+	},
+	PbRatio: func(q *aastocks.Quote) (float64, bool) {
+		return q.PbRatio, q.PbRatio > 0
+	},
+	Yield: func(q *aastocks.Quote) (float64, bool) {
+		return q.Yield, true
+	},
+	Eps: func(q *aastocks.Quote) (float64, bool) {
+		return q.Eps, true
+	},
+	Lots: func(q *aastocks.Quote) (float64, bool) {
+		return float64(q.Lots), true
+	},
+	Range52W: func(q *aastocks.Quote) (float64, bool) {
+		if q.Price52WHigh <= q.Price52WLow {
+			return 0, false
+		}
+		return (q.Price - q.Price52WLow) / (q.Price52WHigh - q.Price52WLow), true
+	},
+}
+
+// ParseField parses field from its name, i.e. "pe".
+func ParseField(name string) (Field, error) {
+	f := Field(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := fields[f]; !ok {
+		return "", fmt.Errorf("Field is unknown: %v", name)
+	}
+	return f, nil
+}
+
+// Value of the field of quote, ok is false if it is not available.
+func (f Field) Value(q *aastocks.Quote) (v float64, ok bool) {
+	value, ok := fields[f]
+	if !ok {
+		return 0, false
+	}
+	return value(q)
+}
+
+// Filter of quotes.
+type Filter struct {
+	desc    string
+	history bool
+	match   func(q *aastocks.Quote, prices []aastocks.HistoricalPrice) (bool, error)
+}
+
+func (f Filter) String() string {
+	return f.desc
+}
+
+// Above filters quotes with field above the value.
+func Above(field Field, value float64) Filter {
+	return compare(fmt.Sprintf("%v > %v", field, value), field, func(v float64) bool {
+		return v > value
+	})
+}
+
+// Below filters quotes with field below the value.
+func Below(field Field, value float64) Filter {
+	return compare(fmt.Sprintf("%v < %v", field, value), field, func(v float64) bool {
+		return v < value
+	})
+}
+
+// Between filters quotes with field between min and max inclusively.
+func Between(field Field, min, max float64) Filter {
+	return compare(fmt.Sprintf("%v <= %v <= %v", min, field, max), field, func(v float64) bool {
+		return v >= min && v <= max
+	})
+}
+
+// compare filters quotes with field available and matched.
+func compare(desc string, field Field, match func(v float64) bool) Filter {
+	return Filter{
+		desc: desc,
+		match: func(q *aastocks.Quote, prices []aastocks.HistoricalPrice) (bool, error) {
+			v, ok := field.Value(q)
+			return ok && match(v), nil
+		},
+	}
+}
+
+// InIndustry filters quotes in any of the industries, which are matched case insensitively.
+func InIndustry(industries ...string) Filter {
+	return Filter{
+		desc: fmt.Sprintf("industry in %v", strings.Join(industries, ", ")),
+		match: func(q *aastocks.Quote, prices []aastocks.HistoricalPrice) (bool, error) {
+			for _, industry := range industries {
+				if strings.EqualFold(q.Industry, industry) {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+	}
+}
+
+// Expression filters quotes with expression of package expr, i.e. "yield > 0.06 && pb < 1".
+// Historical prices are fetched for the expression if it uses indicators.
+func Expression(source string) (Filter, error) {
+	p, err := expr.CompileBool(source)
+	if err != nil {
+		return Filter{}, err
+	}
+	return Filter{
+		desc:    source,
+		history: p.UsesHistory(),
+		match: func(q *aastocks.Quote, prices []aastocks.HistoricalPrice) (bool, error) {
+			return p.EvalBool(expr.Env{Quote: q, Prices: prices})
+		},
+	}, nil
+}
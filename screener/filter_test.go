@@ -0,0 +1,97 @@
+package screener
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/horacehylee/aastocks"
+)
+
+func TestFieldValue(t *testing.T) {
+	q := &aastocks.Quote{
+		Price:        44.65,
+		PrevClose:    44.2,
+		Price52WLow:  41.6,
+		Price52WHigh: 58.5,
+		Yield:        0.06271,
+		Lots:         500,
+	}
+	testCases := []struct {
+		field Field
+		value float64
+		ok    bool
+	}{
+		{field: Price, value: 44.65, ok: true},
+		{field: Change, value: 44.65/44.2 - 1, ok: true},
+		{field: PeRatio, value: 0, ok: false},
+		{field: Yield, value: 0.06271, ok: true},
+		{field: Lots, value: 500, ok: true},
+		{field: Range52W, value: (44.65 - 41.6) / (58.5 - 41.6), ok: true},
+		{field: Field("volume"), value: 0, ok: false},
+	}
+	for _, tC := range testCases {
+		t.Run(string(tC.field), func(t *testing.T) {
+			v, ok := tC.field.Value(q)
+			diff := cmp.Diff([]interface{}{tC.value, tC.ok}, []interface{}{v, ok}, cmpopts.EquateApprox(0, 1e-9))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestParseField(t *testing.T) {
+	testCases := []struct {
+		name  string
+		field Field
+		err   error
+	}{
+		{name: "PE", field: PeRatio},
+		{name: " range_52w ", field: Range52W},
+		{name: "volume", err: fmt.Errorf("Field is unknown: volume")},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			f, err := ParseField(tC.name)
+			diff := cmp.Diff([]interface{}{tC.field, fmt.Sprint(tC.err)}, []interface{}{f, fmt.Sprint(err)})
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	q := &aastocks.Quote{
+		Industry: "Electricity Supply",
+		Price:    44.65,
+		Yield:    0.06271,
+	}
+	testCases := []struct {
+		filter  Filter
+		desc    string
+		matched bool
+	}{
+		{filter: Above(Yield, 0.06), desc: "yield > 0.06", matched: true},
+		{filter: Below(Price, 40), desc: "price < 40", matched: false},
+		{filter: Between(Price, 40, 44.65), desc: "40 <= price <= 44.65", matched: true},
+		// PE ratio is not available
+		{filter: Below(PeRatio, 10), desc: "pe < 10", matched: false},
+		{filter: InIndustry("Banks"), desc: "industry in Banks", matched: false},
+		{filter: mustExpression(`yield > 0.06 && price < 50`), desc: "yield > 0.06 && price < 50", matched: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			matched, err := tC.filter.match(q, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff := cmp.Diff([]interface{}{tC.desc, tC.matched}, []interface{}{tC.filter.String(), matched})
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Package screener screens universe of symbols from AAStocks by filters on their quotes,
+// and ranks the survivors.
+//
+//	s := screener.New(screener.Config{
+//		Filters: []screener.Filter{
+//			screener.Above(screener.Yield, 0.06),
+//			screener.Below(screener.PbRatio, 1),
+//		},
+//		Sort: []screener.Sort{{Field: screener.Yield, Descending: true}},
+//	})
+//	result := s.Screen(ctx, []string{"00005", "00006", "00011"})
+//	for _, row := range result.Rows {
+//		logger.Printf("%v. %v %v\n", row.Rank, row.Quote.Symbol, row.Quote.Yield)
+//	}
+//	for _, err := range result.Errors {
+//		logger.Printf("error: %v\n", err)
+//	}
+package screener
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Config of screener.
+type Config struct {
+	// Filters which quotes must all match.
+	Filters []Filter
+	// Sort of quotes matched, by the first field and then the next for ties.
+	// Quotes are in the order of symbols screened if it is empty.
+	Sort []Sort
+	// Limit of rows in result, all quotes matched are returned if it is zero.
+	Limit int
+	// Concurrency of fetching quotes, 4 is used if it is zero.
+	Concurrency int
+	// Limiter to limit requests to AAStocks, requests are not limited if it is nil.
+	Limiter *aastocks.RateLimiter
+	// Frequency of historical prices fetched for filters using indicators, daily is used if it is zero.
+	Frequency aastocks.PriceFrequency
+}
+
+// Sort by field of quotes.
+// Quotes with the field not available are sorted last, regardless of the direction.
+type Sort struct {
+	Field      Field
+	Descending bool
+}
+
+// Row of quote matched.
+type Row struct {
+	// Rank starting from 1.
+	Rank  int             `json:"rank"`
+	Quote *aastocks.Quote `json:"quote"`
+}
+
+// SymbolError is error of screening the symbol.
+type SymbolError struct {
+	Symbol string
+	Err    error
+}
+
+func (e SymbolError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Symbol, e.Err)
+}
+
+func (e SymbolError) Unwrap() error {
+	return e.Err
+}
+
+// Result of screening.
+type Result struct {
+	// Rows of quotes matched, which are ranked.
+	Rows []Row
+	// Errors of symbols which failed to be screened, in the order of symbols.
+	Errors []SymbolError
+	// Screened is the number of symbols screened successfully, whether they are matched or not.
+	Screened int
+}
+
+// Screener screens symbols by filters.
+type Screener struct {
+	config  Config
+	opts    []aastocks.Option
+	history bool
+}
+
+// New creates screener.
+// Options are applied to quotes fetched by the screener (i.e. WithClient).
+func New(config Config, opts ...aastocks.Option) *Screener {
+	if config.Concurrency <= 0 {
+		config.Concurrency = 4
+	}
+	if config.Frequency == 0 {
+		config.Frequency = aastocks.Daily
+	}
+	s := &Screener{
+		config: config,
+		opts:   opts,
+	}
+	for _, f := range config.Filters {
+		if f.history {
+			s.history = true
+		}
+	}
+	return s
+}
+
+type outcome struct {
+	quote   *aastocks.Quote
+	matched bool
+	err     error
+}
+
+// Screen symbols with bounded concurrency.
+// Symbols not screened yet when context is done are reported with error of the context.
+func (s *Screener) Screen(ctx context.Context, symbols []string) *Result {
+	outcomes := make([]outcome, len(symbols))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < s.config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				outcomes[j] = s.screen(ctx, symbols[j])
+			}
+		}()
+	}
+Loop:
+	for i := range symbols {
+		select {
+		case <-ctx.Done():
+			for j := i; j < len(symbols); j++ {
+				outcomes[j] = outcome{err: ctx.Err()}
+			}
+			break Loop
+		case jobs <- i:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	result := &Result{
+		Rows:   make([]Row, 0),
+		Errors: make([]SymbolError, 0),
+	}
+	for i, o := range outcomes {
+		if o.err != nil {
+			result.Errors = append(result.Errors, SymbolError{Symbol: symbols[i], Err: o.err})
+			continue
+		}
+		result.Screened++
+		if o.matched {
+			result.Rows = append(result.Rows, Row{Quote: o.quote})
+		}
+	}
+	sortRows(result.Rows, s.config.Sort)
+	if s.config.Limit > 0 && len(result.Rows) > s.config.Limit {
+		result.Rows = result.Rows[:s.config.Limit]
+	}
+	for i := range result.Rows {
+		result.Rows[i].Rank = i + 1
+	}
+	return result
+}
+
+func (s *Screener) screen(ctx context.Context, symbol string) outcome {
+	err := s.wait(ctx)
+	if err != nil {
+		return outcome{err: err}
+	}
+	q, err := aastocks.Get(symbol, s.opts...)
+	if err != nil {
+		return outcome{err: err}
+	}
+	var prices []aastocks.HistoricalPrice
+	if s.history {
+		err = s.wait(ctx)
+		if err != nil {
+			return outcome{err: err}
+		}
+		prices, err = q.HistoricalPrices(s.config.Frequency)
+		if err != nil {
+			return outcome{err: err}
+		}
+	}
+	for _, f := range s.config.Filters {
+		ok, err := f.match(q, prices)
+		if err != nil {
+			return outcome{err: fmt.Errorf("Filter %q failed to be matched: %v", f, err)}
+		}
+		if !ok {
+			return outcome{quote: q}
+		}
+	}
+	return outcome{quote: q, matched: true}
+}
+
+func (s *Screener) wait(ctx context.Context) error {
+	if s.config.Limiter == nil {
+		return ctx.Err()
+	}
+	return s.config.Limiter.Wait(ctx)
+}
+
+// sortRows sorts rows stably, so that ties are in the order of symbols.
+func sortRows(rows []Row, sorts []Sort) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, s := range sorts {
+			a, aok := s.Field.Value(rows[i].Quote)
+			b, bok := s.Field.Value(rows[j].Quote)
+			if aok != bok {
+				return aok
+			}
+			if !aok || a == b {
+				continue
+			}
+			if s.Descending {
+				return a > b
+			}
+			return a < b
+		}
+		return false
+	})
+}
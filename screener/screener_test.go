@@ -0,0 +1,169 @@
+package screener
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+// mockTransport serves files of urls.
+type mockTransport map[string]string
+
+func (m mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, ok := m[req.URL.String()]
+	if !ok {
+		return nil, fmt.Errorf("Handler not found for %s", req.URL)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	recorder.Write(b)
+	return recorder.Result(), nil
+}
+
+func mockClient() *http.Client {
+	return &http.Client{
+		Transport: mockTransport{
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006":                                                             "../testdata/detail_quote.html",
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=09923":                                                             "../testdata/detail_quote_new.html",
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=3033":                                                              "../testdata/detail_quote_3033.html",
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=151511":                                                            "../testdata/detail_quote_not_found.html",
+			"http://chartdata1.internet.aastocks.com/servlet/iDataServlet/getdaily?id=00006.HK&type=24&market=1&level=1&period=56&encoding=utf8": "../testdata/historical_price_00006_daily.html",
+		},
+	}
+}
+
+func mustExpression(source string) Filter {
+	f, err := Expression(source)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func TestScreen(t *testing.T) {
+	universe := []string{"00006", "09923", "3033", "151511"}
+	testCases := []struct {
+		desc     string
+		symbols  []string
+		config   Config
+		cancel   bool
+		rows     []string
+		errors   []string
+		screened int
+	}{
+		{
+			desc:    "YieldAndPb",
+			symbols: universe,
+			config: Config{
+				Filters: []Filter{Above(Yield, 0.06), Below(PbRatio, 1.2)},
+			},
+			rows:     []string{"1. 00006"},
+			errors:   []string{"151511: Symbol cannot be found: 151511"},
+			screened: 3,
+		},
+		{
+			desc:    "SortByRange52W",
+			symbols: universe,
+			config: Config{
+				Sort: []Sort{{Field: Range52W, Descending: true}},
+			},
+			rows:     []string{"1. 09923", "2. 00006", "3. 3033"},
+			errors:   []string{"151511: Symbol cannot be found: 151511"},
+			screened: 3,
+		},
+		{
+			desc:    "SortByPriceWithLimit",
+			symbols: universe,
+			config: Config{
+				Sort:        []Sort{{Field: Price}},
+				Limit:       2,
+				Concurrency: 1,
+			},
+			rows:     []string{"1. 3033", "2. 00006"},
+			errors:   []string{"151511: Symbol cannot be found: 151511"},
+			screened: 3,
+		},
+		{
+			desc:    "InIndustry",
+			symbols: universe[:3],
+			config: Config{
+				Filters: []Filter{InIndustry("Banks", "electricity supply")},
+			},
+			rows:     []string{"1. 00006"},
+			errors:   []string{},
+			screened: 3,
+		},
+		{
+			desc:    "ExpressionWithHistory",
+			symbols: []string{"00006"},
+			config: Config{
+				Filters: []Filter{mustExpression("price > 0.5 * sma(20)")},
+			},
+			rows:     []string{"1. 00006"},
+			errors:   []string{},
+			screened: 1,
+		},
+		{
+			desc:    "ExpressionWithInsufficientHistory",
+			symbols: []string{"00006"},
+			config: Config{
+				Filters: []Filter{mustExpression("sma(20) > 0 && rsi(10000) > 0")},
+			},
+			rows:     []string{},
+			errors:   []string{`00006: Filter "sma(20) > 0 && rsi(10000) > 0" failed to be matched: Function rsi failed to be evaluated: Historical prices are insufficient`},
+			screened: 0,
+		},
+		{
+			desc:    "ExpressionWithoutHistory",
+			symbols: universe[:3],
+			config: Config{
+				Filters: []Filter{mustExpression(`industry != "" && change < 0`)},
+			},
+			rows:     []string{"1. 09923"},
+			errors:   []string{},
+			screened: 3,
+		},
+		{
+			desc:    "Cancelled",
+			symbols: universe[:2],
+			cancel:  true,
+			rows:    []string{},
+			errors: []string{
+				"00006: context canceled",
+				"09923: context canceled",
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tC.cancel {
+				cancel()
+			}
+			result := New(tC.config, aastocks.WithClient(mockClient())).Screen(ctx, tC.symbols)
+
+			rows := make([]string, 0)
+			for _, r := range result.Rows {
+				rows = append(rows, fmt.Sprintf("%v. %v", r.Rank, r.Quote.Symbol))
+			}
+			errors := make([]string, 0)
+			for _, err := range result.Errors {
+				errors = append(errors, err.Error())
+			}
+			diff := cmp.Diff([]interface{}{tC.rows, tC.errors, tC.screened}, []interface{}{rows, errors, result.Screened})
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package sink
+
+import (
+	"context"
+	"time"
+)
+
+// ForwardConfig of batching events forwarded to sink.
+type ForwardConfig struct {
+	// BatchSize is the maximum number of events published at once, events are published one by one if it is zero.
+	BatchSize int
+	// FlushInterval is the maximum duration to wait for batch to be full since its first event.
+	// If it is zero, batch is published as soon as no more events are ready, without waiting.
+	FlushInterval time.Duration
+	// OnError is called with batch failed to be published, which is dropped so that forwarding continues.
+	// Forward stops and returns the error if it is nil.
+	OnError func(events []Event, err error)
+}
+
+// Forward events to sink in batches, until events are closed or context is done.
+// Batch pending is published before it returns when events are closed, but it is dropped when context is done.
+// Sink is not closed by it.
+func Forward(ctx context.Context, sink Sink, events <-chan Event, config ForwardConfig) error {
+	size := config.BatchSize
+	if size <= 0 {
+		size = 1
+	}
+	publish := func(batch []Event) error {
+		err := sink.Publish(ctx, batch)
+		if err != nil && config.OnError != nil {
+			config.OnError(batch, err)
+			return nil
+		}
+		return err
+	}
+
+	for {
+		var batch []Event
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			batch = append(make([]Event, 0, size), e)
+		}
+
+		batch, closed, err := fill(ctx, events, batch, size, config.FlushInterval)
+		if err != nil {
+			return err
+		}
+		err = publish(batch)
+		if err != nil {
+			return err
+		}
+		if closed {
+			return nil
+		}
+	}
+}
+
+// fill batch with events until it is full, events are closed, or flush interval is passed.
+// Batch is filled with events ready only if flush interval is zero.
+func fill(ctx context.Context, events <-chan Event, batch []Event, size int, interval time.Duration) ([]Event, bool, error) {
+	var timeout <-chan time.Time
+	if interval > 0 {
+		timer := time.NewTimer(interval)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	for len(batch) < size {
+		if timeout == nil {
+			select {
+			case <-ctx.Done():
+				return nil, false, ctx.Err()
+			case e, ok := <-events:
+				if !ok {
+					return batch, true, nil
+				}
+				batch = append(batch, e)
+			default:
+				return batch, false, nil
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case e, ok := <-events:
+			if !ok {
+				return batch, true, nil
+			}
+			batch = append(batch, e)
+		case <-timeout:
+			return batch, false, nil
+		}
+	}
+	return batch, false, nil
+}
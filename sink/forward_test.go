@@ -0,0 +1,146 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// recordSink records batches published, and fails batches containing event of failing ID.
+type recordSink struct {
+	mu      sync.Mutex
+	batches [][]string
+	failing string
+}
+
+func (s *recordSink) Publish(ctx context.Context, events []Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(events))
+	for _, e := range events {
+		if e.ID == s.failing {
+			return fmt.Errorf("Event failed: %v", e.ID)
+		}
+		ids = append(ids, e.ID)
+	}
+	s.batches = append(s.batches, ids)
+	return nil
+}
+
+func (s *recordSink) Close() error {
+	return nil
+}
+
+func eventsOf(ids ...string) <-chan Event {
+	events := make(chan Event, len(ids))
+	for _, id := range ids {
+		events <- Event{ID: id}
+	}
+	close(events)
+	return events
+}
+
+func TestForward(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		config   ForwardConfig
+		ids      []string
+		expected [][]string
+	}{
+		{
+			desc:     "OneByOne",
+			config:   ForwardConfig{},
+			ids:      []string{"1", "2", "3"},
+			expected: [][]string{{"1"}, {"2"}, {"3"}},
+		},
+		{
+			desc:     "BatchReady",
+			config:   ForwardConfig{BatchSize: 2},
+			ids:      []string{"1", "2", "3"},
+			expected: [][]string{{"1", "2"}, {"3"}},
+		},
+		{
+			desc:     "BatchWithFlushInterval",
+			config:   ForwardConfig{BatchSize: 10, FlushInterval: time.Second},
+			ids:      []string{"1", "2", "3"},
+			expected: [][]string{{"1", "2", "3"}},
+		},
+		{
+			desc:     "Empty",
+			config:   ForwardConfig{BatchSize: 10},
+			ids:      []string{},
+			expected: nil,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := &recordSink{}
+			err := Forward(context.Background(), s, eventsOf(tC.ids...), tC.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tC.expected, s.batches); diff != "" {
+				t.Errorf("batches mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestForwardFlushInterval(t *testing.T) {
+	s := &recordSink{}
+	events := make(chan Event)
+	done := make(chan error)
+	go func() {
+		done <- Forward(context.Background(), s, events, ForwardConfig{BatchSize: 10, FlushInterval: 10 * time.Millisecond})
+	}()
+	events <- Event{ID: "1"}
+	time.Sleep(50 * time.Millisecond)
+	events <- Event{ID: "2"}
+	close(events)
+	err := <-done
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]string{{"1"}, {"2"}}
+	if diff := cmp.Diff(expected, s.batches); diff != "" {
+		t.Errorf("batches mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestForwardError(t *testing.T) {
+	s := &recordSink{failing: "2"}
+	err := Forward(context.Background(), s, eventsOf("1", "2", "3"), ForwardConfig{})
+	if err == nil || err.Error() != "Event failed: 2" {
+		t.Errorf("expected error of event 2, but got %v", err)
+	}
+
+	s = &recordSink{failing: "2"}
+	failed := make([]string, 0)
+	err = Forward(context.Background(), s, eventsOf("1", "2", "3"), ForwardConfig{
+		OnError: func(events []Event, err error) {
+			failed = append(failed, events[0].ID)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"2"}, failed); diff != "" {
+		t.Errorf("failed mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([][]string{{"1"}, {"3"}}, s.batches); diff != "" {
+		t.Errorf("batches mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestForwardContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := Forward(ctx, &recordSink{}, make(chan Event), ForwardConfig{})
+	if err != context.Canceled {
+		t.Errorf("expected %v, but got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,135 @@
+// Package nats publishes events of package sink to NATS subjects.
+//
+// Events are published as JSON to subject "<prefix>.<kind>.<symbol>", so that consumers can subscribe to
+// events of kinds or symbols with wildcards (i.e. "aastocks.price.>" or "aastocks.*.00006").
+//
+//	conn, err := nats.Connect(natsgo.DefaultURL)
+//	s, err := nats.New(conn, nats.Config{JetStream: true})
+//	err = sink.Forward(ctx, s, sink.Prices(ctx, stream.C()), sink.ForwardConfig{BatchSize: 100})
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/horacehylee/aastocks/sink"
+	natsgo "github.com/nats-io/nats.go"
+)
+
+// Config of NATS sink.
+type Config struct {
+	// Prefix of subjects, "aastocks" is used if it is empty.
+	Prefix string
+	// JetStream publishes events to JetStream streams, which should be created for the subjects beforehand.
+	JetStream bool
+}
+
+// Sink publishing events to NATS subjects.
+//
+// With core NATS, delivery is at most once: batch is flushed to server before Publish returns,
+// but events are dropped if no subscribers are connected, or subscribers are slow.
+//
+// With JetStream, delivery is at least once: Publish returns only after all events of batch are acknowledged
+// by the stream, and batch failed can be published again. Events are published with their IDs as message IDs,
+// so the stream discards duplicates within its duplicate window.
+type Sink struct {
+	conn   *natsgo.Conn
+	js     natsgo.JetStreamContext
+	prefix string
+}
+
+// New creates sink publishing with the connection, which is not closed by the sink.
+func New(conn *natsgo.Conn, config Config) (*Sink, error) {
+	s := &Sink{
+		conn:   conn,
+		prefix: config.Prefix,
+	}
+	if s.prefix == "" {
+		s.prefix = "aastocks"
+	}
+	if config.JetStream {
+		js, err := conn.JetStream()
+		if err != nil {
+			return nil, err
+		}
+		s.js = js
+	}
+	return s, nil
+}
+
+// token of subject, which cannot contain separators or wildcards.
+var token = strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_")
+
+// Subject of event.
+func (s *Sink) Subject(e sink.Event) string {
+	symbol := token.Replace(e.Symbol)
+	if symbol == "" {
+		symbol = "_"
+	}
+	return fmt.Sprintf("%v.%v.%v", s.prefix, token.Replace(string(e.Kind)), symbol)
+}
+
+func (s *Sink) message(e sink.Event) (*natsgo.Msg, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	msg := natsgo.NewMsg(s.Subject(e))
+	msg.Data = b
+	msg.Header.Set(natsgo.MsgIdHdr, e.ID)
+	return msg, nil
+}
+
+// Publish events, and waits for them to be flushed, or acknowledged by JetStream.
+func (s *Sink) Publish(ctx context.Context, events []sink.Event) error {
+	if s.js != nil {
+		return s.publishJetStream(ctx, events)
+	}
+	for _, e := range events {
+		msg, err := s.message(e)
+		if err != nil {
+			return err
+		}
+		err = s.conn.PublishMsg(msg)
+		if err != nil {
+			return err
+		}
+	}
+	// flush with context requires deadline, otherwise default timeout of flush is used
+	if _, ok := ctx.Deadline(); !ok {
+		return s.conn.Flush()
+	}
+	return s.conn.FlushWithContext(ctx)
+}
+
+func (s *Sink) publishJetStream(ctx context.Context, events []sink.Event) error {
+	futures := make([]natsgo.PubAckFuture, 0, len(events))
+	for _, e := range events {
+		msg, err := s.message(e)
+		if err != nil {
+			return err
+		}
+		f, err := s.js.PublishMsgAsync(msg)
+		if err != nil {
+			return err
+		}
+		futures = append(futures, f)
+	}
+	for i, f := range futures {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-f.Ok():
+		case err := <-f.Err():
+			return fmt.Errorf("Event %v failed to be acknowledged: %w", events[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// Close sink, the connection is not closed as it is owned by caller.
+func (s *Sink) Close() error {
+	return nil
+}
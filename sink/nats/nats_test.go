@@ -0,0 +1,192 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks/sink"
+	"github.com/nats-io/nats-server/v2/server"
+	natsgo "github.com/nats-io/nats.go"
+)
+
+// testConn connects to embedded NATS server with JetStream enabled.
+func testConn(t *testing.T) *natsgo.Conn {
+	dir, err := ioutil.TempDir("", "nats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	s, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  dir,
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.Start()
+	if !s.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS server is not ready")
+	}
+	t.Cleanup(s.Shutdown)
+	conn, err := natsgo.Connect(s.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(conn.Close)
+	return conn
+}
+
+var events = []sink.Event{
+	{ID: "price:00006:1", Kind: sink.KindPrice, Symbol: "00006", Time: time.Unix(1, 0).UTC()},
+	{ID: "alert:00005:above:1", Kind: sink.KindAlert, Symbol: "00005", Time: time.Unix(1, 0).UTC()},
+	{ID: "price:00006:2", Kind: sink.KindPrice, Symbol: "00006", Time: time.Unix(2, 0).UTC()},
+}
+
+func decode(t *testing.T, msg *natsgo.Msg) string {
+	var e sink.Event
+	err := json.Unmarshal(msg.Data, &e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg.Subject + " " + e.ID
+}
+
+func TestSubject(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		prefix   string
+		event    sink.Event
+		expected string
+	}{
+		{"Default", "", sink.Event{Kind: sink.KindPrice, Symbol: "00006"}, "aastocks.price.00006"},
+		{"Prefix", "hk.quotes", sink.Event{Kind: sink.KindDividend, Symbol: "00006"}, "hk.quotes.dividend.00006"},
+		{"Sanitized", "", sink.Event{Kind: sink.KindAlert, Symbol: "0.5 *>"}, "aastocks.alert.0_5___"},
+		{"EmptySymbol", "", sink.Event{Kind: sink.KindAlert}, "aastocks.alert._"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := &Sink{prefix: tC.prefix}
+			if s.prefix == "" {
+				s.prefix = "aastocks"
+			}
+			actual := s.Subject(tC.event)
+			if actual != tC.expected {
+				t.Errorf("expected %v, but got %v", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPublish(t *testing.T) {
+	conn := testConn(t)
+	sub, err := conn.SubscribeSync("aastocks.>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := New(conn, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	err = s.Publish(context.Background(), events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := make([]string, 0)
+	for range events {
+		msg, err := sub.NextMsg(time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, decode(t, msg))
+	}
+	expected := []string{
+		"aastocks.price.00006 price:00006:1",
+		"aastocks.alert.00005 alert:00005:above:1",
+		"aastocks.price.00006 price:00006:2",
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("messages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPublishJetStream(t *testing.T) {
+	conn := testConn(t)
+	js, err := conn.JetStream()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = js.AddStream(&natsgo.StreamConfig{
+		Name:     "AASTOCKS",
+		Subjects: []string{"aastocks.>"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := New(conn, Config{JetStream: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	err = s.Publish(context.Background(), events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// batch published again is deduplicated by message IDs
+	err = s.Publish(context.Background(), events[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := js.StreamInfo("AASTOCKS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.State.Msgs != uint64(len(events)) {
+		t.Errorf("expected %v messages in stream, but got %v", len(events), info.State.Msgs)
+	}
+
+	sub, err := js.SubscribeSync("aastocks.price.>", natsgo.DeliverAll())
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := make([]string, 0)
+	for i := 0; i < 2; i++ {
+		msg, err := sub.NextMsg(time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, decode(t, msg))
+	}
+	expected := []string{
+		"aastocks.price.00006 price:00006:1",
+		"aastocks.price.00006 price:00006:2",
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("messages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPublishJetStreamNoStream(t *testing.T) {
+	conn := testConn(t)
+	s, err := New(conn, Config{JetStream: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = s.Publish(ctx, events[:1])
+	if err == nil {
+		t.Errorf("expected error when no stream is created for subjects")
+	}
+}
@@ -0,0 +1,24 @@
+module github.com/horacehylee/aastocks/sink/redis
+
+go 1.18
+
+require (
+	github.com/alicebob/miniredis/v2 v2.31.0
+	github.com/google/go-cmp v0.5.2
+	github.com/horacehylee/aastocks v0.1.0
+	github.com/redis/go-redis/v9 v9.3.0
+)
+
+require (
+	github.com/PuerkitoBio/goquery v1.5.1 // indirect
+	github.com/alicebob/gopher-json v0.0.0-20200520072559-a9ecdc9d1d3a // indirect
+	github.com/andybalholm/cascadia v1.1.0 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/yuin/gopher-lua v1.1.0 // indirect
+	golang.org/x/net v0.0.0-20200202094626-16171245cfb2 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Root module in the same repository is used for development, tagged version is required otherwise
+replace github.com/horacehylee/aastocks => ../../
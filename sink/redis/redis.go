@@ -0,0 +1,86 @@
+// Package redis publishes events of package sink to Redis Streams.
+//
+// Events are added to stream with fields of their ID, kind, symbol, time and the event itself as JSON,
+// so that consumer groups can read events with XREADGROUP.
+//
+//	client := goredis.NewClient(&goredis.Options{Addr: "localhost:6379"})
+//	s := redis.New(client, redis.Config{Stream: "aastocks:events", MaxLen: 100000})
+//	err = sink.Forward(ctx, s, sink.Prices(ctx, stream.C()), sink.ForwardConfig{BatchSize: 100})
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/horacehylee/aastocks/sink"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Config of Redis Streams sink.
+type Config struct {
+	// Stream key which events are added to, "aastocks:events" is used if it is empty.
+	Stream string
+	// MaxLen of stream, which is trimmed approximately as events are added. Stream is not trimmed if it is zero.
+	MaxLen int64
+}
+
+// Sink adding events to Redis stream.
+//
+// Delivery is at least once: batch is added in a transaction, so either all or none of its events are added,
+// and Publish returns only after the transaction is executed. Batch failed can be published again,
+// but events are duplicated if the transaction is executed and its reply is lost,
+// so consumers should deduplicate events by ID. Events trimmed by MaxLen before being read are lost.
+type Sink struct {
+	client goredis.Cmdable
+	config Config
+}
+
+// New creates sink adding events with the client, which is not closed by the sink.
+func New(client goredis.Cmdable, config Config) *Sink {
+	if config.Stream == "" {
+		config.Stream = "aastocks:events"
+	}
+	return &Sink{
+		client: client,
+		config: config,
+	}
+}
+
+// Publish events to stream in a transaction.
+func (s *Sink) Publish(ctx context.Context, events []sink.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	args := make([]*goredis.XAddArgs, 0, len(events))
+	for _, e := range events {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		args = append(args, &goredis.XAddArgs{
+			Stream: s.config.Stream,
+			MaxLen: s.config.MaxLen,
+			Approx: s.config.MaxLen > 0,
+			Values: []interface{}{
+				"id", e.ID,
+				"kind", string(e.Kind),
+				"symbol", e.Symbol,
+				"time", e.Time.Format(time.RFC3339Nano),
+				"event", string(b),
+			},
+		})
+	}
+	_, err := s.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for _, a := range args {
+			pipe.XAdd(ctx, a)
+		}
+		return nil
+	})
+	return err
+}
+
+// Close sink, the client is not closed as it is owned by caller.
+func (s *Sink) Close() error {
+	return nil
+}
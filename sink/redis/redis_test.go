@@ -0,0 +1,107 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks/sink"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// testClient connects to local stand-in of Redis.
+func testClient(t *testing.T) (*miniredis.Miniredis, *goredis.Client) {
+	m := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: m.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return m, client
+}
+
+var events = []sink.Event{
+	{ID: "price:00006:1", Kind: sink.KindPrice, Symbol: "00006", Time: time.Unix(1, 0).UTC()},
+	{ID: "alert:00005:above:1", Kind: sink.KindAlert, Symbol: "00005", Time: time.Unix(1, 0).UTC()},
+	{ID: "price:00006:2", Kind: sink.KindPrice, Symbol: "00006", Time: time.Unix(2, 0).UTC()},
+}
+
+func TestPublish(t *testing.T) {
+	_, client := testClient(t)
+	s := New(client, Config{})
+	defer s.Close()
+
+	ctx := context.Background()
+	err := s.Publish(ctx, events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages, err := client.XRange(ctx, "aastocks:events", "-", "+").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	type entry struct {
+		ID     string
+		Kind   string
+		Symbol string
+		Time   string
+		Event  string
+	}
+	actual := make([]entry, 0)
+	for _, m := range messages {
+		var e sink.Event
+		err = json.Unmarshal([]byte(m.Values["event"].(string)), &e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, entry{
+			ID:     m.Values["id"].(string),
+			Kind:   m.Values["kind"].(string),
+			Symbol: m.Values["symbol"].(string),
+			Time:   m.Values["time"].(string),
+			Event:  e.ID,
+		})
+	}
+	expected := []entry{
+		{"price:00006:1", "price", "00006", "1970-01-01T00:00:01Z", "price:00006:1"},
+		{"alert:00005:above:1", "alert", "00005", "1970-01-01T00:00:01Z", "alert:00005:above:1"},
+		{"price:00006:2", "price", "00006", "1970-01-01T00:00:02Z", "price:00006:2"},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("entries mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPublishMaxLen(t *testing.T) {
+	_, client := testClient(t)
+	s := New(client, Config{Stream: "events", MaxLen: 2})
+
+	ctx := context.Background()
+	for _, e := range events {
+		err := s.Publish(ctx, []sink.Event{e})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	n, err := client.XLen(ctx, "events").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// trimming is approximate, so stream may be longer than MaxLen but not longer than events
+	if n < 2 || n > int64(len(events)) {
+		t.Errorf("expected stream to be trimmed to about 2 entries, but got %v", n)
+	}
+}
+
+func TestPublishError(t *testing.T) {
+	m, client := testClient(t)
+	s := New(client, Config{})
+	m.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := s.Publish(ctx, events)
+	if err == nil {
+		t.Errorf("expected error when Redis is unavailable")
+	}
+}
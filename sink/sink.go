@@ -0,0 +1,200 @@
+// Package sink publishes price, alert and dividend events to external systems.
+//
+// Events are published to Sink in batches by Forward, so that channels of price streams,
+// alert engine and dividend watchers do not have to be glued to queues by hand.
+// Webhook is implemented in this package, while NATS and Redis Streams are implemented in
+// nested modules github.com/horacehylee/aastocks/sink/nats and github.com/horacehylee/aastocks/sink/redis.
+//
+//	webhook, err := sink.NewWebhook(sink.WebhookConfig{URL: "https://example.com/hook", Secret: secret})
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	stream := quote.StreamPrices(ctx, 5*time.Second)
+//	err = sink.Forward(ctx, webhook, sink.Prices(ctx, stream.C()), sink.ForwardConfig{
+//		BatchSize:     100,
+//		FlushInterval: time.Second,
+//	})
+//
+// Delivery guarantees depend on the sink, and are documented by each of them.
+// Events may be delivered more than once by retries, so consumers should deduplicate them by ID,
+// which is derived from the event itself and is the same across restarts.
+package sink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/alert"
+)
+
+// Kind of event.
+type Kind string
+
+const (
+	// KindPrice of price polled, with data of PriceData
+	KindPrice Kind = "price"
+	// KindAlert of alert fired, with data of AlertData
+	KindAlert Kind = "alert"
+	// KindDividend of dividend announced or changed, with data of DividendData
+	KindDividend Kind = "dividend"
+)
+
+// Event published to sinks, which is encoded as JSON.
+type Event struct {
+	// ID of event for consumers to deduplicate events delivered more than once.
+	ID     string    `json:"id"`
+	Kind   Kind      `json:"kind"`
+	Symbol string    `json:"symbol"`
+	Time   time.Time `json:"time"`
+	// Data of event, which depends on kind of it.
+	Data interface{} `json:"data"`
+}
+
+// PriceData of price event.
+type PriceData struct {
+	Price aastocks.PriceResult `json:"price"`
+	Quote *aastocks.Quote      `json:"quote,omitempty"`
+}
+
+// AlertData of alert event.
+type AlertData struct {
+	Rule  alert.Rule      `json:"rule"`
+	Value float64         `json:"value"`
+	Quote *aastocks.Quote `json:"quote,omitempty"`
+}
+
+// DividendData of dividend event, previous is nil when the dividend is newly announced.
+type DividendData struct {
+	Dividend aastocks.Dividend  `json:"dividend"`
+	Previous *aastocks.Dividend `json:"previous,omitempty"`
+}
+
+// PriceEvent of price polled.
+func PriceEvent(e aastocks.PriceEvent) Event {
+	return Event{
+		ID:     fmt.Sprintf("%v:%v:%v", KindPrice, e.Price.Symbol, e.Price.Time.UnixNano()),
+		Kind:   KindPrice,
+		Symbol: e.Price.Symbol,
+		Time:   e.Price.Time,
+		Data: PriceData{
+			Price: e.Price,
+			Quote: e.Quote,
+		},
+	}
+}
+
+// AlertEvent of alert fired.
+func AlertEvent(a alert.AlertEvent) Event {
+	return Event{
+		ID:     fmt.Sprintf("%v:%v:%v:%v", KindAlert, a.Rule.Symbol, a.Rule.Name, a.Time.UnixNano()),
+		Kind:   KindAlert,
+		Symbol: a.Rule.Symbol,
+		Time:   a.Time,
+		Data: AlertData{
+			Rule:  a.Rule,
+			Value: a.Value,
+			Quote: a.Quote,
+		},
+	}
+}
+
+// DividendEvent of dividend announced or changed.
+// Its ID is derived from the dividend rather than time of event, as the same change may be emitted again after restart.
+func DividendEvent(e aastocks.DividendEvent) Event {
+	d := e.Dividend
+	return Event{
+		ID: fmt.Sprintf("%v:%v:%v:%v:%v:%v:%v", KindDividend, e.Symbol, d.AnnounceDate.Format("20060102"),
+			d.Event, d.Particular, d.ExDate.Format("20060102"), d.PayableDate.Format("20060102")),
+		Kind:   KindDividend,
+		Symbol: e.Symbol,
+		Time:   e.Time,
+		Data: DividendData{
+			Dividend: e.Dividend,
+			Previous: e.Previous,
+		},
+	}
+}
+
+// Sink of events.
+type Sink interface {
+	// Publish events in order. Error is returned if any of them failed to be delivered,
+	// which may happen after some of them are delivered.
+	Publish(ctx context.Context, events []Event) error
+	// Close sink, events should not be published after it is closed.
+	Close() error
+}
+
+// convert events of channel until it is closed or context is done.
+func convert(ctx context.Context, f func(send func(e Event) bool) bool) <-chan Event {
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		send := func(e Event) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case events <- e:
+				return true
+			}
+		}
+		for f(send) {
+		}
+	}()
+	return events
+}
+
+// Prices converts price events to events, price events with errors are skipped.
+// Channel of events is closed when price events are closed or context is done.
+func Prices(ctx context.Context, prices <-chan aastocks.PriceEvent) <-chan Event {
+	return convert(ctx, func(send func(e Event) bool) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case p, ok := <-prices:
+			if !ok {
+				return false
+			}
+			if p.Err != nil {
+				return true
+			}
+			return send(PriceEvent(p))
+		}
+	})
+}
+
+// Alerts converts alerts to events, alerts with errors are skipped.
+// Channel of events is closed when alerts are closed or context is done.
+func Alerts(ctx context.Context, alerts <-chan alert.AlertEvent) <-chan Event {
+	return convert(ctx, func(send func(e Event) bool) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case a, ok := <-alerts:
+			if !ok {
+				return false
+			}
+			if a.Err != nil {
+				return true
+			}
+			return send(AlertEvent(a))
+		}
+	})
+}
+
+// Dividends converts dividend events to events.
+// Channel of events is closed when dividend events are closed or context is done.
+func Dividends(ctx context.Context, dividends <-chan aastocks.DividendEvent) <-chan Event {
+	return convert(ctx, func(send func(e Event) bool) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case d, ok := <-dividends:
+			if !ok {
+				return false
+			}
+			return send(DividendEvent(d))
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/alert"
+)
+
+func TestEvents(t *testing.T) {
+	at := time.Date(2020, time.August, 25, 13, 18, 38, 0, time.UTC)
+	dividend := aastocks.Dividend{
+		AnnounceDate: time.Date(2020, time.August, 5, 0, 0, 0, 0, time.UTC),
+		Event:        "Interim Results",
+		Particular:   "D:HKD 0.78",
+		ExDate:       time.Date(2020, time.August, 25, 0, 0, 0, 0, time.UTC),
+		PayableDate:  time.Date(2020, time.September, 8, 0, 0, 0, 0, time.UTC),
+	}
+	testCases := []struct {
+		desc     string
+		event    Event
+		expected string
+	}{
+		{
+			desc: "Price",
+			event: PriceEvent(aastocks.PriceEvent{
+				Price: aastocks.PriceResult{Symbol: "00006", Price: 44.65, Time: at},
+			}),
+			expected: `{"id":"price:00006:1598361518000000000","kind":"price","symbol":"00006","time":"2020-08-25T13:18:38Z",` +
+				`"data":{"price":{"price":44.65,"symbol":"00006","time":"2020-08-25T13:18:38Z"}}}`,
+		},
+		{
+			desc: "Alert",
+			event: AlertEvent(alert.AlertEvent{
+				Rule:  alert.Rule{Name: "above", Symbol: "00006", Kind: alert.PriceCrossAbove, Value: 44.5},
+				Value: 44.65,
+				Time:  at,
+			}),
+			expected: `{"id":"alert:00006:above:1598361518000000000","kind":"alert","symbol":"00006","time":"2020-08-25T13:18:38Z",` +
+				`"data":{"rule":{"name":"above","symbol":"00006","kind":"price_cross_above","value":44.5,"hysteresis":0,"cooldown":"0s"},"value":44.65}}`,
+		},
+		{
+			desc: "Dividend",
+			event: DividendEvent(aastocks.DividendEvent{
+				Symbol:   "00006",
+				Dividend: dividend,
+				Time:     at,
+			}),
+			expected: `{"id":"dividend:00006:20200805:Interim Results:D:HKD 0.78:20200825:20200908","kind":"dividend","symbol":"00006","time":"2020-08-25T13:18:38Z",` +
+				`"data":{"dividend":{"announce_date":"2020-08-05T00:00:00Z","year_ended":"0001-01-01T00:00:00Z","event":"Interim Results",` +
+				`"particular":"D:HKD 0.78","type":"","ex_date":"2020-08-25T00:00:00Z","payable_date":"2020-09-08T00:00:00Z"}}}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			b, err := json.Marshal(tC.event)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tC.expected, string(b)); diff != "" {
+				t.Errorf("event mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrices(t *testing.T) {
+	at := time.Unix(1598361518, 0)
+	prices := make(chan aastocks.PriceEvent, 3)
+	prices <- aastocks.PriceEvent{Price: aastocks.PriceResult{Symbol: "00006", Price: 44.65, Time: at}}
+	prices <- aastocks.PriceEvent{Price: aastocks.PriceResult{Symbol: "00005"}, Err: context.DeadlineExceeded}
+	prices <- aastocks.PriceEvent{Price: aastocks.PriceResult{Symbol: "00005", Price: 29.5, Time: at}}
+	close(prices)
+
+	actual := make([]string, 0)
+	for e := range Prices(context.Background(), prices) {
+		actual = append(actual, e.ID)
+	}
+	expected := []string{"price:00006:1598361518000000000", "price:00005:1598361518000000000"}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("events mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAlerts(t *testing.T) {
+	at := time.Unix(1598361518, 0)
+	rule := alert.Rule{Name: "Breakout", Symbol: "00006", Kind: alert.Expression, Expr: "price > 45"}
+	alerts := make(chan alert.AlertEvent, 2)
+	alerts <- alert.AlertEvent{Rule: rule, Time: at, Err: fmt.Errorf("Rule failed to be evaluated")}
+	alerts <- alert.AlertEvent{Rule: rule, Value: 1, Time: at}
+	close(alerts)
+
+	actual := make([]string, 0)
+	for e := range Alerts(context.Background(), alerts) {
+		actual = append(actual, e.ID)
+	}
+	expected := []string{"alert:00006:Breakout:1598361518000000000"}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("events mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAlertsContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	alerts := make(chan alert.AlertEvent)
+	events := Alerts(ctx, alerts)
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Errorf("expected events to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Errorf("expected events to be closed when context is done")
+	}
+}
@@ -0,0 +1,195 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers of webhook requests.
+const (
+	// TimestampHeader of webhook request, in Unix seconds when it is signed
+	TimestampHeader = "X-Aastocks-Timestamp"
+	// SignatureHeader of webhook request, as "sha256=" followed by hex of HMAC-SHA256 of timestamp, "." and body
+	SignatureHeader = "X-Aastocks-Signature"
+)
+
+// WebhookConfig of webhook.
+type WebhookConfig struct {
+	// URL which batches of events are posted to, as JSON array.
+	URL string
+	// Secret to sign requests with, requests are not signed if it is empty.
+	Secret []byte
+	// Client posting requests, http.DefaultClient is used if it is nil.
+	Client *http.Client
+	// Retries of request after it is failed, 3 retries are made if it is zero, and none if it is negative.
+	Retries int
+	// Backoff before the first retry, which is doubled for each retry. 1 second is used if it is zero.
+	Backoff time.Duration
+	// Header added to requests (i.e. Authorization).
+	Header http.Header
+}
+
+// Webhook posts batches of events to URL, signed with HMAC-SHA256 of the secret.
+//
+// Delivery is at least once within retries: batch is retried on network errors, 5xx and 429 responses,
+// and it is failed on other non 2xx responses. Batch retried may be received more than once,
+// if response of the receiver is lost, so receivers should deduplicate events by ID.
+// Timestamp is signed along with body, so that receivers can reject requests replayed long after.
+type Webhook struct {
+	config WebhookConfig
+	client *http.Client
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// NewWebhook creates webhook posting to URL of the config.
+func NewWebhook(config WebhookConfig) (*Webhook, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("Webhook URL is invalid: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("Webhook URL is invalid: %v", config.URL)
+	}
+	if config.Retries == 0 {
+		config.Retries = 3
+	}
+	if config.Retries < 0 {
+		config.Retries = 0
+	}
+	if config.Backoff <= 0 {
+		config.Backoff = time.Second
+	}
+	client := config.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Webhook{
+		config: config,
+		client: client,
+		now:    time.Now,
+		sleep:  sleep,
+	}, nil
+}
+
+// sleep for duration until context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Sign body with secret at timestamp, as value of SignatureHeader.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify signature of webhook request received, with body read from it.
+// Request is rejected if its timestamp is not within tolerance from now, tolerance is not checked if it is zero.
+func Verify(secret []byte, req *http.Request, body []byte, tolerance time.Duration) error {
+	timestamp := req.Header.Get(TimestampHeader)
+	signature := req.Header.Get(SignatureHeader)
+	if timestamp == "" || signature == "" {
+		return fmt.Errorf("Webhook signature cannot be found")
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return fmt.Errorf("Webhook signature is invalid")
+	}
+	if tolerance == 0 {
+		return nil
+	}
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("Webhook timestamp is invalid: %v", timestamp)
+	}
+	age := time.Since(time.Unix(sec, 0))
+	if age > tolerance || age < -tolerance {
+		return fmt.Errorf("Webhook timestamp is out of tolerance: %v", timestamp)
+	}
+	return nil
+}
+
+// Publish events as single request, which is retried with backoff.
+func (w *Webhook) Publish(ctx context.Context, events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	backoff := w.config.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= w.config.Retries {
+			return fmt.Errorf("Webhook failed after %v attempts: %w", attempt+1, err)
+		}
+		err = w.sleep(ctx, backoff)
+		if err != nil {
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// post body to URL, and returns whether it should be retried if it is failed.
+func (w *Webhook) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range w.config.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(w.config.Secret) > 0 {
+		timestamp := strconv.FormatInt(w.now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(w.config.Secret, timestamp, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("Webhook responded %v", resp.Status)
+	if msg := strings.TrimSpace(string(b)); msg != "" {
+		err = fmt.Errorf("Webhook responded %v: %v", resp.Status, msg)
+	}
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+// Close webhook, which holds no resources.
+func (w *Webhook) Close() error {
+	return nil
+}
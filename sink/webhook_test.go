@@ -0,0 +1,243 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// webhookServer responds statuses in order, and records events of requests verified.
+type webhookServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests int
+	events   []string
+	errors   []string
+}
+
+func newWebhookServer(t *testing.T, secret []byte, statuses ...int) *webhookServer {
+	s := &webhookServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		status := http.StatusOK
+		if s.requests < len(s.statuses) {
+			status = s.statuses[s.requests]
+		}
+		s.requests++
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = Verify(secret, r, body, time.Minute)
+		if err != nil {
+			s.errors = append(s.errors, err.Error())
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		var events []Event
+		err = json.Unmarshal(body, &events)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		for _, e := range events {
+			s.events = append(s.events, e.ID)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestWebhook(t *testing.T) {
+	secret := []byte("secret")
+	testCases := []struct {
+		desc     string
+		statuses []int
+		retries  int
+		err      string
+		requests int
+		events   []string
+		backoffs []time.Duration
+	}{
+		{
+			desc:     "Delivered",
+			requests: 1,
+			events:   []string{"1", "2"},
+			backoffs: []time.Duration{},
+		},
+		{
+			desc:     "RetriedWithBackoff",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			requests: 3,
+			events:   []string{"1", "2"},
+			backoffs: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			desc:     "RetriesExhausted",
+			statuses: []int{http.StatusBadGateway, http.StatusBadGateway},
+			retries:  1,
+			err:      "Webhook failed after 2 attempts: Webhook responded 502 Bad Gateway",
+			requests: 2,
+			backoffs: []time.Duration{time.Second},
+		},
+		{
+			desc:     "NotRetriedForClientError",
+			statuses: []int{http.StatusBadRequest},
+			err:      "Webhook failed after 1 attempts: Webhook responded 400 Bad Request",
+			requests: 1,
+			backoffs: []time.Duration{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			server := newWebhookServer(t, secret, tC.statuses...)
+			w, err := NewWebhook(WebhookConfig{URL: server.URL, Secret: secret, Retries: tC.retries})
+			if err != nil {
+				t.Fatal(err)
+			}
+			backoffs := make([]time.Duration, 0)
+			w.sleep = func(ctx context.Context, d time.Duration) error {
+				backoffs = append(backoffs, d)
+				return nil
+			}
+
+			err = w.Publish(context.Background(), []Event{{ID: "1"}, {ID: "2"}})
+			if tC.err == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tC.err != "" && (err == nil || err.Error() != tC.err) {
+				t.Errorf("expected error %q, but got %v", tC.err, err)
+			}
+			if server.requests != tC.requests {
+				t.Errorf("expected %v requests, but got %v", tC.requests, server.requests)
+			}
+			if diff := cmp.Diff(tC.events, server.events); diff != "" {
+				t.Errorf("events mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tC.backoffs, backoffs); diff != "" {
+				t.Errorf("backoffs mismatch (-want +got):\n%s", diff)
+			}
+			if len(server.errors) > 0 {
+				t.Errorf("expected requests to be verified, but got %v", server.errors)
+			}
+		})
+	}
+}
+
+func TestWebhookHeader(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+	}))
+	defer server.Close()
+	w, err := NewWebhook(WebhookConfig{
+		URL:    server.URL,
+		Header: http.Header{"Authorization": []string{"Bearer token"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Publish(context.Background(), []Event{{ID: "1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Get("Authorization") != "Bearer token" {
+		t.Errorf("expected Authorization header, but got %v", header.Get("Authorization"))
+	}
+	if header.Get("Content-Type") != "application/json" {
+		t.Errorf("expected Content-Type header, but got %v", header.Get("Content-Type"))
+	}
+	if header.Get(SignatureHeader) != "" {
+		t.Errorf("expected request not to be signed, but got %v", header.Get(SignatureHeader))
+	}
+}
+
+func TestNewWebhookInvalidURL(t *testing.T) {
+	_, err := NewWebhook(WebhookConfig{URL: "ftp://example.com"})
+	if err == nil || err.Error() != "Webhook URL is invalid: ftp://example.com" {
+		t.Errorf("expected invalid URL error, but got %v", err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	secret := []byte("secret")
+	body := []byte(`[{"id":"1"}]`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	testCases := []struct {
+		desc      string
+		timestamp string
+		signature string
+		body      []byte
+		err       string
+	}{
+		{
+			desc:      "Valid",
+			timestamp: now,
+			signature: Sign(secret, now, body),
+			body:      body,
+		},
+		{
+			desc:      "Missing",
+			timestamp: now,
+			body:      body,
+			err:       "Webhook signature cannot be found",
+		},
+		{
+			desc:      "TamperedBody",
+			timestamp: now,
+			signature: Sign(secret, now, body),
+			body:      []byte(`[{"id":"2"}]`),
+			err:       "Webhook signature is invalid",
+		},
+		{
+			desc:      "WrongSecret",
+			timestamp: now,
+			signature: Sign([]byte("other"), now, body),
+			body:      body,
+			err:       "Webhook signature is invalid",
+		},
+		{
+			desc:      "Replayed",
+			timestamp: old,
+			signature: Sign(secret, old, body),
+			body:      body,
+			err:       "Webhook timestamp is out of tolerance: " + old,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(tC.body)))
+			if tC.timestamp != "" {
+				req.Header.Set(TimestampHeader, tC.timestamp)
+			}
+			if tC.signature != "" {
+				req.Header.Set(SignatureHeader, tC.signature)
+			}
+			err := Verify(secret, req, tC.body, time.Minute)
+			actual := ""
+			if err != nil {
+				actual = err.Error()
+			}
+			if actual != tC.err {
+				t.Errorf("expected error %q, but got %q", tC.err, actual)
+			}
+		})
+	}
+}
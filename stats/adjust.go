@@ -0,0 +1,48 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// AdjustDividends adjusts prices before each ex-date backward for cash dividends,
+// so that returns include dividends as if they were reinvested.
+// Prices before ex-date are multiplied by 1 - dividend / close price of the day before ex-date.
+// Only HKD cash dividends are adjusted, as prices are quoted in HKD.
+func AdjustDividends(prices []aastocks.HistoricalPrice, dividends []aastocks.Dividend) []aastocks.HistoricalPrice {
+	// Factors are computed with unadjusted prices, and then applied cumulatively
+	factors := make([]float64, len(prices))
+	for i := range factors {
+		factors[i] = 1
+	}
+	for _, d := range dividends {
+		currency, amount, ok := d.Cash()
+		if !ok || currency != "HKD" || d.ExDate.IsZero() {
+			continue
+		}
+		// Index of the first price on or after ex-date
+		i := sort.Search(len(prices), func(i int) bool {
+			return !prices[i].Time.Before(d.ExDate)
+		})
+		if i == 0 || i == len(prices) {
+			continue
+		}
+		factor := 1 - amount/prices[i-1].Close
+		for j := 0; j < i; j++ {
+			factors[j] *= factor
+		}
+	}
+
+	adjusted := make([]aastocks.HistoricalPrice, len(prices))
+	copy(adjusted, prices)
+	for j, factor := range factors {
+		if factor != 1 {
+			adjusted[j].Open *= factor
+			adjusted[j].High *= factor
+			adjusted[j].Low *= factor
+			adjusted[j].Close *= factor
+		}
+	}
+	return adjusted
+}
@@ -0,0 +1,45 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+func TestAdjustDividends(t *testing.T) {
+	prices := pricesOf(10, 10, 9, 9, 10)
+	dividends := []aastocks.Dividend{
+		{
+			Particular: "D:HKD 1.0000",
+			Type:       "Cash",
+			ExDate:     day(2),
+		},
+		{
+			Particular: "D:HKD 0.9000",
+			Type:       "Cash",
+			ExDate:     day(4),
+		},
+		{
+			Particular: "D:RMB 1.0000",
+			Type:       "Cash",
+			ExDate:     day(3),
+		},
+		{
+			Particular: "Preferential Offer",
+			Type:       "-",
+			ExDate:     day(1),
+		},
+	}
+	adjusted := AdjustDividends(prices, dividends)
+
+	expected := pricesOf(8.1, 8.1, 8.1, 8.1, 10)
+	diff := cmp.Diff(expected, adjusted, equateFloat)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff(pricesOf(10, 10, 9, 9, 10), prices)
+	if diff != "" {
+		t.Fatalf("prices should not be modified: %v", diff)
+	}
+}
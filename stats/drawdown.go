@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Drawdown is the maximum decline of close prices from peak to trough.
+type Drawdown struct {
+	// Depth is the decline from peak as fraction (i.e. 0.25 for 25% decline).
+	Depth  float64
+	Peak   time.Time
+	Trough time.Time
+	// Recovery is the time close price regains the peak, zero if it is not recovered yet.
+	Recovery time.Time
+	// Duration is from peak to recovery, or to the last price if it is not recovered yet.
+	Duration time.Duration
+}
+
+// MaxDrawdown of close prices.
+func MaxDrawdown(prices []aastocks.HistoricalPrice) Drawdown {
+	var max Drawdown
+	if len(prices) == 0 {
+		return max
+	}
+	peak := prices[0]
+	recovered := true
+	for _, p := range prices {
+		if p.Close >= peak.Close {
+			if !recovered && peak.Time.Equal(max.Peak) {
+				max.Recovery = p.Time
+				max.Duration = p.Time.Sub(max.Peak)
+			}
+			peak = p
+			recovered = true
+			continue
+		}
+		depth := 1 - p.Close/peak.Close
+		if depth > max.Depth {
+			max = Drawdown{
+				Depth:  depth,
+				Peak:   peak.Time,
+				Trough: p.Time,
+			}
+		}
+		recovered = false
+	}
+	if max.Depth > 0 && max.Recovery.IsZero() {
+		max.Duration = prices[len(prices)-1].Time.Sub(max.Peak)
+	}
+	return max
+}
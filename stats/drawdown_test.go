@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+func TestMaxDrawdown(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		prices   []aastocks.HistoricalPrice
+		drawdown Drawdown
+	}{
+		{
+			desc:   "Recovered",
+			prices: pricesOf(100, 80, 90, 100, 95),
+			drawdown: Drawdown{
+				Depth:    0.2,
+				Peak:     day(0),
+				Trough:   day(1),
+				Recovery: day(3),
+				Duration: 3 * 24 * time.Hour,
+			},
+		},
+		{
+			desc:   "NotRecovered",
+			prices: pricesOf(100, 110, 95, 60, 80),
+			drawdown: Drawdown{
+				Depth:    0.4545454545454546,
+				Peak:     day(1),
+				Trough:   day(3),
+				Duration: 3 * 24 * time.Hour,
+			},
+		},
+		{
+			desc:     "Rising",
+			prices:   pricesOf(100, 110, 120),
+			drawdown: Drawdown{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			diff := cmp.Diff(tC.drawdown, MaxDrawdown(tC.prices), equateFloat)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
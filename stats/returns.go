@@ -0,0 +1,59 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Returns are simple returns between consecutive close prices.
+func Returns(prices []aastocks.HistoricalPrice) []float64 {
+	if len(prices) < 2 {
+		return []float64{}
+	}
+	returns := make([]float64, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		returns[i-1] = prices[i].Close/prices[i-1].Close - 1
+	}
+	return returns
+}
+
+// LogReturns are logarithmic returns between consecutive close prices.
+func LogReturns(prices []aastocks.HistoricalPrice) []float64 {
+	if len(prices) < 2 {
+		return []float64{}
+	}
+	returns := make([]float64, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		returns[i-1] = math.Log(prices[i].Close / prices[i-1].Close)
+	}
+	return returns
+}
+
+// AlignReturns computes simple returns of prices and benchmark on times which both of them have prices,
+// so that they can be compared with each other (i.e. for Beta and Correlation).
+func AlignReturns(prices []aastocks.HistoricalPrice, benchmark []aastocks.HistoricalPrice) ([]float64, []float64) {
+	closes := make(map[int64]float64, len(benchmark))
+	for _, b := range benchmark {
+		closes[b.Time.UnixNano()] = b.Close
+	}
+	a := make([]float64, 0)
+	b := make([]float64, 0)
+	var prev aastocks.HistoricalPrice
+	var prevBenchmark float64
+	started := false
+	for _, p := range prices {
+		c, ok := closes[p.Time.UnixNano()]
+		if !ok {
+			continue
+		}
+		if started {
+			a = append(a, p.Close/prev.Close-1)
+			b = append(b, c/prevBenchmark-1)
+		}
+		prev = p
+		prevBenchmark = c
+		started = true
+	}
+	return a, b
+}
@@ -0,0 +1,44 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+func TestReturns(t *testing.T) {
+	prices := pricesOf(100, 110, 99)
+	diff := cmp.Diff([]float64{0.1, -0.1}, Returns(prices), equateFloat)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff([]float64{0.09531017980432493, -0.10536051565782628}, LogReturns(prices), equateFloat)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff([]float64{}, Returns(pricesOf(100)))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestAlignReturns(t *testing.T) {
+	prices := pricesOf(100, 110, 99, 105)
+	// Benchmark is missing price of the second day
+	benchmark := []aastocks.HistoricalPrice{
+		{Time: day(0), Close: 1000},
+		{Time: day(2), Close: 990},
+		{Time: day(3), Close: 1000},
+		{Time: day(4), Close: 1050},
+	}
+	a, b := AlignReturns(prices, benchmark)
+	diff := cmp.Diff([]float64{-0.01, 0.06060606060606055}, a, equateFloat)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff([]float64{-0.01, 0.010101010101010166}, b, equateFloat)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"math"
+)
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// stdDev is sample standard deviation.
+func stdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return math.NaN()
+	}
+	m := mean(values)
+	var sum float64
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+	return math.Sqrt(sum / float64(len(values)-1))
+}
+
+// Volatility is annualised sample standard deviation of returns.
+func Volatility(returns []float64, periodsPerYear float64) float64 {
+	return stdDev(returns) * math.Sqrt(periodsPerYear)
+}
+
+func excessReturns(returns []float64, riskFree float64, periodsPerYear float64) []float64 {
+	excess := make([]float64, len(returns))
+	for i, r := range returns {
+		excess[i] = r - riskFree/periodsPerYear
+	}
+	return excess
+}
+
+// Sharpe is annualised Sharpe ratio of returns, with annual risk-free rate.
+func Sharpe(returns []float64, riskFree float64, periodsPerYear float64) float64 {
+	excess := excessReturns(returns, riskFree, periodsPerYear)
+	return mean(excess) / stdDev(excess) * math.Sqrt(periodsPerYear)
+}
+
+// Sortino is annualised Sortino ratio of returns, with annual risk-free rate as the target return.
+// It is infinite if there is no return below the target.
+func Sortino(returns []float64, riskFree float64, periodsPerYear float64) float64 {
+	if len(returns) < 2 {
+		return math.NaN()
+	}
+	excess := excessReturns(returns, riskFree, periodsPerYear)
+	var sum float64
+	for _, e := range excess {
+		if e < 0 {
+			sum += e * e
+		}
+	}
+	downside := math.Sqrt(sum / float64(len(excess)))
+	return mean(excess) / downside * math.Sqrt(periodsPerYear)
+}
+
+func covariance(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) < 2 {
+		return math.NaN()
+	}
+	ma, mb := mean(a), mean(b)
+	var sum float64
+	for i := range a {
+		sum += (a[i] - ma) * (b[i] - mb)
+	}
+	return sum / float64(len(a)-1)
+}
+
+// Beta of returns against benchmark returns, which should be aligned by time (i.e. with AlignReturns).
+func Beta(returns []float64, benchmark []float64) float64 {
+	return covariance(returns, benchmark) / covariance(benchmark, benchmark)
+}
+
+// Correlation is Pearson correlation between returns and benchmark returns, which should be aligned by time.
+func Correlation(returns []float64, benchmark []float64) float64 {
+	return covariance(returns, benchmark) / (stdDev(returns) * stdDev(benchmark))
+}
@@ -0,0 +1,99 @@
+// Package stats computes risk and performance statistics over historical prices of AAStocks.
+//
+// Returns are computed from close prices, which can be adjusted for dividends with AdjustDividends beforehand.
+// Statistics are annualised with periods per year of the price frequency.
+//
+//	prices, err := quote.HistoricalPrices(aastocks.Daily)
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	s := stats.Summarize(quote.Symbol, prices, hsi, stats.Config{
+//		PeriodsPerYear: stats.PeriodsPerYear(aastocks.Daily),
+//		RiskFree:       0.01,
+//	})
+package stats
+
+import (
+	"math"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// PeriodsPerYear of historical prices with the frequency.
+// Hong Kong market is assumed to have 252 trading days per year, and 6 hourly prices per trading day.
+func PeriodsPerYear(frequency aastocks.PriceFrequency) float64 {
+	switch frequency {
+	case aastocks.Hourly:
+		return 252 * 6
+	case aastocks.Weekly:
+		return 52
+	case aastocks.Monthly:
+		return 12
+	default:
+		return 252
+	}
+}
+
+// Config for summarizing statistics.
+type Config struct {
+	// PeriodsPerYear of the historical prices, 252 is used if it is zero.
+	PeriodsPerYear float64
+	// RiskFree is annual risk-free rate (i.e. 0.01 for 1%).
+	RiskFree float64
+}
+
+// Stats is summary of risk and performance statistics for a symbol.
+// Statistics are NaN if there are not enough prices to compute them.
+type Stats struct {
+	Symbol           string
+	Start            time.Time
+	End              time.Time
+	TotalReturn      float64
+	AnnualisedReturn float64
+	Volatility       float64
+	Sharpe           float64
+	Sortino          float64
+	Drawdown         Drawdown
+	// Beta and Correlation are against the benchmark, NaN if there is no benchmark.
+	Beta        float64
+	Correlation float64
+}
+
+// Summarize statistics of historical prices for the symbol, against the benchmark (i.e. HSI) which can be nil.
+func Summarize(symbol string, prices []aastocks.HistoricalPrice, benchmark []aastocks.HistoricalPrice, config Config) Stats {
+	ppy := config.PeriodsPerYear
+	if ppy == 0 {
+		ppy = 252
+	}
+	nan := math.NaN()
+	s := Stats{
+		Symbol:           symbol,
+		TotalReturn:      nan,
+		AnnualisedReturn: nan,
+		Beta:             nan,
+		Correlation:      nan,
+	}
+	if len(prices) > 0 {
+		s.Start = prices[0].Time
+		s.End = prices[len(prices)-1].Time
+	}
+
+	returns := Returns(prices)
+	if len(returns) > 0 {
+		first, last := prices[0].Close, prices[len(prices)-1].Close
+		s.TotalReturn = last/first - 1
+		s.AnnualisedReturn = math.Pow(last/first, ppy/float64(len(returns))) - 1
+	}
+	s.Volatility = Volatility(returns, ppy)
+	s.Sharpe = Sharpe(returns, config.RiskFree, ppy)
+	s.Sortino = Sortino(returns, config.RiskFree, ppy)
+	s.Drawdown = MaxDrawdown(prices)
+
+	if len(benchmark) > 0 {
+		a, b := AlignReturns(prices, benchmark)
+		s.Beta = Beta(a, b)
+		s.Correlation = Correlation(a, b)
+	}
+	return s
+}
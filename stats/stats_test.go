@@ -0,0 +1,95 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/horacehylee/aastocks"
+)
+
+var equateFloat = cmp.Options{
+	cmpopts.EquateApprox(0, 1e-9),
+	cmpopts.EquateNaNs(),
+}
+
+func day(i int) time.Time {
+	return time.Date(2020, time.August, 3+i, 0, 0, 0, 0, time.UTC)
+}
+
+func pricesOf(closes ...float64) []aastocks.HistoricalPrice {
+	prices := make([]aastocks.HistoricalPrice, len(closes))
+	for i, c := range closes {
+		prices[i] = aastocks.HistoricalPrice{
+			Time:  day(i),
+			Open:  c,
+			High:  c,
+			Low:   c,
+			Close: c,
+		}
+	}
+	return prices
+}
+
+func TestSummarize(t *testing.T) {
+	nan := math.NaN()
+	testCases := []struct {
+		desc      string
+		prices    []aastocks.HistoricalPrice
+		benchmark []aastocks.HistoricalPrice
+		stats     Stats
+	}{
+		{
+			desc:      "WithBenchmark",
+			prices:    pricesOf(100, 110, 99, 105, 120, 90, 95, 125),
+			benchmark: pricesOf(1000, 1010, 990, 1000, 1050, 980, 990, 1060),
+			stats: Stats{
+				Symbol:           "00006",
+				Start:            day(0),
+				End:              day(7),
+				TotalReturn:      0.25,
+				AnnualisedReturn: 3080.4879110195775,
+				Volatility:       2.856817421888219,
+				Sharpe:           4.084228935286663,
+				Sortino:          7.218761471568988,
+				Drawdown: Drawdown{
+					Depth:    0.25,
+					Peak:     day(4),
+					Trough:   day(5),
+					Recovery: day(7),
+					Duration: 3 * 24 * time.Hour,
+				},
+				Beta:        3.926465203062089,
+				Correlation: 0.9776532754668532,
+			},
+		},
+		{
+			desc:   "WithoutPrices",
+			prices: []aastocks.HistoricalPrice{},
+			stats: Stats{
+				Symbol:           "00006",
+				TotalReturn:      nan,
+				AnnualisedReturn: nan,
+				Volatility:       nan,
+				Sharpe:           nan,
+				Sortino:          nan,
+				Beta:             nan,
+				Correlation:      nan,
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := Summarize("00006", tC.prices, tC.benchmark, Config{
+				PeriodsPerYear: PeriodsPerYear(aastocks.Daily),
+				RiskFree:       0.0252,
+			})
+			diff := cmp.Diff(tC.stats, s, equateFloat)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
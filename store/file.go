@@ -0,0 +1,116 @@
+package store
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+	"github.com/horacehylee/aastocks/internal/atomicfile"
+)
+
+var priceHeader = []string{"time", "open", "high", "low", "close", "volume"}
+
+// readCSV reads records of CSV file without its header, empty records are returned if it does not exist.
+func readCSV(path string, header []string) ([][]string, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return [][]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = len(header)
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%v failed to be read: %w", path, err)
+	}
+	if len(records) == 0 {
+		return [][]string{}, nil
+	}
+	return records[1:], nil
+}
+
+// writeCSV writes records to CSV file with header.
+// File is replaced atomically, so partially written records will not be read.
+func writeCSV(path string, header []string, records [][]string) error {
+	return atomicfile.Write(path, func(f io.Writer) error {
+		w := csv.NewWriter(f)
+		w.Write(header)
+		w.WriteAll(records)
+		return w.Error()
+	})
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func parseFloats(s []string) ([]float64, error) {
+	values := make([]float64, len(s))
+	for i := range s {
+		v, err := strconv.ParseFloat(s[i], 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// readPrices reads prices from CSV file, empty prices are returned if it does not exist.
+func readPrices(path string) ([]aastocks.HistoricalPrice, error) {
+	records, err := readCSV(path, priceHeader)
+	if err != nil {
+		return nil, err
+	}
+	prices := make([]aastocks.HistoricalPrice, len(records))
+	for i, record := range records {
+		prices[i], err = parsePrice(record)
+		if err != nil {
+			return nil, fmt.Errorf("Price failed to be parsed at %v:%v: %w", path, i+2, err)
+		}
+	}
+	return prices, nil
+}
+
+func parsePrice(record []string) (aastocks.HistoricalPrice, error) {
+	t, err := time.Parse(time.RFC3339Nano, record[0])
+	if err != nil {
+		return aastocks.HistoricalPrice{}, err
+	}
+	values, err := parseFloats(record[1:])
+	if err != nil {
+		return aastocks.HistoricalPrice{}, err
+	}
+	return aastocks.HistoricalPrice{
+		Time:   t,
+		Open:   values[0],
+		High:   values[1],
+		Low:    values[2],
+		Close:  values[3],
+		Volume: values[4],
+	}, nil
+}
+
+// writePrices writes prices to CSV file.
+func writePrices(path string, prices []aastocks.HistoricalPrice) error {
+	records := make([][]string, len(prices))
+	for i, p := range prices {
+		records[i] = []string{
+			p.Time.Format(time.RFC3339Nano),
+			formatFloat(p.Open),
+			formatFloat(p.High),
+			formatFloat(p.Low),
+			formatFloat(p.Close),
+			formatFloat(p.Volume),
+		}
+	}
+	return writeCSV(path, priceHeader, records)
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// RecorderConfig of snapshot recorder.
+type RecorderConfig struct {
+	// Symbols of universe to be recorded.
+	Symbols []string
+	// Interval between recordings of the universe, 1 hour is used if it is zero.
+	Interval time.Duration
+	// Limiter to limit requests to AAStocks, requests are not limited if it is nil.
+	Limiter *aastocks.RateLimiter
+	// Schedule to record only when market is open (i.e. hkex.MarketCalendar), it is always recorded if it is nil.
+	Schedule aastocks.Schedule
+}
+
+// SymbolError is error of recording the symbol.
+type SymbolError struct {
+	Symbol string
+	Err    error
+}
+
+func (e SymbolError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Symbol, e.Err)
+}
+
+// RecordResult of recording the universe.
+type RecordResult struct {
+	Time time.Time
+	// Added is number of snapshots added, quotes unchanged since the last recording are not added again.
+	Added  int
+	Errors []SymbolError
+}
+
+// Recorder records snapshots of quotes of universe periodically into store.
+type Recorder struct {
+	store  *Store
+	config RecorderConfig
+	now    func() time.Time
+}
+
+// NewRecorder creates snapshot recorder, quotes are fetched with options of the store.
+func NewRecorder(store *Store, config RecorderConfig) *Recorder {
+	if config.Interval <= 0 {
+		config.Interval = time.Hour
+	}
+	return &Recorder{
+		store:  store,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// Record snapshots of quotes of the universe once.
+// Symbols failed to be fetched or stored are skipped with errors in result.
+func (r *Recorder) Record(ctx context.Context) *RecordResult {
+	result := &RecordResult{
+		Time:   r.now(),
+		Errors: make([]SymbolError, 0),
+	}
+	for _, symbol := range r.config.Symbols {
+		if r.config.Limiter != nil {
+			err := r.config.Limiter.Wait(ctx)
+			if err != nil {
+				result.Errors = append(result.Errors, SymbolError{Symbol: symbol, Err: err})
+				return result
+			}
+		}
+		err := ctx.Err()
+		if err != nil {
+			result.Errors = append(result.Errors, SymbolError{Symbol: symbol, Err: err})
+			return result
+		}
+		q, err := aastocks.Get(symbol, r.store.opts...)
+		if err != nil {
+			result.Errors = append(result.Errors, SymbolError{Symbol: symbol, Err: err})
+			continue
+		}
+		added, err := r.store.AddSnapshots(NewSnapshot(q))
+		if err != nil {
+			result.Errors = append(result.Errors, SymbolError{Symbol: symbol, Err: err})
+			continue
+		}
+		result.Added += added
+	}
+	return result
+}
+
+// Run records snapshots of the universe every interval, until context is done.
+// Results of recordings are sent to the channel, which is closed once it is ended.
+func (r *Recorder) Run(ctx context.Context) <-chan *RecordResult {
+	results := make(chan *RecordResult)
+	go func() {
+		defer close(results)
+		timeout := time.After(0)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timeout:
+			}
+			if r.config.Schedule != nil {
+				now := r.now()
+				wait := r.config.Schedule.NextOpen(now).Sub(now)
+				if wait > 0 {
+					timeout = time.After(wait)
+					continue
+				}
+			}
+			timeout = time.After(r.config.Interval)
+			result := r.Record(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case results <- result:
+			}
+		}
+	}()
+	return results
+}
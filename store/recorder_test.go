@@ -0,0 +1,175 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/horacehylee/aastocks"
+)
+
+var snapshot00006 = Snapshot{
+	Time: time.Date(2020, time.August, 25, 21, 18, 38, 0, time.UTC),
+	Quote: aastocks.Quote{
+		Symbol:       "00006",
+		Name:         "POWER ASSETS",
+		Industry:     "Electricity Supply",
+		Price:        44.65,
+		PrevClose:    44.2,
+		Price52WLow:  41.6,
+		Price52WHigh: 58.5,
+		Yield:        0.06271,
+		PeRatio:      13.368,
+		PbRatio:      1.115,
+		Lots:         500,
+		Eps:          3.34,
+	},
+}
+
+func TestRecorderRecord(t *testing.T) {
+	s := testStore(t)
+	r := NewRecorder(s, RecorderConfig{
+		Symbols: []string{"00006", "151511"},
+		Limiter: aastocks.NewRateLimiter(time.Millisecond, 1),
+	})
+
+	result := r.Record(context.Background())
+	diff := cmp.Diff(1, result.Added)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff(1, len(result.Errors))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff("151511", result.Errors[0].Symbol)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	if !errors.Is(result.Errors[0].Err, aastocks.ErrSymbolNotFound) {
+		t.Fatalf("Error should be symbol not found, but got %v", result.Errors[0].Err)
+	}
+
+	// Quote not updated is not added again
+	result = r.Record(context.Background())
+	diff = cmp.Diff(0, result.Added)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	snapshots, err := s.Snapshots("00006", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff = cmp.Diff([]Snapshot{snapshot00006}, snapshots, ignoreClient)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+// updatingTransport serves detail page of the quote, with server date advanced by a minute on every fetch.
+type updatingTransport struct {
+	fetched int
+}
+
+func (u *updatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	b, err := ioutil.ReadFile("../testdata/detail_quote.html")
+	if err != nil {
+		return nil, err
+	}
+	date := snapshot00006.Time.Add(time.Duration(u.fetched) * time.Minute).Format("2006-01-02T15:04:05")
+	b = bytes.Replace(b, []byte("2020-08-25T21:18:38"), []byte(date), 1)
+	u.fetched++
+	recorder := httptest.NewRecorder()
+	recorder.Write(b)
+	return recorder.Result(), nil
+}
+
+func TestRecorderRecordUnchanged(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	s, err := Open(dir, aastocks.WithClient(&http.Client{Transport: &updatingTransport{}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewRecorder(s, RecorderConfig{Symbols: []string{"00006"}})
+
+	added := make([]int, 0)
+	for i := 0; i < 2; i++ {
+		result := r.Record(context.Background())
+		if len(result.Errors) > 0 {
+			t.Fatal(result.Errors[0])
+		}
+		added = append(added, result.Added)
+	}
+	// Quote updated with the same fundamentals is not added again
+	diff := cmp.Diff([]int{1, 0}, added)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	snapshots, err := s.Snapshots("00006", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff = cmp.Diff([]Snapshot{snapshot00006}, snapshots, ignoreClient)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+type delayedSchedule struct {
+	open time.Time
+}
+
+func (s delayedSchedule) NextOpen(t time.Time) time.Time {
+	if t.Before(s.open) {
+		return s.open
+	}
+	return t
+}
+
+func TestRecorderRun(t *testing.T) {
+	s := testStore(t)
+	start := time.Now()
+	r := NewRecorder(s, RecorderConfig{
+		Symbols:  []string{"00006"},
+		Schedule: delayedSchedule{open: start.Add(50 * time.Millisecond)},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := r.Run(ctx)
+
+	select {
+	case result := <-results:
+		diff := cmp.Diff(1, result.Added)
+		if diff != "" {
+			t.Fatalf(diff)
+		}
+		if result.Time.Before(start.Add(50 * time.Millisecond)) {
+			t.Fatalf("Snapshots should be recorded once market is open, but recorded at %v", result.Time)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Timeout is triggered, expect result to be received")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-results:
+		if ok {
+			t.Fatalf("Results should be closed")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Timeout is triggered, expect results to be closed")
+	}
+}
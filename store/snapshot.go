@@ -0,0 +1,298 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+// Snapshot of quote fundamentals at its update time.
+type Snapshot struct {
+	Time time.Time
+	// Quote of snapshot, its update time is not stored as it is time of snapshot.
+	aastocks.Quote
+}
+
+// NewSnapshot takes snapshot of the quote, with time of its update time.
+func NewSnapshot(q *aastocks.Quote) Snapshot {
+	quote := *q
+	quote.UpdateTime = time.Time{}
+	return Snapshot{
+		Time:  q.UpdateTime,
+		Quote: quote,
+	}
+}
+
+var snapshotHeader = []string{"time", "name", "industry", "price", "prev_close", "price_52w_low", "price_52w_high", "yield", "pe_ratio", "pb_ratio", "lots", "eps"}
+
+func (s *Store) snapshotPath(symbol string) (string, error) {
+	err := checkSymbol(symbol)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, symbol, "snapshots.csv"), nil
+}
+
+// AddSnapshots stores snapshots, snapshots of the same symbol and time are replaced.
+// Snapshots with the same fields as the previous snapshot of the symbol are not added,
+// as update time of quote changes on every fetch even if its fundamentals are unchanged.
+// Number of snapshots added is returned.
+func (s *Store) AddSnapshots(snapshots ...Snapshot) (int, error) {
+	bySymbol := make(map[string][]Snapshot)
+	symbols := make([]string, 0)
+	for _, snapshot := range snapshots {
+		if _, ok := bySymbol[snapshot.Symbol]; !ok {
+			symbols = append(symbols, snapshot.Symbol)
+		}
+		bySymbol[snapshot.Symbol] = append(bySymbol[snapshot.Symbol], snapshot)
+	}
+	added := 0
+	for _, symbol := range symbols {
+		n, err := s.addSnapshots(symbol, bySymbol[symbol])
+		added += n
+		if err != nil {
+			return added, err
+		}
+	}
+	return added, nil
+}
+
+func (s *Store) addSnapshots(symbol string, snapshots []Snapshot) (int, error) {
+	path, err := s.snapshotPath(symbol)
+	if err != nil {
+		return 0, err
+	}
+	unlock := s.lock(path)
+	defer unlock()
+
+	stored, err := readSnapshots(path, symbol)
+	if err != nil {
+		return 0, err
+	}
+	byTime := make(map[int64]int, len(stored)+len(snapshots))
+	merged := make([]Snapshot, 0, len(stored)+len(snapshots))
+	for _, snapshot := range stored {
+		byTime[snapshot.Time.UnixNano()] = len(merged)
+		merged = append(merged, snapshot)
+	}
+	fresh := make(map[int64]bool, len(snapshots))
+	for _, snapshot := range snapshots {
+		if i, ok := byTime[snapshot.Time.UnixNano()]; ok {
+			merged[i] = snapshot
+			continue
+		}
+		byTime[snapshot.Time.UnixNano()] = len(merged)
+		fresh[snapshot.Time.UnixNano()] = true
+		merged = append(merged, snapshot)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+	added := 0
+	kept := merged[:0]
+	for _, snapshot := range merged {
+		if fresh[snapshot.Time.UnixNano()] {
+			if len(kept) > 0 && sameFields(kept[len(kept)-1], snapshot) {
+				continue
+			}
+			added++
+		}
+		kept = append(kept, snapshot)
+	}
+	err = writeSnapshots(path, kept)
+	if err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+// Snapshots stored for the symbol within range, from is inclusive and to is exclusive.
+// Range is not bounded by zero time. Empty snapshots are returned if none is stored.
+func (s *Store) Snapshots(symbol string, from, to time.Time) ([]Snapshot, error) {
+	path, err := s.snapshotPath(symbol)
+	if err != nil {
+		return nil, err
+	}
+	unlock := s.lock(path)
+	defer unlock()
+
+	stored, err := readSnapshots(path, symbol)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Snapshot, 0, len(stored))
+	for _, snapshot := range stored {
+		if (!from.IsZero() && snapshot.Time.Before(from)) || (!to.IsZero() && !snapshot.Time.Before(to)) {
+			continue
+		}
+		result = append(result, snapshot)
+	}
+	return result, nil
+}
+
+// AsOf returns the latest snapshot of each symbol stored at or before the time, ordered by symbol.
+// Symbols without snapshots at the time are skipped.
+func (s *Store) AsOf(t time.Time) ([]Snapshot, error) {
+	symbols, err := s.Symbols()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Snapshot, 0, len(symbols))
+	for _, symbol := range symbols {
+		snapshots, err := s.Snapshots(symbol, time.Time{}, t.Add(1))
+		if err != nil {
+			return nil, err
+		}
+		if len(snapshots) == 0 {
+			continue
+		}
+		result = append(result, snapshots[len(snapshots)-1])
+	}
+	return result, nil
+}
+
+// Field of snapshot for time series.
+type Field string
+
+// Fields of snapshot.
+const (
+	Price   Field = "price"
+	PeRatio Field = "pe"
+	PbRatio Field = "pb"
+	Yield   Field = "yield"
+	Eps     Field = "eps"
+)
+
+// Value of the field in snapshot.
+func (f Field) Value(s Snapshot) (float64, error) {
+	switch f {
+	case Price:
+		return s.Price, nil
+	case PeRatio:
+		return s.PeRatio, nil
+	case PbRatio:
+		return s.PbRatio, nil
+	case Yield:
+		return s.Yield, nil
+	case Eps:
+		return s.Eps, nil
+	}
+	return 0, fmt.Errorf("Field is unknown: %v", f)
+}
+
+// Point of time series.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series of the field from snapshots of the symbol within range (i.e. historical PE ratio).
+func (s *Store) Series(symbol string, field Field, from, to time.Time) ([]Point, error) {
+	_, err := field.Value(Snapshot{})
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := s.Snapshots(symbol, from, to)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]Point, len(snapshots))
+	for i, snapshot := range snapshots {
+		v, _ := field.Value(snapshot)
+		points[i] = Point{Time: snapshot.Time, Value: v}
+	}
+	return points, nil
+}
+
+// readSnapshots reads snapshots of the symbol from CSV file, empty snapshots are returned if it does not exist.
+func readSnapshots(path string, symbol string) ([]Snapshot, error) {
+	records, err := readCSV(path, snapshotHeader)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]Snapshot, len(records))
+	for i, record := range records {
+		snapshots[i], err = parseSnapshot(record)
+		if err != nil {
+			return nil, fmt.Errorf("Snapshot failed to be parsed at %v:%v: %w", path, i+2, err)
+		}
+		snapshots[i].Symbol = symbol
+	}
+	return snapshots, nil
+}
+
+func parseSnapshot(record []string) (Snapshot, error) {
+	t, err := time.Parse(time.RFC3339Nano, record[0])
+	if err != nil {
+		return Snapshot{}, err
+	}
+	values, err := parseFloats(record[3:10])
+	if err != nil {
+		return Snapshot{}, err
+	}
+	lots, err := strconv.Atoi(record[10])
+	if err != nil {
+		return Snapshot{}, err
+	}
+	eps, err := strconv.ParseFloat(record[11], 64)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return Snapshot{
+		Time: t,
+		Quote: aastocks.Quote{
+			Name:         record[1],
+			Industry:     record[2],
+			Price:        values[0],
+			PrevClose:    values[1],
+			Price52WLow:  values[2],
+			Price52WHigh: values[3],
+			Yield:        values[4],
+			PeRatio:      values[5],
+			PbRatio:      values[6],
+			Lots:         lots,
+			Eps:          eps,
+		},
+	}, nil
+}
+
+// writeSnapshots writes snapshots to CSV file, symbol is not written as file is per symbol.
+func writeSnapshots(path string, snapshots []Snapshot) error {
+	records := make([][]string, len(snapshots))
+	for i, s := range snapshots {
+		records[i] = snapshotRecord(s)
+	}
+	return writeCSV(path, snapshotHeader, records)
+}
+
+func snapshotRecord(s Snapshot) []string {
+	return []string{
+		s.Time.Format(time.RFC3339Nano),
+		s.Name,
+		s.Industry,
+		formatFloat(s.Price),
+		formatFloat(s.PrevClose),
+		formatFloat(s.Price52WLow),
+		formatFloat(s.Price52WHigh),
+		formatFloat(s.Yield),
+		formatFloat(s.PeRatio),
+		formatFloat(s.PbRatio),
+		strconv.Itoa(s.Lots),
+		formatFloat(s.Eps),
+	}
+}
+
+// sameFields returns whether snapshots have the same fields stored, regardless of their time.
+func sameFields(a, b Snapshot) bool {
+	x, y := snapshotRecord(a), snapshotRecord(b)
+	for i := 1; i < len(x); i++ {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
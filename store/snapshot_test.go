@@ -0,0 +1,149 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/horacehylee/aastocks"
+)
+
+var ignoreClient = cmpopts.IgnoreUnexported(aastocks.Quote{})
+
+func TestSnapshots(t *testing.T) {
+	s := testStore(t)
+	added, err := s.AddSnapshots(
+		Snapshot{Time: date(3), Quote: aastocks.Quote{Symbol: "00006", Name: "POWER ASSETS", Industry: "Electricity Supply", Price: 44.65, PeRatio: 13.368, Yield: 0.06271, Lots: 500, Eps: 3.34}},
+		Snapshot{Time: date(1), Quote: aastocks.Quote{Symbol: "00006", PeRatio: 12}},
+		Snapshot{Time: date(2), Quote: aastocks.Quote{Symbol: "00005", PeRatio: 8}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff(3, added)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	// Snapshot of the same time is replaced
+	added, err = s.AddSnapshots(
+		Snapshot{Time: date(1), Quote: aastocks.Quote{Symbol: "00006", PeRatio: 12.5}},
+		Snapshot{Time: date(5), Quote: aastocks.Quote{Symbol: "00005", PeRatio: 9}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff = cmp.Diff(1, added)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	snapshots, err := s.Snapshots("00006", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff = cmp.Diff([]Snapshot{
+		{Time: date(1), Quote: aastocks.Quote{Symbol: "00006", PeRatio: 12.5}},
+		{Time: date(3), Quote: aastocks.Quote{Symbol: "00006", Name: "POWER ASSETS", Industry: "Electricity Supply", Price: 44.65, PeRatio: 13.368, Yield: 0.06271, Lots: 500, Eps: 3.34}},
+	}, snapshots, ignoreClient)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	testCases := []struct {
+		desc     string
+		asOf     time.Time
+		expected []Snapshot
+	}{
+		{
+			desc: "Latest",
+			asOf: date(10),
+			expected: []Snapshot{
+				{Time: date(5), Quote: aastocks.Quote{Symbol: "00005", PeRatio: 9}},
+				{Time: date(3), Quote: aastocks.Quote{Symbol: "00006", Name: "POWER ASSETS", Industry: "Electricity Supply", Price: 44.65, PeRatio: 13.368, Yield: 0.06271, Lots: 500, Eps: 3.34}},
+			},
+		},
+		{
+			desc: "Inclusive",
+			asOf: date(2),
+			expected: []Snapshot{
+				{Time: date(2), Quote: aastocks.Quote{Symbol: "00005", PeRatio: 8}},
+				{Time: date(1), Quote: aastocks.Quote{Symbol: "00006", PeRatio: 12.5}},
+			},
+		},
+		{
+			desc: "Partial",
+			asOf: date(1),
+			expected: []Snapshot{
+				{Time: date(1), Quote: aastocks.Quote{Symbol: "00006", PeRatio: 12.5}},
+			},
+		},
+		{
+			desc:     "Before",
+			asOf:     date(0),
+			expected: []Snapshot{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			snapshots, err := s.AsOf(tC.asOf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff := cmp.Diff(tC.expected, snapshots, ignoreClient)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestSeries(t *testing.T) {
+	s := testStore(t)
+	_, err := s.AddSnapshots(
+		Snapshot{Time: date(1), Quote: aastocks.Quote{Symbol: "00006", PeRatio: 12, Yield: 0.06, Eps: 3.3}},
+		Snapshot{Time: date(2), Quote: aastocks.Quote{Symbol: "00006", PeRatio: 13, Yield: 0.05, Eps: 3.4}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		desc     string
+		field    Field
+		from     time.Time
+		expected []Point
+		err      error
+	}{
+		{
+			desc:     "PeRatio",
+			field:    PeRatio,
+			expected: []Point{{Time: date(1), Value: 12}, {Time: date(2), Value: 13}},
+		},
+		{
+			desc:     "Yield",
+			field:    Yield,
+			from:     date(2),
+			expected: []Point{{Time: date(2), Value: 0.05}},
+		},
+		{
+			desc:  "UnknownField",
+			field: Field("dps"),
+			err:   fmt.Errorf("Field is unknown: dps"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			points, err := s.Series("00006", tC.field, tC.from, time.Time{})
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.expected, points)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
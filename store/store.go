@@ -0,0 +1,215 @@
+// Package store persists historical prices of quotes under local directory,
+// so that history longer than the window served by AAStocks is accumulated over time.
+//
+// Prices fetched are merged with those stored, and deduplicated by time.
+//
+//	s, err := store.Open("history")
+//	if err != nil {
+//		logger.Fatal(err)
+//	}
+//	added, err := s.Sync(ctx, "00006", aastocks.Hourly)
+//	prices, err := s.Prices("00006", aastocks.Hourly, from, to)
+//
+// Snapshots of quote fundamentals can be recorded periodically for universe, as AAStocks only serves the current ones.
+// They are keyed by update time of quotes, so that time series of PE ratio can be queried later.
+//
+//	r := store.NewRecorder(s, store.RecorderConfig{Symbols: []string{"00005", "00006"}, Interval: time.Hour})
+//	for result := range r.Run(ctx) {
+//		logger.Printf("added: %v, errors: %v\n", result.Added, result.Errors)
+//	}
+//	pe, err := s.Series("00006", store.PeRatio, from, to)
+//	universe, err := s.AsOf(date)
+//
+// Store is safe for concurrent use, but directory should not be shared by multiple processes.
+package store
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/horacehylee/aastocks"
+)
+
+var frequencyNames = map[aastocks.PriceFrequency]string{
+	aastocks.Hourly:  "hourly",
+	aastocks.Daily:   "daily",
+	aastocks.Weekly:  "weekly",
+	aastocks.Monthly: "monthly",
+}
+
+// Store of historical prices and snapshots, with files per symbol under its directory.
+type Store struct {
+	dir  string
+	opts []aastocks.Option
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// Open store under the directory, which is created if it does not exist.
+// Options are applied to quotes fetched by Sync (i.e. WithClient).
+func Open(dir string, opts ...aastocks.Option) (*Store, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{
+		dir:   dir,
+		opts:  opts,
+		locks: make(map[string]*sync.Mutex),
+	}, nil
+}
+
+// checkSymbol checks if symbol can be used as name of its directory.
+func checkSymbol(symbol string) error {
+	if symbol == "" || symbol == "." || symbol == ".." || filepath.Base(symbol) != symbol {
+		return fmt.Errorf("Symbol is invalid: %q", symbol)
+	}
+	return nil
+}
+
+// path of file storing prices of the symbol with the frequency.
+func (s *Store) path(symbol string, freq aastocks.PriceFrequency) (string, error) {
+	err := checkSymbol(symbol)
+	if err != nil {
+		return "", err
+	}
+	name, ok := frequencyNames[freq]
+	if !ok {
+		return "", fmt.Errorf("Frequency is unknown: %v", freq)
+	}
+	return filepath.Join(s.dir, symbol, name+".csv"), nil
+}
+
+// lock file of the path, and returns function to unlock it.
+func (s *Store) lock(path string) func() {
+	s.mu.Lock()
+	l, ok := s.locks[path]
+	if !ok {
+		l = &sync.Mutex{}
+		s.locks[path] = l
+	}
+	s.mu.Unlock()
+	l.Lock()
+	return l.Unlock
+}
+
+// Sync fetches historical prices of the symbol from AAStocks, and merges them with those stored.
+// Number of prices added is returned, prices replacing those of the same time are not counted.
+func (s *Store) Sync(ctx context.Context, symbol string, freq aastocks.PriceFrequency) (int, error) {
+	_, err := s.path(symbol, freq)
+	if err != nil {
+		return 0, err
+	}
+	err = ctx.Err()
+	if err != nil {
+		return 0, err
+	}
+	q, err := aastocks.Get(symbol, s.opts...)
+	if err != nil {
+		return 0, err
+	}
+	err = ctx.Err()
+	if err != nil {
+		return 0, err
+	}
+	prices, err := q.HistoricalPrices(freq)
+	if err != nil {
+		return 0, fmt.Errorf("Historical prices of %v failed to be fetched: %w", symbol, err)
+	}
+	return s.Merge(symbol, freq, prices)
+}
+
+// Merge prices into those stored for the symbol, prices of the same time are replaced.
+// Number of prices added is returned.
+func (s *Store) Merge(symbol string, freq aastocks.PriceFrequency, prices []aastocks.HistoricalPrice) (int, error) {
+	path, err := s.path(symbol, freq)
+	if err != nil {
+		return 0, err
+	}
+	if len(prices) == 0 {
+		return 0, nil
+	}
+	unlock := s.lock(path)
+	defer unlock()
+
+	stored, err := readPrices(path)
+	if err != nil {
+		return 0, err
+	}
+	merged, added := merge(stored, prices)
+	err = writePrices(path, merged)
+	if err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+// merge prices into stored prices sorted by time, prices of the same time are replaced by the later ones.
+func merge(stored, prices []aastocks.HistoricalPrice) ([]aastocks.HistoricalPrice, int) {
+	byTime := make(map[int64]int, len(stored)+len(prices))
+	merged := make([]aastocks.HistoricalPrice, 0, len(stored)+len(prices))
+	for _, p := range stored {
+		byTime[p.Time.UnixNano()] = len(merged)
+		merged = append(merged, p)
+	}
+	added := 0
+	for _, p := range prices {
+		if i, ok := byTime[p.Time.UnixNano()]; ok {
+			merged[i] = p
+			continue
+		}
+		byTime[p.Time.UnixNano()] = len(merged)
+		merged = append(merged, p)
+		added++
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+	return merged, added
+}
+
+// Prices stored for the symbol within range, from is inclusive and to is exclusive.
+// Range is not bounded by zero time. Empty prices are returned if none is stored.
+func (s *Store) Prices(symbol string, freq aastocks.PriceFrequency, from, to time.Time) ([]aastocks.HistoricalPrice, error) {
+	path, err := s.path(symbol, freq)
+	if err != nil {
+		return nil, err
+	}
+	unlock := s.lock(path)
+	defer unlock()
+
+	stored, err := readPrices(path)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]aastocks.HistoricalPrice, 0, len(stored))
+	for _, p := range stored {
+		if (!from.IsZero() && p.Time.Before(from)) || (!to.IsZero() && !p.Time.Before(to)) {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// Symbols stored, in ascending order.
+func (s *Store) Symbols() ([]string, error) {
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	symbols := make([]string, 0, len(infos))
+	for _, info := range infos {
+		if info.IsDir() {
+			symbols = append(symbols, info.Name())
+		}
+	}
+	return symbols, nil
+}
@@ -0,0 +1,269 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/horacehylee/aastocks"
+)
+
+// mockTransport serves files of urls.
+type mockTransport map[string]string
+
+func (m mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, ok := m[req.URL.String()]
+	if !ok {
+		return nil, fmt.Errorf("Handler not found for %s", req.URL)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	recorder.Write(b)
+	return recorder.Result(), nil
+}
+
+func testStore(t *testing.T) *Store {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	s, err := Open(dir, aastocks.WithClient(&http.Client{
+		Transport: mockTransport{
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006":                                                             "../testdata/detail_quote.html",
+			"http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=151511":                                                            "../testdata/detail_quote_not_found.html",
+			"http://chartdata1.internet.aastocks.com/servlet/iDataServlet/getdaily?id=00006.HK&type=24&market=1&level=1&period=56&encoding=utf8": "../testdata/historical_price_00006_daily.html",
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func date(day int) time.Time {
+	return time.Date(2020, time.August, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestMerge(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		merges   [][]aastocks.HistoricalPrice
+		added    []int
+		expected []aastocks.HistoricalPrice
+	}{
+		{
+			desc: "Sorted",
+			merges: [][]aastocks.HistoricalPrice{
+				{{Time: date(3), Close: 3}, {Time: date(1), Close: 1}},
+				{{Time: date(2), Close: 2}},
+			},
+			added: []int{2, 1},
+			expected: []aastocks.HistoricalPrice{
+				{Time: date(1), Close: 1},
+				{Time: date(2), Close: 2},
+				{Time: date(3), Close: 3},
+			},
+		},
+		{
+			desc: "Deduplicated",
+			merges: [][]aastocks.HistoricalPrice{
+				{{Time: date(1), Close: 1}, {Time: date(2), Close: 2}},
+				{{Time: date(2), Close: 2.5, Volume: 100}, {Time: date(3), Close: 3}},
+				{},
+			},
+			added: []int{2, 1, 0},
+			expected: []aastocks.HistoricalPrice{
+				{Time: date(1), Close: 1},
+				{Time: date(2), Close: 2.5, Volume: 100},
+				{Time: date(3), Close: 3},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := testStore(t)
+			added := make([]int, 0)
+			for _, prices := range tC.merges {
+				n, err := s.Merge("00006", aastocks.Daily, prices)
+				if err != nil {
+					t.Fatal(err)
+				}
+				added = append(added, n)
+			}
+			diff := cmp.Diff(tC.added, added)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			prices, err := s.Prices("00006", aastocks.Daily, time.Time{}, time.Time{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff = cmp.Diff(tC.expected, prices)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestPrices(t *testing.T) {
+	s := testStore(t)
+	_, err := s.Merge("00006", aastocks.Hourly, []aastocks.HistoricalPrice{
+		{Time: date(1), Open: 1.5, High: 2.25, Low: 1, Close: 2, Volume: 1426.486},
+		{Time: date(2)},
+		{Time: date(3)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		desc     string
+		symbol   string
+		freq     aastocks.PriceFrequency
+		from     time.Time
+		to       time.Time
+		expected []aastocks.HistoricalPrice
+		err      error
+	}{
+		{
+			desc:   "All",
+			symbol: "00006",
+			freq:   aastocks.Hourly,
+			expected: []aastocks.HistoricalPrice{
+				{Time: date(1), Open: 1.5, High: 2.25, Low: 1, Close: 2, Volume: 1426.486},
+				{Time: date(2)},
+				{Time: date(3)},
+			},
+		},
+		{
+			desc:     "Range",
+			symbol:   "00006",
+			freq:     aastocks.Hourly,
+			from:     date(2),
+			to:       date(3),
+			expected: []aastocks.HistoricalPrice{{Time: date(2)}},
+		},
+		{
+			desc:     "OtherFrequency",
+			symbol:   "00006",
+			freq:     aastocks.Daily,
+			expected: []aastocks.HistoricalPrice{},
+		},
+		{
+			desc:   "InvalidSymbol",
+			symbol: "../00006",
+			freq:   aastocks.Hourly,
+			err:    fmt.Errorf(`Symbol is invalid: "../00006"`),
+		},
+		{
+			desc:   "UnknownFrequency",
+			symbol: "00006",
+			freq:   aastocks.PriceFrequency(1),
+			err:    fmt.Errorf("Frequency is unknown: 1"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			prices, err := s.Prices(tC.symbol, tC.freq, tC.from, tC.to)
+			diff := cmp.Diff(fmt.Sprint(tC.err), fmt.Sprint(err))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+			diff = cmp.Diff(tC.expected, prices)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestSync(t *testing.T) {
+	s := testStore(t)
+	// Price older than window of AAStocks is kept
+	old := aastocks.HistoricalPrice{Time: time.Date(2010, time.January, 4, 0, 0, 0, 0, time.UTC), Close: 50}
+	_, err := s.Merge("00006", aastocks.Daily, []aastocks.HistoricalPrice{old})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := s.Sync(context.Background(), "00006", aastocks.Daily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prices, err := s.Prices("00006", aastocks.Daily, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := cmp.Diff(len(prices)-1, added)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff(old, prices[0])
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	prices, err = s.Prices("00006", aastocks.Daily, date(24), date(26))
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff = cmp.Diff([]aastocks.HistoricalPrice{
+		{Time: date(24), Open: 44.2, High: 44.7, Low: 44.15, Close: 44.2, Volume: 1426.486},
+		{Time: date(25), Open: 44.2, High: 44.65, Low: 44.05, Close: 44.65, Volume: 1709.545},
+	}, prices)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// Prices already stored are not added again
+	added, err = s.Sync(context.Background(), "00006", aastocks.Daily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff = cmp.Diff(0, added)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	symbols, err := s.Symbols()
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff = cmp.Diff([]string{"00006"}, symbols)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	_, err = os.Stat(filepath.Join(s.dir, "00006", "daily.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncError(t *testing.T) {
+	s := testStore(t)
+	_, err := s.Sync(context.Background(), "151511", aastocks.Daily)
+	if !errors.Is(err, aastocks.ErrSymbolNotFound) {
+		t.Fatalf("Error should be symbol not found, but got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.Sync(ctx, "00006", aastocks.Daily)
+	diff := cmp.Diff(context.Canceled, err, cmpopts.EquateErrors())
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
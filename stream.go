@@ -0,0 +1,247 @@
+package aastocks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PriceEvent is event of price stream, with either price or error of fetching it.
+// Quote is snapshot of the refreshed quote, with changes of its fields from the previous snapshot.
+// Changes are empty for the first snapshot of the stream.
+// Events with error of price hub carry symbol of the price only.
+// Err is omitted from JSON, as errors cannot be marshalled.
+type PriceEvent struct {
+	Price   PriceResult   `json:"price"`
+	Quote   *Quote        `json:"quote,omitempty"`
+	Changes []FieldChange `json:"changes,omitempty"`
+	Err     error         `json:"-"`
+}
+
+// FieldChange of quote, with its previous and new values.
+// Field is named as JSON field name of quote (i.e. "prev_close"), same as ParseError.
+type FieldChange struct {
+	Field    string      `json:"field"`
+	Previous interface{} `json:"previous"`
+	New      interface{} `json:"new"`
+}
+
+// DiffQuotes returns changes of fields from previous to latest quote.
+// No changes are returned if previous quote is nil.
+func DiffQuotes(previous *Quote, latest *Quote) []FieldChange {
+	changes := make([]FieldChange, 0)
+	if previous == nil || latest == nil {
+		return changes
+	}
+	fields := []struct {
+		name     string
+		previous interface{}
+		new      interface{}
+	}{
+		{"name", previous.Name, latest.Name},
+		{"industry", previous.Industry, latest.Industry},
+		{"price", previous.Price, latest.Price},
+		{"prev_close", previous.PrevClose, latest.PrevClose},
+		{"price_52w_low", previous.Price52WLow, latest.Price52WLow},
+		{"price_52w_high", previous.Price52WHigh, latest.Price52WHigh},
+		{"yield", previous.Yield, latest.Yield},
+		{"pe_ratio", previous.PeRatio, latest.PeRatio},
+		{"pb_ratio", previous.PbRatio, latest.PbRatio},
+		{"lots", previous.Lots, latest.Lots},
+		{"eps", previous.Eps, latest.Eps},
+		{"update_time", previous.UpdateTime, latest.UpdateTime},
+	}
+	for _, f := range fields {
+		if t, ok := f.previous.(time.Time); ok {
+			if t.Equal(f.new.(time.Time)) {
+				continue
+			}
+		} else if f.previous == f.new {
+			continue
+		}
+		changes = append(changes, FieldChange{
+			Field:    f.name,
+			Previous: f.previous,
+			New:      f.new,
+		})
+	}
+	return changes
+}
+
+// DropPolicy of price stream when its buffer is full.
+type DropPolicy int
+
+const (
+	// Block polling until consumer receives events from the buffer
+	Block DropPolicy = iota
+	// DropOldest event in the buffer for the latest event
+	DropOldest
+	// Coalesce events to the latest one, buffer size is ignored
+	Coalesce
+)
+
+// WithBuffer to buffer events of price stream, with policy when the buffer is full.
+// Events are unbuffered and polling is blocked by default. It applies to StreamPrices only.
+func WithBuffer(size int, policy DropPolicy) ServeOption {
+	return func(c *serveConfig) {
+		c.bufferSize = size
+		c.dropPolicy = policy
+	}
+}
+
+// OnlyChanges to emit price events only when any field of the quote is changed, errors are always emitted.
+// It applies to StreamPrices only.
+func OnlyChanges() ServeOption {
+	return func(c *serveConfig) {
+		c.onlyChanges = true
+	}
+}
+
+// PriceStream of real time prices polled from AAStocks.
+// Its channel is closed once the stream is ended by Close or context cancellation.
+type PriceStream struct {
+	events chan PriceEvent
+	policy DropPolicy
+	parent context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// StreamPrices continuously fetching latest price from AAStocks into price stream.
+// It will start goroutine to fetch real time prices, which exits when the stream is ended.
+func (q *Quote) StreamPrices(ctx context.Context, delay time.Duration, opts ...ServeOption) *PriceStream {
+	config := newServeConfig(opts)
+	size := config.bufferSize
+	if config.dropPolicy == Coalesce || (config.dropPolicy == DropOldest && size < 1) {
+		size = 1
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	s := &PriceStream{
+		events: make(chan PriceEvent, size),
+		policy: config.dropPolicy,
+		parent: ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(s.done)
+		defer close(s.events)
+		defer s.end()
+
+		var previous *Quote
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+		for {
+			select {
+			case <-streamCtx.Done():
+				return
+			case <-timer.C:
+			}
+
+			wait := config.untilOpen()
+			if wait > 0 {
+				timer.Reset(wait)
+				continue
+			}
+
+			if config.wait(streamCtx) != nil {
+				return
+			}
+			// Fetching into new quote, so that fields not found are not carried over from previous one
+			latest := q.clone()
+			event := PriceEvent{}
+			err := latest.details(streamCtx)
+			if streamCtx.Err() != nil {
+				// Fetching is aborted when the stream is ended
+				return
+			}
+			next := config.nextDelay(delay, latest, err)
+			if err != nil {
+				event.Err = err
+			} else {
+				changes := DiffQuotes(previous, latest)
+				if config.onlyChanges && previous != nil && len(changes) == 0 {
+					timer.Reset(next)
+					continue
+				}
+				previous = latest
+				snapshot := *latest
+				event = PriceEvent{
+					Price: PriceResult{
+						Price:  latest.Price,
+						Symbol: latest.Symbol,
+						Time:   latest.UpdateTime,
+					},
+					Quote:   &snapshot,
+					Changes: changes,
+				}
+			}
+			if !s.send(streamCtx, event) {
+				return
+			}
+			timer.Reset(next)
+		}
+	}()
+	return s
+}
+
+// send event according to drop policy, false is returned if the stream is ended.
+func (s *PriceStream) send(ctx context.Context, event PriceEvent) bool {
+	if s.policy == Block {
+		select {
+		case <-ctx.Done():
+			return false
+		case s.events <- event:
+			return true
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case s.events <- event:
+			return true
+		default:
+		}
+		// Buffer is full, dropping the oldest event
+		select {
+		case <-s.events:
+		default:
+		}
+	}
+}
+
+func (s *PriceStream) end() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = s.parent.Err()
+}
+
+// C returns channel of price events, which is closed when the stream is ended.
+func (s *PriceStream) C() <-chan PriceEvent {
+	return s.events
+}
+
+// Close the stream, and waits until its goroutine exits.
+func (s *PriceStream) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// Done returns channel which is closed when the stream is ended.
+func (s *PriceStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns context error if the stream is ended by its context,
+// nil is returned if it is still running or ended by Close.
+func (s *PriceStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
@@ -0,0 +1,341 @@
+package aastocks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// serveRepeat serves with handlers in order, and repeats the last handler afterwards.
+func serveRepeat(handlers ...http.HandlerFunc) http.HandlerFunc {
+	var mu sync.Mutex
+	i := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		h := handlers[i]
+		if i < len(handlers)-1 {
+			i++
+		}
+		mu.Unlock()
+		h(w, r)
+	}
+}
+
+func TestStreamPrices(t *testing.T) {
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": serveAll(
+			serveFile("testdata/detail_quote.html"), // First called with getting quote
+
+			serveFile("testdata/detail_quote.html"),
+			serveError(fmt.Errorf("testing error")),
+			serveFile("testdata/detail_quote_00006_2.html"),
+		),
+	})
+	quote, err := Get("00006", WithClient(mock.client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := quote.StreamPrices(ctx, 10*time.Millisecond)
+
+	expected := []PriceEvent{
+		{
+			Price: PriceResult{
+				Symbol: "00006",
+				Price:  44.65,
+				Time:   time.Date(2020, time.August, 25, 21, 18, 38, 0, time.UTC),
+			},
+		},
+		{
+			Err: fmt.Errorf("Failed to fetch quote details: 500 Internal Server Error"),
+		},
+		{
+			Price: PriceResult{
+				Symbol: "00006",
+				Price:  44.4,
+				Time:   time.Date(2020, time.August, 29, 00, 55, 31, 0, time.UTC),
+			},
+		},
+	}
+	events := make([]PriceEvent, 0)
+	timeout := time.After(2 * time.Second)
+Loop:
+	for {
+		select {
+		case e, ok := <-stream.C():
+			if !ok {
+				break Loop
+			}
+			events = append(events, e)
+			if len(events) == len(expected) {
+				cancel()
+			}
+		case <-timeout:
+			t.Fatalf("Timeout is triggered, expect channel to be closed")
+		}
+	}
+
+	diff := cmp.Diff(expected, events, equateErrorMessage, cmpopts.IgnoreFields(PriceEvent{}, "Quote", "Changes"))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+	diff = cmp.Diff(context.Canceled, stream.Err(), equateErrorMessage)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestStreamPricesClose(t *testing.T) {
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": serveRepeat(
+			serveFile("testdata/detail_quote.html"),
+		),
+	})
+	quote, err := Get("00006", WithClient(mock.client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := quote.StreamPrices(context.Background(), 10*time.Millisecond)
+	<-stream.C()
+	stream.Close()
+
+	select {
+	case <-stream.Done():
+	default:
+		t.Fatalf("stream should be done after closed")
+	}
+	for range stream.C() {
+		// Draining events until channel is closed
+	}
+	if stream.Err() != nil {
+		t.Fatalf("No error should be expected when stream is closed: %v", stream.Err())
+	}
+}
+
+func TestStreamPricesCloseWhileFetching(t *testing.T) {
+	fetching := make(chan struct{})
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": serveRepeat(
+			serveFile("testdata/detail_quote.html"),
+			func(w http.ResponseWriter, r *http.Request) {
+				// Stalling until the request is aborted
+				close(fetching)
+				<-r.Context().Done()
+			},
+		),
+	})
+	quote, err := Get("00006", WithClient(mock.client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := quote.StreamPrices(context.Background(), 10*time.Millisecond)
+	<-fetching
+	closed := make(chan struct{})
+	go func() {
+		stream.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Timeout is triggered, expect stream to be closed while fetching")
+	}
+}
+
+func TestStreamPricesBuffer(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		opts  []ServeOption
+		price float64
+		// polling is blocked by slow consumer, so that latest price is never served
+		blocked bool
+	}{
+		{
+			desc:    "Block",
+			price:   44.65,
+			blocked: true,
+		},
+		{
+			desc:  "DropOldest",
+			opts:  []ServeOption{WithBuffer(1, DropOldest)},
+			price: 44.4,
+		},
+		{
+			desc:  "Coalesce",
+			opts:  []ServeOption{WithBuffer(0, Coalesce)},
+			price: 44.4,
+		},
+	}
+	mock := mockClient()
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			// Latest price is served twice, so event of its first fetch must be sent already
+			served := make(chan struct{})
+			var once sync.Once
+			latest := serveFile("testdata/detail_quote_00006_2.html")
+			mock.set(map[string]http.HandlerFunc{
+				"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": serveRepeat(
+					serveFile("testdata/detail_quote.html"), // First called with getting quote
+
+					serveFile("testdata/detail_quote.html"),
+					serveFile("testdata/detail_quote.html"),
+					latest,
+					func(w http.ResponseWriter, r *http.Request) {
+						once.Do(func() { close(served) })
+						latest(w, r)
+					},
+				),
+			})
+			quote, err := Get("00006", WithClient(mock.client))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stream := quote.StreamPrices(context.Background(), 10*time.Millisecond, tC.opts...)
+			defer stream.Close()
+
+			// Slow consumer, polling is blocked without dropping events
+			if tC.blocked {
+				time.Sleep(100 * time.Millisecond)
+			} else {
+				select {
+				case <-served:
+				case <-time.After(2 * time.Second):
+					t.Fatalf("Timeout is triggered, expect latest price to be served")
+				}
+			}
+			e := <-stream.C()
+			diff := cmp.Diff(tC.price, e.Price.Price)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestStreamPricesOnlyChanges(t *testing.T) {
+	mock := mockClient()
+	mock.set(map[string]http.HandlerFunc{
+		"GET-http://www.aastocks.com/en/stocks/quote/detail-quote.aspx?symbol=00006": serveRepeat(
+			serveFile("testdata/detail_quote.html"), // First called with getting quote
+
+			serveFile("testdata/detail_quote.html"),
+			serveFile("testdata/detail_quote.html"),
+			serveFile("testdata/detail_quote.html"),
+			serveFile("testdata/detail_quote_00006_2.html"),
+		),
+	})
+	quote, err := Get("00006", WithClient(mock.client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := quote.StreamPrices(context.Background(), 10*time.Millisecond, OnlyChanges())
+	defer stream.Close()
+
+	expected := []PriceEvent{
+		{
+			Price: PriceResult{
+				Symbol: "00006",
+				Price:  44.65,
+				Time:   time.Date(2020, time.August, 25, 21, 18, 38, 0, time.UTC),
+			},
+			Quote: &Quote{
+				Symbol:       "00006",
+				Name:         "POWER ASSETS",
+				Industry:     "Electricity Supply",
+				Price:        44.65,
+				PrevClose:    44.2,
+				Price52WLow:  41.6,
+				Price52WHigh: 58.5,
+				Yield:        0.06271,
+				PeRatio:      13.368,
+				PbRatio:      1.115,
+				Eps:          3.34,
+				Lots:         500,
+				UpdateTime:   time.Date(2020, time.August, 25, 21, 18, 38, 0, time.UTC),
+			},
+			Changes: []FieldChange{},
+		},
+		{
+			Price: PriceResult{
+				Symbol: "00006",
+				Price:  44.4,
+				Time:   time.Date(2020, time.August, 29, 00, 55, 31, 0, time.UTC),
+			},
+			Quote: &Quote{
+				Symbol:       "00006",
+				Name:         "POWER ASSETS",
+				Industry:     "Electricity Supply",
+				Price:        44.4,
+				PrevClose:    44.15,
+				Price52WLow:  41.6,
+				Price52WHigh: 58.5,
+				Yield:        0.06306,
+				PeRatio:      13.293,
+				PbRatio:      1.108,
+				Eps:          3.34,
+				Lots:         500,
+				UpdateTime:   time.Date(2020, time.August, 29, 00, 55, 31, 0, time.UTC),
+			},
+			Changes: []FieldChange{
+				{Field: "price", Previous: 44.65, New: 44.4},
+				{Field: "prev_close", Previous: 44.2, New: 44.15},
+				{Field: "yield", Previous: 0.06271, New: 0.06306},
+				{Field: "pe_ratio", Previous: 13.368, New: 13.293},
+				{Field: "pb_ratio", Previous: 1.115, New: 1.108},
+				{
+					Field:    "update_time",
+					Previous: time.Date(2020, time.August, 25, 21, 18, 38, 0, time.UTC),
+					New:      time.Date(2020, time.August, 29, 00, 55, 31, 0, time.UTC),
+				},
+			},
+		},
+	}
+	events := make([]PriceEvent, 0)
+	timeout := time.After(2 * time.Second)
+	for len(events) < len(expected) {
+		select {
+		case e := <-stream.C():
+			events = append(events, e)
+		case <-timeout:
+			t.Fatalf("Timeout is triggered, expect changed price to be emitted")
+		}
+	}
+
+	diff := cmp.Diff(expected, events, equateErrorMessage, cmpopts.IgnoreUnexported(Quote{}))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func TestPriceEventJSON(t *testing.T) {
+	event := PriceEvent{
+		Price:   PriceResult{Price: 44.4, Symbol: "00006", Time: time.Date(2020, time.August, 26, 16, 8, 0, 0, time.UTC)},
+		Changes: []FieldChange{{Field: "price", Previous: 44.65, New: 44.4}},
+		Err:     fmt.Errorf("testing error"),
+	}
+	b, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"price":{"price":44.4,"symbol":"00006","time":"2020-08-26T16:08:00Z"},"changes":[{"field":"price","previous":44.65,"new":44.4}]}`
+	diff := cmp.Diff(expected, string(b))
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}